@@ -0,0 +1,80 @@
+package apply
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// cmd represents the apply command.
+var cmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Reconciles deployed network with its configuration",
+	Long: `Reconciles deployed network with its configuration
+
+Compares network configuration with the cluster state and installs missing
+orderer, peers, channels, anchor peers and chaincodes in dependency order.
+Components which are already deployed are skipped.
+
+Examples:
+  # Apply network configuration:
+  fabnctl apply -f ./network-config.yaml
+
+  # Apply network configuration on ARM-based cluster:
+  fabnctl apply --arch=arm64 -f ./network-config.yaml
+
+  # Apply network configuration enrolling nodes identities against organizations Fabric CA:
+  fabnctl apply -f ./network-config.yaml --enroll`,
+
+	RunE: shared.WithHandleErrors(apply),
+}
+
+func init() {
+	cmd.Flags().StringP("config", "f", "./network-config.yaml",
+		"Network structure config file path required for deployment",
+	)
+	cmd.Flags().Int("parallelism", 4, "Maximum number of peers processed at the same time")
+	cmd.Flags().Bool("continue-on-error", false,
+		"Proceed with the rest of peers when some of them fail, reporting failures in the end",
+	)
+	cmd.Flags().Bool("enroll", false,
+		"Register and enroll nodes identities against organizations Fabric CA instead of using cryptogen ones",
+	)
+}
+
+func apply(cmd *cobra.Command, _ []string) error {
+	var logger = term.NewLogger()
+
+	netConfig, err := shared.LoadNetworkConfig(cmd, "config")
+	if err != nil {
+		return err
+	}
+
+	network, err := fabric.NewNetwork(netConfig,
+		fabric.WithArchFlag(cmd.Flags(), "arch"),
+		fabric.WithDomainFlag(cmd.Flags(), "domain"),
+		fabric.WithCryptoStoreFlag(cmd.Flags(), "crypto-store"),
+		fabric.WithCustomDeployChartsFlag(cmd.Flags(), "charts"),
+		fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+		fabric.WithParallelismFlag(cmd.Flags(), "parallelism"),
+		fabric.WithContinueOnErrorFlag(cmd.Flags(), "continue-on-error"),
+		fabric.WithEnrollmentFlag(cmd.Flags(), "enroll"),
+		fabric.WithLogger(logger),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if err = network.Apply(cmd.Context()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddTo adds apply command to `root` cobra.Command.
+func AddTo(root *cobra.Command) {
+	root.AddCommand(cmd)
+}
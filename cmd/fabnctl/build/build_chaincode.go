@@ -52,7 +52,7 @@ If nothing passed docker auth config would be searched for credentials by given
 	)
 	chaincodeCmd.Flags().Bool("push", false, "Push image to remote registry")
 	chaincodeCmd.Flags().Bool("ssh", true, "Build over SSH")
-	chaincodeCmd.Flags().String("host", kube.Config.Host, "Remote host for SSH connection (default: get from .kube config)")
+	chaincodeCmd.Flags().String("host", kubeHost(), "Remote host for SSH connection (default: get from .kube config)")
 	chaincodeCmd.Flags().Int("port", 22, "Remote port for SSH connection")
 	chaincodeCmd.Flags().StringP("user", "u", os.Getenv("USER"), "User from remote host for SSH connection")
 	chaincodeCmd.Flags().StringSliceP("ignore", "i", nil, "File patterns to skip during transfer")
@@ -114,3 +114,13 @@ func buildChaincode(cmd *cobra.Command, chaincodeName, srcPath string) error {
 
 	return nil
 }
+
+// kubeHost returns host of the Kubernetes API server from the kubeconfig,
+// or empty string when kubeconfig is not available.
+func kubeHost() string {
+	if kube.Config == nil {
+		return ""
+	}
+
+	return kube.Config.Host
+}
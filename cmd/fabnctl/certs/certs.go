@@ -0,0 +1,29 @@
+package certs
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+)
+
+// cmd represents the certs command.
+var cmd = &cobra.Command{
+	Use:   "certs",
+	Short: "Provides methods for inspecting and renewing network certificates",
+	Long: `Provides methods for inspecting and renewing network certificates.
+
+Examples:
+  # Check certificates expiring within 30 days
+  fabnctl certs check -d example.com
+
+  # Rotate peer TLS key pair
+  fabnctl certs rotate -d example.com --component peer0.org1`,
+}
+
+func init() {
+	shared.AddConfirmFlag(cmd)
+}
+
+// AddTo adds certs commands to `root` cobra.Command.
+func AddTo(root *cobra.Command) {
+	root.AddCommand(cmd)
+}
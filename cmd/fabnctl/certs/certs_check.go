@@ -0,0 +1,125 @@
+package certs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// checkCmd represents the certs check command.
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Reports expiration of the network certificates",
+	Long: `Reports expiration of the network certificates
+
+Scans certificates in the crypto store (see --crypto-store) and in transport secrets of peers and orderers,
+reporting their subjects, SANs, issuers and days to expiry. Fails when any of them expires within the threshold.
+
+Examples:
+  # Check certificates expiring within 30 days:
+  fabnctl certs check -d example.com
+
+  # Check certificates expiring within 90 days:
+  fabnctl certs check -d example.com --threshold 90
+
+  # Print certificates as JSON:
+  fabnctl certs check -d example.com --output json`,
+
+	RunE: shared.WithHandleErrors(func(cmd *cobra.Command, _ []string) error {
+		return check(cmd)
+	}),
+}
+
+func init() {
+	cmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().Int("threshold", 30, "Number of days before expiration certificates are reported as expiring")
+	checkCmd.Flags().String("output", "text", "Output format. One of: text, json")
+}
+
+func check(cmd *cobra.Command) error {
+	var logger = term.NewLogger()
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse 'output' parameter", term.ErrInvalidArgs)
+	}
+
+	if output != "text" && output != "json" {
+		return fmt.Errorf("%w: unsupported output format '%s'", term.ErrInvalidArgs, output)
+	}
+
+	certificates, err := fabric.NewCertificates(
+		fabric.WithExpiryThresholdFlag(cmd.Flags(), "threshold"),
+		fabric.WithSharedOptionsForCerts(
+			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithCryptoStoreFlag(cmd.Flags(), "crypto-store"),
+			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithLogger(logger),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := certificates.Check(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		if err = json.NewEncoder(cmd.OutOrStdout()).Encode(statuses); err != nil {
+			return fmt.Errorf("failed to encode certificates: %w", err)
+		}
+	} else {
+		printCertificates(cmd.OutOrStdout(), statuses)
+	}
+
+	var expiring int
+
+	for _, status := range statuses {
+		if status.Expiring {
+			expiring++
+		}
+	}
+
+	if expiring > 0 {
+		return fmt.Errorf("%d of %d certificates are expired or expire soon", expiring, len(statuses))
+	}
+
+	return nil
+}
+
+func printCertificates(w io.Writer, statuses []fabric.CertificateStatus) {
+	var table = tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, "SOURCE\tSUBJECT\tSANS\tISSUER\tEXPIRES\tDAYS LEFT")
+
+	for _, s := range statuses {
+		var (
+			sans = strings.Join(s.SANs, ", ")
+			days = fmt.Sprint(s.DaysLeft)
+		)
+
+		if len(sans) == 0 {
+			sans = "-"
+		}
+
+		if s.Expiring {
+			days += " ✘"
+		}
+
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Source, s.Subject, sans, s.Issuer, s.NotAfter.Format("2006-01-02"), days,
+		)
+	}
+
+	_ = table.Flush()
+}
@@ -0,0 +1,103 @@
+package certs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// rotateCmd represents the certs rotate command.
+var rotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Rotates TLS key pair of the network component",
+	Long: `Rotates TLS key pair of the network component
+
+Re-issues TLS certificate of the peer or orderer with the TLS CA generated by cryptogen,
+replaces it in the artifacts volume and in its transport secret, then restarts the component.
+Orderer keeps its private key, so that it is still recognized by other Raft consenters.
+
+Examples:
+  # Rotate peer TLS key pair:
+  fabnctl certs rotate -d example.com --component peer0.org1
+
+  # Rotate orderer TLS certificate:
+  fabnctl certs rotate -d example.com --component orderer`,
+
+	RunE: shared.WithHandleErrors(func(cmd *cobra.Command, _ []string) error {
+		return rotate(cmd)
+	}),
+}
+
+func init() {
+	cmd.AddCommand(rotateCmd)
+
+	rotateCmd.Flags().String("component", "",
+		"Network component, either peer as {peer}.{org} or orderer hostname (required)",
+	)
+
+	_ = rotateCmd.MarkFlagRequired("component")
+}
+
+func rotate(cmd *cobra.Command) error {
+	var logger = term.NewLogger()
+
+	component, err := cmd.Flags().GetString("component")
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse required parameter 'component': %s", term.ErrInvalidArgs, err)
+	}
+
+	if !shared.Confirm(cmd, logger, fmt.Sprintf("Rotate TLS key pair of '%s' and restart it?", component)) {
+		return nil
+	}
+
+	var hostnames = shared.OrdererHostnames()
+
+	if len(hostnames) == 0 {
+		hostnames = []string{viper.GetString("fabric.orderer_hostname_name")}
+	}
+
+	for _, hostname := range hostnames {
+		if hostname != component {
+			continue
+		}
+
+		orderer, err := fabric.NewOrderer(hostname,
+			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithCryptoStoreFlag(cmd.Flags(), "crypto-store"),
+			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithLogger(logger),
+		)
+		if err != nil {
+			return err
+		}
+
+		return orderer.RotateTLS(cmd.Context())
+	}
+
+	var parts = strings.SplitN(component, ".", 2)
+
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return fmt.Errorf("%w: component '%s' is neither orderer hostname, nor peer as {peer}.{org}",
+			term.ErrInvalidArgs, component,
+		)
+	}
+
+	peer, err := fabric.NewPeer(parts[1], parts[0],
+		fabric.WithSharedOptionsForPeer(
+			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithCryptoStoreFlag(cmd.Flags(), "crypto-store"),
+			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithLogger(logger),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	return peer.RotateTLS(cmd.Context())
+}
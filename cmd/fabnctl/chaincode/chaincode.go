@@ -0,0 +1,24 @@
+package chaincode
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// cmd represents the cc command.
+var cmd = &cobra.Command{
+	Use:   "cc",
+	Short: "Provides methods for managing chaincode artifacts",
+	Long: `Provides methods for managing chaincode artifacts.
+
+Examples:
+  # Validate chaincode CouchDB indexes
+  fabnctl cc indexes validate --source ./assets
+
+  # Show CouchDB indexes of chaincode on peers
+  fabnctl cc indexes status assets -d example.com -C supply-channel -o org1 -p peer0`,
+}
+
+// AddTo adds cc commands to `root` cobra.Command.
+func AddTo(root *cobra.Command) {
+	root.AddCommand(cmd)
+}
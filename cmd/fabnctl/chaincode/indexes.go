@@ -0,0 +1,20 @@
+package chaincode
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// indexesCmd represents the cc indexes command.
+var indexesCmd = &cobra.Command{
+	Use:   "indexes",
+	Short: "Provides methods for managing chaincode CouchDB indexes",
+	Long: `Provides methods for managing chaincode CouchDB indexes.
+
+Indexes are defined in chaincode 'META-INF/statedb/couchdb/indexes' directory for the state database,
+and in 'META-INF/statedb/couchdb/collections/<collection>/indexes' ones for private data collections.
+They are packaged along with chaincode and created by peers once it gets installed.`,
+}
+
+func init() {
+	cmd.AddCommand(indexesCmd)
+}
@@ -0,0 +1,159 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// statusCmd represents the cc indexes status command.
+var statusCmd = &cobra.Command{
+	Use:   "status [name]",
+	Short: "Shows CouchDB indexes of the chaincode databases on organization peers",
+	Long: `Shows CouchDB indexes of the chaincode databases on organization peers
+
+Queries CouchDB of each peer for indexes existing in the chaincode state database on the channel,
+as well as in its private data collections databases. When chaincode source is given,
+indexes defined in it, but not found in CouchDB, are reported as missing.
+
+Examples:
+  # Show chaincode indexes:
+  fabnctl cc indexes status assets -d example.com -C supply-channel -o org1 -p peer0 -o org2 -p peer0
+
+  # Compare chaincode indexes with ones defined in its source:
+  fabnctl cc indexes status assets -d example.com -C supply-channel -o org1 -p peer0 --source ./assets
+
+  # Print chaincode indexes as JSON:
+  fabnctl cc indexes status assets -d example.com -C supply-channel -o org1 -p peer0 --output json`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("%q requires exactly 1 argument: [name] (chaincode name)",
+				cmd.CommandPath())
+		}
+		return nil
+	},
+	RunE: shared.WithHandleErrors(func(cmd *cobra.Command, args []string) error {
+		return indexesStatus(cmd, args[0])
+	}),
+}
+
+func init() {
+	indexesCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().StringArrayP("org", "o", nil,
+		"Organization owning chaincode. Can be used multiple times to pass list of organizations (required)")
+	statusCmd.Flags().StringArrayP("peers", "p", nil,
+		"Peer hostname. Can be used multiply time to pass list of peers by (required)")
+	statusCmd.Flags().StringP("channel", "C", "", "Channel name (required)")
+	statusCmd.Flags().String("source", "", "Chaincode source path, which indexes are compared with existing ones")
+	statusCmd.Flags().String("output", "text", "Output format. One of: text, json")
+	statusCmd.Flags().Int("parallelism", 4, "Maximum number of peers queried at the same time")
+
+	_ = statusCmd.MarkFlagRequired("org")
+	_ = statusCmd.MarkFlagRequired("peers")
+	_ = statusCmd.MarkFlagRequired("channel")
+}
+
+func indexesStatus(cmd *cobra.Command, name string) error {
+	var logger = term.NewLogger()
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse 'output' parameter", term.ErrInvalidArgs)
+	}
+
+	if output != "text" && output != "json" {
+		return fmt.Errorf("%w: unsupported output format '%s'", term.ErrInvalidArgs, output)
+	}
+
+	source, err := cmd.Flags().GetString("source")
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse 'source' parameter", term.ErrInvalidArgs)
+	}
+
+	chaincode, err := fabric.NewChaincode(name,
+		fabric.WithChannelFlag(cmd.Flags(), "channel"),
+		fabric.WithChaincodePeersFlag(cmd.Flags(), "org", "peers"),
+		fabric.WithSharedOptionsForChaincode(
+			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithParallelismFlag(cmd.Flags(), "parallelism"),
+			fabric.WithLogger(logger),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := chaincode.IndexesStatus(cmd.Context(), source)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		if err = json.NewEncoder(cmd.OutOrStdout()).Encode(statuses); err != nil {
+			return fmt.Errorf("failed to encode indexes status: %w", err)
+		}
+	} else {
+		printIndexesStatus(cmd.OutOrStdout(), statuses)
+	}
+
+	for _, status := range statuses {
+		if len(status.Error) != 0 {
+			return fmt.Errorf("failed to query indexes on '%s.%s' peer: %s", status.Peer, status.Org, status.Error)
+		}
+	}
+
+	return nil
+}
+
+func printIndexesStatus(w io.Writer, statuses []fabric.PeerIndexes) {
+	var table = tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, "ORG\tPEER\tDATABASE\tINDEX\tDDOC\tFIELDS\tSTATUS")
+
+	for _, p := range statuses {
+		if len(p.Error) != 0 {
+			_, _ = fmt.Fprintf(table, "%s\t%s\t-\t-\t-\t-\terror: %s\n", p.Org, p.Peer, p.Error)
+			continue
+		}
+
+		if len(p.Databases) == 0 {
+			_, _ = fmt.Fprintf(table, "%s\t%s\t-\t-\t-\t-\tno chaincode databases\n", p.Org, p.Peer)
+		}
+
+		for _, db := range p.Databases {
+			if len(db.Indexes) == 0 && len(db.Missing) == 0 {
+				_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t-\t-\t-\tno indexes\n", p.Org, p.Peer, db.Database)
+			}
+
+			for _, index := range db.Indexes {
+				_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t✔\n",
+					p.Org, p.Peer, db.Database, index.Name, dash(index.DDoc), strings.Join(index.Fields, ", "),
+				)
+			}
+
+			for _, name := range db.Missing {
+				_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\t-\t-\t✘ missing\n", p.Org, p.Peer, db.Database, name)
+			}
+		}
+	}
+
+	_ = table.Flush()
+}
+
+func dash(value string) string {
+	if len(value) == 0 {
+		return "-"
+	}
+
+	return value
+}
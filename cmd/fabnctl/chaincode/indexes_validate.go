@@ -0,0 +1,79 @@
+package chaincode
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// validateCmd represents the cc indexes validate command.
+var validateCmd = &cobra.Command{
+	Use:   "validate [name]",
+	Short: "Validates chaincode CouchDB indexes against CouchDB index schema",
+	Long: `Validates chaincode CouchDB indexes against CouchDB index schema
+
+Each index file must be JSON object with 'index.fields' listing indexed fields either by name
+or along with sort direction, and optional 'ddoc', 'name', 'type' (only 'json' one) and 'index.partial_filter_selector'.
+The same validation is performed on packaging, so that peers won't reject chaincode on its installation.
+
+Examples:
+  # Validate chaincode CouchDB indexes:
+  fabnctl cc indexes validate --source ./assets
+
+  # Validate named chaincode CouchDB indexes:
+  fabnctl cc indexes validate assets --source ./chaincodes/assets`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return fmt.Errorf("%q accepts at most 1 argument: [name] (chaincode name)",
+				cmd.CommandPath())
+		}
+		return nil
+	},
+	RunE: shared.WithHandleErrors(func(cmd *cobra.Command, args []string) error {
+		var name string
+
+		if len(args) != 0 {
+			name = args[0]
+		}
+
+		return validateIndexes(cmd, name)
+	}),
+}
+
+func init() {
+	indexesCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().String("source", ".", "Chaincode source path")
+}
+
+func validateIndexes(cmd *cobra.Command, name string) error {
+	var logger = term.NewLogger()
+
+	source, err := cmd.Flags().GetString("source")
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse 'source' parameter", term.ErrInvalidArgs)
+	}
+
+	// Chaincode is named after its source directory, unless name is given:
+	if len(name) == 0 {
+		if abs, err := filepath.Abs(source); err == nil {
+			name = filepath.Base(abs)
+		}
+	}
+
+	chaincode, err := fabric.NewChaincode(name,
+		fabric.WithSharedOptionsForChaincode(
+			fabric.WithLogger(logger),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	return chaincode.ValidateIndexes(source)
+}
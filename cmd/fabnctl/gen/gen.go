@@ -2,6 +2,7 @@ package gen
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
 )
 
 // cmd represents the gen command.
@@ -17,6 +18,7 @@ Examples:
   # Generate connection config:
   fabnctl gen connection -f ./network-config.yaml
 `,
+	PersistentPreRunE: shared.ValidateNetworkConfig,
 }
 
 func init() {
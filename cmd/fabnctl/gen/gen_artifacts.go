@@ -3,18 +3,18 @@ package gen
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
-	"os/exec"
 	"path"
 
 	"github.com/mittwald/go-helm-client"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/cryptostore"
 	"github.com/timoth-y/fabnctl/pkg/helm"
 	"github.com/timoth-y/fabnctl/pkg/kube"
 	"github.com/timoth-y/fabnctl/pkg/term"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 )
 
@@ -59,6 +59,22 @@ func genArtifacts(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("%w: failed to parse 'config' parameter", term.ErrInvalidArgs)
 	}
 
+	store, err := cryptostore.New(shared.CryptoStore, shared.Domain, shared.Namespace)
+	if err != nil {
+		return fmt.Errorf("%w: %s", term.ErrInvalidArgs, err)
+	}
+
+	// Crypto material is downloaded into temporary directory, unless it's kept in the local one:
+	if _, local := store.(*cryptostore.LocalStore); !local {
+		if cryptoConfigDir, err = ioutil.TempDir("", "fabnctl-crypto-"); err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+
+		defer os.RemoveAll(cryptoConfigDir)
+
+		cryptoConfigDir = path.Join(cryptoConfigDir, "crypto-config")
+	}
+
 	// Preparing additional values for chart installation:
 	if shared.TargetArch == "arm64" {
 		armValues, err := helm.ValuesFromFile(path.Join(shared.ChartsPath, "artifacts", "values.arm64.yaml"))
@@ -113,30 +129,18 @@ func genArtifacts(cmd *cobra.Command, _ []string) error {
 
 	// Deploying 'artifacts.wait' job,
 	// that will span pod for hooking to PV with generated earlier artifacts:
-	if err = exec.Command("kubectl", "apply",
-		"-n", shared.Namespace,
-		"-f", path.Join(shared.ChartsPath, "artifacts", "artifacts-wait-job.yaml"),
-	).Run(); err != nil {
+	if _, err = kube.CreateJobFromFile(cmd.Context(),
+		path.Join(shared.ChartsPath, "artifacts", "artifacts-wait-job.yaml"),
+		shared.Namespace,
+	); err != nil {
 		return fmt.Errorf("failed to deploy 'artifacts.wait' pod: %w", err)
 	}
 
 	// Cleaning 'artifacts.wait' job and pod:
 	defer func(cmd *cobra.Command) {
-		if err = kube.Client.BatchV1().Jobs(shared.Namespace).DeleteCollection(cmd.Context(),
-			metav1.DeleteOptions{}, metav1.ListOptions{
-				LabelSelector: "fabnctl/cid=artifacts.wait",
-			}); err != nil {
+		if err := kube.DeleteJobs(cmd.Context(), "fabnctl/cid=artifacts.wait", shared.Namespace); err != nil {
 			cmd.PrintErrln(fmt.Errorf("failed to delete artifacts.wait job: %w", err))
 		}
-
-		var zero int64 = 0
-
-		if err = kube.Client.CoreV1().Pods(shared.Namespace).DeleteCollection(cmd.Context(),
-			metav1.DeleteOptions{GracePeriodSeconds: &zero}, metav1.ListOptions{
-				LabelSelector: "job-name=artifacts.wait",
-			}); err != nil {
-			cmd.PrintErrln(fmt.Errorf("failed to delete artifacts.wait pod: %w", err))
-		}
 	}(cmd)
 
 	// Waiting for 'artifacts.wait' pod readiness:
@@ -152,28 +156,32 @@ func genArtifacts(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Downloading generated 'crypto-config' artifacts on local file system:
-	if _, err = os.Stat(cryptoConfigDir); !os.IsNotExist(err) {
-		exec.Command("rm", "-rf", cryptoConfigDir)
+	if err = os.RemoveAll(cryptoConfigDir); err != nil {
+		return fmt.Errorf("failed to clean up '%s' directory: %w", cryptoConfigDir, err)
 	}
 
-	if err = exec.Command("kubectl", "cp",
-		fmt.Sprintf("%s:crypto-config", waitPodName),
-		cryptoConfigDir,
-	).Run(); err != nil {
+	if err = kube.CopyFromPod(cmd.Context(), waitPodName, shared.Namespace,
+		"crypto-config", cryptoConfigDir,
+	); err != nil {
 		return fmt.Errorf("failed to copy crypto-config: %w", err)
 	}
 
-	logger.Successf("Files 'crypto-config' has been downloaded to %s", cryptoConfigDir)
+	if _, local := store.(*cryptostore.LocalStore); local {
+		logger.Successf("Files 'crypto-config' has been downloaded to %s", cryptoConfigDir)
+	} else if err = cryptostore.Import(cmd.Context(), store, cryptoConfigDir); err != nil {
+		return fmt.Errorf("failed to import crypto-config into '%s' crypto store: %w", shared.CryptoStore, err)
+	} else {
+		logger.Successf("Files 'crypto-config' has been imported into '%s' crypto store", shared.CryptoStore)
+	}
 
 	// Downloading generated 'channel-artifacts' artifacts on local file system:
-	if _, err = os.Stat(channelArtifactsDir); !os.IsNotExist(err) {
-		exec.Command("rm", "-rf", channelArtifactsDir)
+	if err = os.RemoveAll(channelArtifactsDir); err != nil {
+		return fmt.Errorf("failed to clean up '%s' directory: %w", channelArtifactsDir, err)
 	}
 
-	if err = exec.Command("kubectl", "cp",
-		fmt.Sprintf("%s:channel-artifacts", waitPodName),
-		channelArtifactsDir,
-	).Run(); err != nil {
+	if err = kube.CopyFromPod(cmd.Context(), waitPodName, shared.Namespace,
+		"channel-artifacts", channelArtifactsDir,
+	); err != nil {
 		return fmt.Errorf("failed to copy channel-artifacts: %w", err)
 	}
 
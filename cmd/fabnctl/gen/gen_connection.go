@@ -5,12 +5,15 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
 	"text/template"
 
 	"github.com/Masterminds/sprig"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/cryptostore"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
 	"github.com/timoth-y/fabnctl/pkg/model"
 	"github.com/timoth-y/fabnctl/pkg/term"
 	"github.com/timoth-y/fabnctl/pkg/util"
@@ -30,6 +33,9 @@ Examples:
   # Generate connection.yaml with custom properties:
   fabnctl gen connection -f ./network-config.yaml -n edge-device -c supply-channel -o org1 /
     -x userID=user1,logging=debug ./artifacts
+
+  # Generate connection.yaml referencing identities enrolled with 'fabnctl identity enroll' command:
+  fabnctl gen connection -f ./network-config.yaml -c supply-channel -o org1 --identity app1 ./artifacts
 `,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -57,6 +63,9 @@ func init() {
 	connectionCmd.Flags().StringToStringP("x-properties", "x", nil,
 		"Custom extension properties that would be added to config as x-{key}: {values}",
 	)
+	connectionCmd.Flags().StringArray("identity", nil,
+		"Enrolled identity of the owner organization referenced in config. Can be used multiple times",
+	)
 
 	_ = connectionCmd.MarkFlagRequired("org")
 	_ = connectionCmd.MarkFlagRequired("channel")
@@ -72,6 +81,7 @@ func genConnection(cmd *cobra.Command, artifactsPath string) error {
 		desc        string
 		version     float64
 		xProperties map[string]string
+		identities  []string
 		netConfig   model.NetworkConfig
 	)
 
@@ -104,6 +114,10 @@ func genConnection(cmd *cobra.Command, artifactsPath string) error {
 		return fmt.Errorf("%w: failed to parse 'version' parameter", term.ErrInvalidArgs)
 	}
 
+	if identities, err = cmd.Flags().GetStringArray("identity"); err != nil {
+		return fmt.Errorf("%w: failed to parse 'identity' parameter", term.ErrInvalidArgs)
+	}
+
 	if len(name) == 0 {
 		name = fmt.Sprintf("%s-connection", ownerOrg)
 	}
@@ -122,15 +136,28 @@ func genConnection(cmd *cobra.Command, artifactsPath string) error {
 		return fmt.Errorf("failed to decode config found on path: %s: %w", configPath, err)
 	}
 
+	// Certificates are read from the artifacts path, unless crypto material is kept in another store:
+	store, err := cryptostore.New(shared.CryptoStore, netConfig.Domain, shared.Namespace)
+	if err != nil {
+		return fmt.Errorf("%w: %s", term.ErrInvalidArgs, err)
+	}
+
+	var readCert = func(name string) ([]byte, string, error) {
+		if _, local := store.(*cryptostore.LocalStore); !local {
+			cert, err := store.Read(cmd.Context(), name)
+			return cert, name, err
+		}
+
+		var certPath = path.Join(artifactsPath, cryptostore.LocalDir(netConfig.Domain), name)
+		cert, err := ioutil.ReadFile(certPath)
+		return cert, certPath, err
+	}
+
 	// Enrich network config with TLS CA certificates:
-	var certPath = path.Join(
-		artifactsPath,
-		fmt.Sprintf(".crypto-config.%s", netConfig.Domain),
+	if cert, certPath, err := readCert(path.Join(
 		"ordererOrganizations", netConfig.Domain,
 		"tlsca", fmt.Sprintf("tlsca.%s-cert.pem", netConfig.Domain),
-	)
-
-	if cert, err := ioutil.ReadFile(certPath); err != nil {
+	)); err != nil {
 		cmd.Printf(
 			"%s TLS certificate for '%s' orderer not found on path '%s'\n",
 			viper.GetString("cli.warning_emoji"), netConfig.Orderer.Name, certPath,
@@ -156,18 +183,14 @@ func genConnection(cmd *cobra.Command, artifactsPath string) error {
 	netConfig.Organizations = channelOrgs
 
 	if org := netConfig.GetOrganization(ownerOrg); org == nil {
-		return fmt.Errorf("Organization with ID '%s' isn't a part of '%s' channel consortium", org, channel)
+		return fmt.Errorf("Organization with ID '%s' isn't a part of '%s' channel consortium", ownerOrg, channel)
 	}
 
 	for i, org := range netConfig.Organizations {
-		certPath = path.Join(
-			artifactsPath,
-			fmt.Sprintf(".crypto-config.%s", netConfig.Domain),
+		if cert, certPath, err := readCert(path.Join(
 			"peerOrganizations", fmt.Sprintf("%s.%s", org.Hostname, netConfig.Domain),
 			"tlsca", fmt.Sprintf("tlsca.%s.%s-cert.pem", org.Hostname, netConfig.Domain),
-		)
-
-		if cert, err := ioutil.ReadFile(certPath); err != nil {
+		)); err != nil {
 			cmd.Printf(
 				"%s TLS certificate for '%s' organization not found on path '%s'\n",
 				viper.GetString("cli.warning_emoji"), org.Name, certPath,
@@ -176,14 +199,10 @@ func genConnection(cmd *cobra.Command, artifactsPath string) error {
 			netConfig.Organizations[i].TLSCert = string(cert)
 		}
 
-		certPath = path.Join(
-			artifactsPath,
-			fmt.Sprintf(".crypto-config.%s", netConfig.Domain),
+		if cert, certPath, err := readCert(path.Join(
 			"peerOrganizations", fmt.Sprintf("%s.%s", org.Hostname, netConfig.Domain),
 			"ca", fmt.Sprintf("ca.%s.%s-cert.pem", org.Hostname, netConfig.Domain),
-		)
-
-		if cert, err := ioutil.ReadFile(certPath); err != nil {
+		)); err != nil {
 			cmd.Printf(
 				"%s TLS certificate for '%s' organization's CA not found on path '%s'\n",
 				viper.GetString("cli.warning_emoji"), org.Name, certPath,
@@ -193,6 +212,55 @@ func genConnection(cmd *cobra.Command, artifactsPath string) error {
 		}
 	}
 
+	// Resolve enrolled identities of the owner organization from the crypto store.
+	// Local store files are referenced by their paths, while the rest are embedded into config:
+	type ConnectionIdentity struct {
+		Name     string
+		CertPath string
+		KeyPath  string
+		CertPEM  string
+		KeyPEM   string
+	}
+
+	var users []ConnectionIdentity
+
+	for _, identity := range identities {
+		var (
+			mspPath = fabric.IdentityMSPPath(ownerOrg, identity)
+			user    = ConnectionIdentity{Name: identity}
+		)
+
+		cert, certPath, err := readCert(path.Join(mspPath, "signcerts", "cert.pem"))
+		if err != nil {
+			return fmt.Errorf(
+				"identity '%s' isn't enrolled: '%s' not found, use 'fabnctl identity enroll' command first",
+				identity, certPath,
+			)
+		}
+
+		key, keyPath, err := readCert(path.Join(mspPath, "keystore", "priv_sk"))
+		if err != nil {
+			return fmt.Errorf(
+				"identity '%s' isn't enrolled: '%s' not found, use 'fabnctl identity enroll' command first",
+				identity, keyPath,
+			)
+		}
+
+		if _, local := store.(*cryptostore.LocalStore); local {
+			if user.CertPath, err = filepath.Abs(certPath); err != nil {
+				return fmt.Errorf("failed to resolve MSP path of '%s' identity: %w", identity, err)
+			}
+
+			if user.KeyPath, err = filepath.Abs(keyPath); err != nil {
+				return fmt.Errorf("failed to resolve MSP path of '%s' identity: %w", identity, err)
+			}
+		} else {
+			user.CertPEM, user.KeyPEM = string(cert), string(key)
+		}
+
+		users = append(users, user)
+	}
+
 	// Values for template rendering:
 	type ConnectionValues struct {
 		Name string
@@ -202,6 +270,7 @@ func genConnection(cmd *cobra.Command, artifactsPath string) error {
 		Channel string
 		model.NetworkConfig
 		XProperties map[string]string
+		Users []ConnectionIdentity
 	}
 
 	values := ConnectionValues{
@@ -212,6 +281,7 @@ func genConnection(cmd *cobra.Command, artifactsPath string) error {
 		Channel:       channel,
 		NetworkConfig: netConfig,
 		XProperties:   xProperties,
+		Users:         users,
 	}
 
 	var (
@@ -0,0 +1,42 @@
+package identity
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+)
+
+// cmd represents the identity command.
+var cmd = &cobra.Command{
+	Use:   "identity",
+	Short: "Provides methods for managing users and client identities of organizations",
+	Long: `Provides methods for managing users and client identities of organizations.
+
+Identities are registered with and enrolled against organization Fabric CA deployed along with its peers.
+Enrolled MSP folders are stored in the crypto store under 'identities/$ORG/$NAME/msp' paths.
+
+Examples:
+  # Register client identity
+  fabnctl identity register app1 -d example.com -o org1
+
+  # Enroll identity and publish it as Kubernetes secret
+  fabnctl identity enroll app1 -d example.com -o org1 --secret $SECRET --publish
+
+  # Revoke identity
+  fabnctl identity revoke app1 -d example.com -o org1
+
+  # List organization identities
+  fabnctl identity list -d example.com -o org1`,
+}
+
+func init() {
+	cmd.PersistentFlags().StringP("org", "o", "", "Organization owning identity (required)")
+
+	_ = cmd.MarkPersistentFlagRequired("org")
+
+	shared.AddConfirmFlag(cmd)
+}
+
+// AddTo adds identity commands to `root` cobra.Command.
+func AddTo(root *cobra.Command) {
+	root.AddCommand(cmd)
+}
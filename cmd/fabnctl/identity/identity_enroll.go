@@ -0,0 +1,76 @@
+package identity
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// enrollCmd represents the identity enroll command.
+var enrollCmd = &cobra.Command{
+	Use:   "enroll [name]",
+	Short: "Enrolls identity against organization Fabric CA",
+	Long: `Enrolls identity against organization Fabric CA
+
+Enrolled MSP is stored in the crypto store under 'identities/$ORG/$NAME/msp' path,
+so that it could be referenced in connection profiles with 'fabnctl gen connection --identity' command.
+It can be optionally published as Kubernetes secret labelled with 'fabnctl/cid=identity'.
+
+Examples:
+  # Enroll identity:
+  fabnctl identity enroll app1 -d example.com -o org1 --secret $SECRET
+
+  # Enroll identity and publish it as Kubernetes secret:
+  fabnctl identity enroll app1 -d example.com -o org1 --secret $SECRET --publish`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("%q requires exactly 1 argument: [name] (identity name)",
+				cmd.CommandPath())
+		}
+		return nil
+	},
+	RunE: shared.WithHandleErrors(func(cmd *cobra.Command, args []string) error {
+		return enroll(cmd, args[0])
+	}),
+}
+
+func init() {
+	cmd.AddCommand(enrollCmd)
+
+	enrollCmd.Flags().String("secret", "", "Enrollment secret of the identity (required)")
+	enrollCmd.Flags().Bool("publish", false, "Publish enrolled identity as Kubernetes secret")
+
+	_ = enrollCmd.MarkFlagRequired("secret")
+}
+
+func enroll(cmd *cobra.Command, name string) error {
+	var logger = term.NewLogger()
+
+	org, err := cmd.Flags().GetString("org")
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse required parameter 'org' (organization): %s", term.ErrInvalidArgs, err)
+	}
+
+	identities, err := fabric.NewIdentities(org,
+		fabric.WithEnrollmentSecretFlag(cmd.Flags(), "secret"),
+		fabric.WithPublishSecretFlag(cmd.Flags(), "publish"),
+		fabric.WithSharedOptionsForIdentity(
+			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithLogger(logger),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, err = identities.Enroll(cmd.Context(), name); err != nil {
+		return err
+	}
+
+	return nil
+}
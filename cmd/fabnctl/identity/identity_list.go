@@ -0,0 +1,119 @@
+package identity
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/ca"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// listCmd represents the identity list command.
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists identities registered with organization Fabric CA",
+	Long: `Lists identities registered with organization Fabric CA
+
+Examples:
+  # List identities:
+  fabnctl identity list -d example.com -o org1
+
+  # Print identities as JSON:
+  fabnctl identity list -d example.com -o org1 --output json`,
+
+	RunE: shared.WithHandleErrors(func(cmd *cobra.Command, _ []string) error {
+		return list(cmd)
+	}),
+}
+
+func init() {
+	cmd.AddCommand(listCmd)
+
+	listCmd.Flags().String("output", "text", "Output format. One of: text, json")
+}
+
+func list(cmd *cobra.Command) error {
+	var logger = term.NewLogger()
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse 'output' parameter", term.ErrInvalidArgs)
+	}
+
+	if output != "text" && output != "json" {
+		return fmt.Errorf("%w: unsupported output format '%s'", term.ErrInvalidArgs, output)
+	}
+
+	org, err := cmd.Flags().GetString("org")
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse required parameter 'org' (organization): %s", term.ErrInvalidArgs, err)
+	}
+
+	identities, err := fabric.NewIdentities(org,
+		fabric.WithSharedOptionsForIdentity(
+			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithLogger(logger),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	infos, err := identities.List(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		if err = json.NewEncoder(cmd.OutOrStdout()).Encode(infos); err != nil {
+			return fmt.Errorf("failed to encode identities: %w", err)
+		}
+
+		return nil
+	}
+
+	printIdentities(cmd.OutOrStdout(), infos)
+
+	return nil
+}
+
+func printIdentities(w io.Writer, infos []ca.IdentityInfo) {
+	var table = tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, "NAME\tTYPE\tAFFILIATION\tMAX ENROLLMENTS\tATTRIBUTES")
+
+	for _, info := range infos {
+		var attrs []string
+
+		for _, attr := range info.Attributes {
+			if strings.HasPrefix(attr.Name, "hf.") {
+				continue
+			}
+
+			attrs = append(attrs, fmt.Sprintf("%s=%s", attr.Name, attr.Value))
+		}
+
+		var affiliation = info.Affiliation
+		if len(affiliation) == 0 {
+			affiliation = "-"
+		}
+
+		var attributes = strings.Join(attrs, ", ")
+		if len(attributes) == 0 {
+			attributes = "-"
+		}
+
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%d\t%s\n",
+			info.Name, info.Type, affiliation, info.MaxEnrollments, attributes,
+		)
+	}
+
+	_ = table.Flush()
+}
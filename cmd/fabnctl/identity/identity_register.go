@@ -0,0 +1,85 @@
+package identity
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// registerCmd represents the identity register command.
+var registerCmd = &cobra.Command{
+	Use:   "register [name]",
+	Short: "Registers identity with organization Fabric CA",
+	Long: `Registers identity with organization Fabric CA
+
+Prints enrollment secret of the registered identity, which is required for its enrollment.
+Attributes are included into enrollment certificates, so that they could be used for attribute-based access control.
+
+Examples:
+  # Register client identity:
+  fabnctl identity register app1 -d example.com -o org1
+
+  # Register admin identity with predefined secret:
+  fabnctl identity register ops -d example.com -o org1 --type admin --secret $SECRET
+
+  # Register identity with attributes included into its certificates:
+  fabnctl identity register app1 -d example.com -o org1 --attr role=auditor,region=eu`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("%q requires exactly 1 argument: [name] (identity name)",
+				cmd.CommandPath())
+		}
+		return nil
+	},
+	RunE: shared.WithHandleErrors(func(cmd *cobra.Command, args []string) error {
+		return register(cmd, args[0])
+	}),
+}
+
+func init() {
+	cmd.AddCommand(registerCmd)
+
+	registerCmd.Flags().String("type", "client", "Identity type. One of: client, admin, peer, orderer")
+	registerCmd.Flags().String("affiliation", "", "Identity affiliation (default is the root affiliation)")
+	registerCmd.Flags().StringToString("attr", nil, "Identity attributes included into its certificates")
+	registerCmd.Flags().Int("max-enrollments", 0, "Maximum number of identity enrollments (default is CA's one)")
+	registerCmd.Flags().String("secret", "", "Enrollment secret (default is generated by CA)")
+}
+
+func register(cmd *cobra.Command, name string) error {
+	var logger = term.NewLogger()
+
+	org, err := cmd.Flags().GetString("org")
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse required parameter 'org' (organization): %s", term.ErrInvalidArgs, err)
+	}
+
+	identities, err := fabric.NewIdentities(org,
+		fabric.WithIdentityTypeFlag(cmd.Flags(), "type"),
+		fabric.WithAffiliationFlag(cmd.Flags(), "affiliation"),
+		fabric.WithIdentityAttributesFlag(cmd.Flags(), "attr"),
+		fabric.WithMaxEnrollmentsFlag(cmd.Flags(), "max-enrollments"),
+		fabric.WithEnrollmentSecretFlag(cmd.Flags(), "secret"),
+		fabric.WithSharedOptionsForIdentity(
+			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithLogger(logger),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	secret, err := identities.Register(cmd.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Enrollment secret of '%s' identity: %s", name, secret)
+
+	return nil
+}
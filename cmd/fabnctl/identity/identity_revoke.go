@@ -0,0 +1,73 @@
+package identity
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// revokeCmd represents the identity revoke command.
+var revokeCmd = &cobra.Command{
+	Use:   "revoke [name]",
+	Short: "Revokes identity with organization Fabric CA",
+	Long: `Revokes identity with organization Fabric CA
+
+All certificates of the identity are revoked, so that it can no longer enroll.
+Kubernetes secret the identity was published as is removed as well.
+
+Examples:
+  # Revoke identity:
+  fabnctl identity revoke app1 -d example.com -o org1
+
+  # Revoke compromised identity without confirmation:
+  fabnctl identity revoke app1 -d example.com -o org1 --reason keycompromise -y`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("%q requires exactly 1 argument: [name] (identity name)",
+				cmd.CommandPath())
+		}
+		return nil
+	},
+	RunE: shared.WithHandleErrors(func(cmd *cobra.Command, args []string) error {
+		return revoke(cmd, args[0])
+	}),
+}
+
+func init() {
+	cmd.AddCommand(revokeCmd)
+
+	revokeCmd.Flags().String("reason", "",
+		"Revocation reason as defined in RFC 5280, e.g. keycompromise, superseded or cessationofoperation",
+	)
+}
+
+func revoke(cmd *cobra.Command, name string) error {
+	var logger = term.NewLogger()
+
+	org, err := cmd.Flags().GetString("org")
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse required parameter 'org' (organization): %s", term.ErrInvalidArgs, err)
+	}
+
+	identities, err := fabric.NewIdentities(org,
+		fabric.WithRevocationReasonFlag(cmd.Flags(), "reason"),
+		fabric.WithSharedOptionsForIdentity(
+			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithLogger(logger),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	if !shared.Confirm(cmd, logger, fmt.Sprintf("Revoke '%s' identity of '%s' organization?", name, org)) {
+		return nil
+	}
+
+	return identities.Revoke(cmd.Context(), name)
+}
@@ -0,0 +1,143 @@
+package inspect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+	"sigs.k8s.io/yaml"
+)
+
+// cmd represents the inspect command.
+var cmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Provides methods for decoding channel blocks and artifacts",
+	Long: `Provides methods for decoding channel blocks and artifacts.
+
+Examples:
+  # Decode the newest block of the channel:
+  fabnctl inspect block -d example.com -c supply-channel
+
+  # Decode current channel configuration:
+  fabnctl inspect channel-config -d example.com -c supply-channel -o org1 -p peer0
+
+  # Decode local channel artifact:
+  fabnctl inspect block -d example.com supply-channel.tx`,
+}
+
+func init() {
+	cmd.PersistentFlags().StringP("channel", "c", "", "Channel name")
+	cmd.PersistentFlags().StringP("org", "o", "", "Organization which peer CLI pod is used to fetch blocks")
+	cmd.PersistentFlags().StringP("peer", "p", "", "Peer which CLI pod is used to fetch blocks")
+	cmd.PersistentFlags().String("output", "json", "Output format. One of: json, yaml")
+}
+
+// AddTo adds inspect commands to `root` cobra.Command.
+func AddTo(root *cobra.Command) {
+	root.AddCommand(cmd)
+}
+
+// fetchBlock fetches channel block specified by `target` through the organization peer CLI pod.
+func fetchBlock(cmd *cobra.Command, target string) ([]byte, error) {
+	var (
+		err         error
+		channelName string
+		org, peer   string
+		options     = []fabric.ChannelOption{
+			fabric.WithSharedOptionsForChannel(
+				fabric.WithDomainFlag(cmd.Flags(), "domain"),
+				fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+				fabric.WithOrderers(shared.OrdererHostnames()...),
+			),
+		}
+	)
+
+	if channelName, err = cmd.Flags().GetString("channel"); err != nil || len(channelName) == 0 {
+		return nil, fmt.Errorf("%w: 'channel' parameter is required to fetch block", term.ErrInvalidArgs)
+	}
+
+	if org, err = cmd.Flags().GetString("org"); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse 'org' parameter", term.ErrInvalidArgs)
+	}
+
+	if peer, err = cmd.Flags().GetString("peer"); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse 'peer' parameter", term.ErrInvalidArgs)
+	}
+
+	if len(org) != 0 {
+		if len(peer) == 0 {
+			peer = "peer0"
+		}
+
+		options = append(options, fabric.WithChannelPeers(org, peer))
+	}
+
+	channel, err := fabric.NewChannel(channelName, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return channel.FetchBlock(cmd.Context(), target)
+}
+
+// readArtifact reads local block or transaction file by its `name`,
+// which is looked up in the '.channel-artifacts.<domain>' directory when not found by the given path.
+func readArtifact(name string) ([]byte, string, error) {
+	var filePath = name
+
+	if _, err := os.Stat(filePath); os.IsNotExist(err) && len(shared.Domain) != 0 {
+		filePath = path.Join(fmt.Sprintf(".channel-artifacts.%s", shared.Domain), name)
+	}
+
+	payload, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read artifact from path: %s: %w", filePath, err)
+	}
+
+	return payload, filePath, nil
+}
+
+// printDecoded prints decoded `value` in the output format passed with 'output' flag.
+// Raw JSON payloads are printed as is, so that nested config is rendered same as with other values.
+func printDecoded(cmd *cobra.Command, value interface{}) error {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse 'output' parameter", term.ErrInvalidArgs)
+	}
+
+	var payload []byte
+
+	if raw, ok := value.([]byte); ok {
+		payload = raw
+	} else if payload, err = json.Marshal(value); err != nil {
+		return fmt.Errorf("failed to encode decoded payload: %w", err)
+	}
+
+	switch output {
+	case "json":
+		var buffer bytes.Buffer
+		if err = json.Indent(&buffer, payload, "", "  "); err != nil {
+			return fmt.Errorf("failed to format decoded payload: %w", err)
+		}
+
+		buffer.WriteByte('\n')
+		_, err = buffer.WriteTo(cmd.OutOrStdout())
+	case "yaml":
+		if payload, err = yaml.JSONToYAML(payload); err != nil {
+			return fmt.Errorf("failed to format decoded payload: %w", err)
+		}
+
+		_, err = cmd.OutOrStdout().Write(payload)
+	default:
+		return fmt.Errorf("%w: unsupported output format '%s'", term.ErrInvalidArgs, output)
+	}
+
+	return err
+}
@@ -0,0 +1,100 @@
+package inspect
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/blocks"
+	"github.com/timoth-y/fabnctl/pkg/channelconfig"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// blockCmd represents the inspect block command.
+var blockCmd = &cobra.Command{
+	Use:   "block [file]",
+	Short: "Decodes channel block or local block/transaction file",
+	Long: `Decodes channel block or local block/transaction file
+
+Block is printed with its transactions, creator MSP IDs, endorsements, validation codes and read/write sets.
+When file is given, it is looked up in '.channel-artifacts.<domain>' directory if not found by the given path.
+
+Examples:
+  # Decode the newest block of the channel using any joined peer CLI pod:
+  fabnctl inspect block -d example.com -c supply-channel
+
+  # Decode block by its number using certain peer CLI pod:
+  fabnctl inspect block -d example.com -c supply-channel -o org1 -p peer0 --block 5 --output yaml
+
+  # Decode channel creation transaction from channel artifacts:
+  fabnctl inspect block -d example.com supply-channel.tx`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: shared.WithHandleErrors(inspectBlock),
+}
+
+func init() {
+	cmd.AddCommand(blockCmd)
+
+	blockCmd.Flags().String("block", "newest", "Block to fetch. One of: newest, oldest, config or block number")
+}
+
+func inspectBlock(cmd *cobra.Command, args []string) error {
+	if len(args) != 0 {
+		payload, filePath, err := readArtifact(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Channel artifacts transactions are config update envelopes, rather than blocks:
+		if path.Ext(filePath) == ".tx" {
+			envelope, err := channelconfig.DecodeEnvelope(payload)
+			if err != nil {
+				return err
+			}
+
+			tx, err := blocks.DescribeEnvelope(envelope)
+			if err != nil {
+				return err
+			}
+
+			return printDecoded(cmd, tx)
+		}
+
+		return printBlock(cmd, payload)
+	}
+
+	target, err := cmd.Flags().GetString("block")
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse 'block' parameter", term.ErrInvalidArgs)
+	}
+
+	if _, err := strconv.ParseUint(target, 10, 64); err != nil &&
+		target != "newest" && target != "oldest" && target != "config" {
+		return fmt.Errorf("%w: unsupported block '%s', must be either newest, oldest, config or block number",
+			term.ErrInvalidArgs, target,
+		)
+	}
+
+	payload, err := fetchBlock(cmd, target)
+	if err != nil {
+		return err
+	}
+
+	return printBlock(cmd, payload)
+}
+
+func printBlock(cmd *cobra.Command, payload []byte) error {
+	block, err := blocks.Decode(payload)
+	if err != nil {
+		return err
+	}
+
+	info, err := blocks.Describe(block)
+	if err != nil {
+		return err
+	}
+
+	return printDecoded(cmd, info)
+}
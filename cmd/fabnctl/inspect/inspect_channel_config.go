@@ -0,0 +1,58 @@
+package inspect
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/channelconfig"
+)
+
+// channelConfigCmd represents the inspect channel-config command.
+var channelConfigCmd = &cobra.Command{
+	Use:   "channel-config [file]",
+	Short: "Decodes current channel configuration or the one of the local config block",
+	Long: `Decodes current channel configuration or the one of the local config block
+
+Configuration is printed in the same format as 'configtxlator proto_decode --type common.Config' does.
+
+Examples:
+  # Decode current channel configuration:
+  fabnctl inspect channel-config -d example.com -c supply-channel
+
+  # Decode system channel configuration from the genesis block:
+  fabnctl inspect channel-config -d example.com genesis.block --output yaml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: shared.WithHandleErrors(inspectChannelConfig),
+}
+
+func init() {
+	cmd.AddCommand(channelConfigCmd)
+}
+
+func inspectChannelConfig(cmd *cobra.Command, args []string) error {
+	var (
+		payload []byte
+		err     error
+	)
+
+	if len(args) != 0 {
+		payload, _, err = readArtifact(args[0])
+	} else {
+		payload, err = fetchBlock(cmd, "config")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	config, err := channelconfig.ConfigFromBlockBytes(payload)
+	if err != nil {
+		return err
+	}
+
+	decoded, err := channelconfig.MarshalJSON(config)
+	if err != nil {
+		return err
+	}
+
+	return printDecoded(cmd, decoded)
+}
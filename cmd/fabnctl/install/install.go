@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
 )
 
 // cmd represents the deployment command.
@@ -24,7 +25,14 @@ Examples:
 
   # Deploy chaincode (Smart Contracts package)
   fabnctl deploy cc -d example.com -C supply-channel --cc_name assets -o org1 -p peer0 -o org2 -p peer0 /contracts`,
-	RunE: install,
+	PersistentPreRunE: shared.ValidateNetworkConfig,
+	RunE:              install,
+}
+
+func init() {
+	cmd.PersistentFlags().StringP("config", "f", "./network-config.yaml",
+		"Network structure config file path, which is validated before deployment",
+	)
 }
 
 func install(cmd *cobra.Command, args []string) error {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
 	"github.com/timoth-y/fabnctl/pkg/fabric"
 	"github.com/timoth-y/fabnctl/pkg/term"
@@ -26,7 +27,23 @@ Examples:
   fabnctl deploy cc assets -d example.com -C supply-channel -o org1 -p peer0 -r my-registry.io -f docker_files/assets_new.Dockerfile
 
   # Set custom version for new chaincode or it's update:
-  fabnctl deploy cc assets -d example.com -C supply-channel -o org1 -p peer0 -v 2.2
+  fabnctl deploy cc assets -d example.com -C supply-channel -o org1 -p peer0 -v 2.2.0
+
+  # Take version from the git tag or commit of chaincode source:
+  fabnctl deploy cc assets -d example.com -C supply-channel -o org1 -p peer0 --source ./assets --version-from-git
+
+  # Set endorsement policy, private data collections and require chaincode initialization:
+  fabnctl deploy cc assets -d example.com -C supply-channel -o org1 -p peer0 -o org2 -p peer0 \
+    --policy "AND('org1.member','org2.member')" --collections-config ./collections.json --init-required
+
+  # Install prebuilt chaincode package produced by 'package cc' command:
+  fabnctl deploy cc assets -d example.com -C supply-channel -o org1 -p peer0 -v 1.2.0 --package assets_1.2.0.tar.gz
+
+  # Install Go chaincode source package, which is built and launched by peer without chaincode chart:
+  fabnctl deploy cc assets -d example.com -C supply-channel -o org1 -p peer0 --type golang --path ./chaincodes/assets
+
+  # Continue installation interrupted after some of its steps were completed:
+  fabnctl deploy cc assets -d example.com -C supply-channel -o org1 -p peer0 -o org2 -p peer0 --resume
 
   # Disable image rebuild and automatic update:
   fabnctl deploy cc assets -d example.com -C supply-channel -o org1 -p peer0 --rebuild=false --update=false`,
@@ -52,10 +69,46 @@ func init() {
 		"Peer hostname. Can be used multiply time to pass list of peers by (required)")
 	chaincodeCmd.Flags().StringP("channel", "C", "", "Channel name (required)")
 	chaincodeCmd.Flags().String("image", "", "Chaincode image")
-	chaincodeCmd.Flags().String("source", "", "Chaincode source path")
-	chaincodeCmd.Flags().Float64P("version", "v", 1.0,
-		"Version for chaincode commit. If not set and update will be required it will be automatically incremented",
+	chaincodeCmd.Flags().String("source", "", "Chaincode source path (alias: --path)")
+	chaincodeCmd.Flags().String("type", "external",
+		"Chaincode package type: 'external' for chaincode service deployed with Helm chart, "+
+			"or 'golang', 'node', 'java' for source package built and launched by peer",
+	)
+	chaincodeCmd.Flags().String("package", "",
+		"Prebuilt chaincode package file produced by 'package cc' command, which is installed instead of packaging one",
+	)
+	chaincodeCmd.Flags().StringP("version", "v", "1.0",
+		"Version for chaincode commit, either semantic or free-form one. "+
+			"If not set and update will be required it will be automatically incremented",
+	)
+	chaincodeCmd.Flags().Bool("version-from-git", false,
+		"Take version from the git tag or commit of the chaincode source (requires --source)",
 	)
+	chaincodeCmd.Flags().String("policy", "",
+		"Endorsement signature policy, e.g. \"AND('org1.member','org2.member')\", or channel config policy reference, "+
+			"e.g. /Channel/Application/Endorsement (default channel endorsement policy)",
+	)
+	chaincodeCmd.Flags().String("collections-config", "", "Path to private data collections config JSON file")
+	chaincodeCmd.Flags().Int("parallelism", 4, "Maximum number of peers processed at the same time")
+	chaincodeCmd.Flags().Bool("continue-on-error", false,
+		"Proceed with the rest of peers when some of them fail, reporting failures in the end",
+	)
+	chaincodeCmd.Flags().Bool("init-required", false,
+		"Require chaincode 'Init' function to be invoked before any other transaction, it will be invoked after commit",
+	)
+	chaincodeCmd.Flags().Bool("tls", false,
+		"Secure connection between peers and chaincode service with mutual TLS, using organization TLS CA",
+	)
+	chaincodeCmd.Flags().Bool("resume", false,
+		"Continue unfinished installation run from its last completed step, reusing recorded version and sequence",
+	)
+
+	chaincodeCmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "path" {
+			name = "source"
+		}
+		return pflag.NormalizedName(name)
+	})
 
 	_ = chaincodeCmd.MarkFlagRequired("org")
 	_ = chaincodeCmd.MarkFlagRequired("peers")
@@ -74,8 +127,12 @@ func installChaincode(cmd *cobra.Command, name string) error {
 		fabric.WithSharedOptionsForChaincode(
 			fabric.WithArchFlag(cmd.Flags(), "arch"),
 			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithCryptoStoreFlag(cmd.Flags(), "crypto-store"),
 			fabric.WithCustomDeployChartsFlag(cmd.Flags(), "charts"),
 			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithOrderers(shared.OrdererHostnames()...),
+			fabric.WithParallelismFlag(cmd.Flags(), "parallelism"),
+			fabric.WithContinueOnErrorFlag(cmd.Flags(), "continue-on-error"),
 			fabric.WithLogger(logger),
 		),
 	)
@@ -87,7 +144,15 @@ func installChaincode(cmd *cobra.Command, name string) error {
 	if err = chaincode.Install(cmd.Context(),
 		fabric.WithImageFlag(cmd.Flags(), "image"),
 		fabric.WithSourceFlag(cmd.Flags(), "source"),
+		fabric.WithPackageFlag(cmd.Flags(), "package"),
+		fabric.WithChaincodeTypeFlag(cmd.Flags(), "type"),
 		fabric.WithVersionFlag(cmd.Flags(), "version"),
+		fabric.WithVersionFromGitFlag(cmd.Flags(), "version-from-git"),
+		fabric.WithEndorsementPolicyFlag(cmd.Flags(), "policy"),
+		fabric.WithCollectionsConfigFlag(cmd.Flags(), "collections-config"),
+		fabric.WithInitRequiredFlag(cmd.Flags(), "init-required"),
+		fabric.WithTLSFlag(cmd.Flags(), "tls"),
+		fabric.WithResumeFlag(cmd.Flags(), "resume"),
 	); err != nil {
 		return err
 	}
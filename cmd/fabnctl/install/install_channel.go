@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
 	"github.com/timoth-y/fabnctl/pkg/fabric"
 	"github.com/timoth-y/fabnctl/pkg/term"
 )
@@ -34,6 +35,10 @@ func init() {
 		"Peer hostname. Can be used multiply time to pass list of peers by (required)",
 	)
 	channelCmd.Flags().StringP("channel", "c", "", "Channel name (required)")
+	channelCmd.Flags().Int("parallelism", 4, "Maximum number of peers processed at the same time")
+	channelCmd.Flags().Bool("continue-on-error", false,
+		"Proceed with the rest of peers when some of them fail, reporting failures in the end",
+	)
 
 	_ = channelCmd.MarkFlagRequired("org")
 	_ = channelCmd.MarkFlagRequired("peer")
@@ -58,6 +63,9 @@ func installChannel(cmd *cobra.Command, _ []string) error {
 			fabric.WithDomainFlag(cmd.Flags(), "domain"),
 			fabric.WithCustomDeployChartsFlag(cmd.Flags(), "charts"),
 			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithOrderers(shared.OrdererHostnames()...),
+			fabric.WithParallelismFlag(cmd.Flags(), "parallelism"),
+			fabric.WithContinueOnErrorFlag(cmd.Flags(), "continue-on-error"),
 			fabric.WithLogger(logger),
 		),
 	)
@@ -14,34 +14,64 @@ var ordererCmd = &cobra.Command{
 	Short: "Performs deployment sequence of the Fabric orderer service",
 	Long: `Performs deployment sequence of the Fabric orderer service
 
+Each Raft consenter passed with 'orderer' flag or declared in the network config
+is deployed with its own TLS secrets and ingress.
+
 Examples:
   # Deploy orderer:
-  fabnctl deploy orderer -d example.com`,
+  fabnctl deploy orderer -d example.com
+
+  # Deploy Raft cluster declared in network config:
+  fabnctl deploy orderer -d example.com -f ./network-config.yaml
+
+  # Deploy Raft cluster of three orderers:
+  fabnctl deploy orderer -d example.com --orderer orderer0 --orderer orderer1 --orderer orderer2
+
+  # Deploy orderer along with CA and enroll its identities against it:
+  fabnctl deploy orderer -d example.com --enroll`,
 
 	RunE: shared.WithHandleErrors(installOrderer),
 }
 
 func init() {
 	cmd.AddCommand(ordererCmd)
+
+	ordererCmd.Flags().Bool("enroll", false,
+		"Deploy CA along with orderer, then register and enroll orderer and its TLS identities against it",
+	)
 }
 
 func installOrderer(cmd *cobra.Command, _ []string) error {
-	var logger = term.NewLogger()
-
-	orderer, err := fabric.NewOrderer(viper.GetString("fabric.orderer_hostname_name"),
-		fabric.WithArchFlag(cmd.Flags(), "arch"),
-		fabric.WithDomainFlag(cmd.Flags(), "domain"),
-		fabric.WithCustomDeployChartsFlag(cmd.Flags(), "charts"),
-		fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
-		fabric.WithLogger(logger),
+	var (
+		logger    = term.NewLogger()
+		hostnames = shared.OrdererHostnames()
 	)
 
-	if err != nil {
-		return err
+	if len(hostnames) == 0 {
+		hostnames = []string{viper.GetString("fabric.orderer_hostname_name")}
 	}
 
-	if err = orderer.Install(cmd.Context()); err != nil {
-		return err
+	for _, hostname := range hostnames {
+		orderer, err := fabric.NewOrderer(hostname,
+			fabric.WithArchFlag(cmd.Flags(), "arch"),
+			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithCryptoStoreFlag(cmd.Flags(), "crypto-store"),
+			fabric.WithCustomDeployChartsFlag(cmd.Flags(), "charts"),
+			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithEnrollmentFlag(cmd.Flags(), "enroll"),
+			fabric.WithLogger(logger),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if err = orderer.Install(cmd.Context()); err != nil {
+			return err
+		}
+
+		logger.NewLine()
 	}
+
 	return nil
 }
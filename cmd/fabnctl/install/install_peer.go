@@ -20,7 +20,10 @@ Examples:
   fabnctl deploy peer -d example.com -o org1 -p peer0
 
   # Deploy peer but skip CA service installation:
-  fabnctl deploy peer -d example.com -o org1 -p peer0 --withCA=false`,
+  fabnctl deploy peer -d example.com -o org1 -p peer0 --withCA=false
+
+  # Deploy peer enrolling its identities against organization CA:
+  fabnctl deploy peer -d example.com -o org1 -p peer0 --enroll`,
 
 	RunE: shared.WithHandleErrors(installPeer),
 }
@@ -33,6 +36,9 @@ func init() {
 	peerCmd.Flags().Bool("withCA", true,
 		"Deploy CA service along with peer",
 	)
+	peerCmd.Flags().Bool("enroll", false,
+		"Register and enroll peer, its TLS and organization admin identities against organization CA",
+	)
 
 	peerCmd.MarkFlagRequired("org")
 }
@@ -59,8 +65,11 @@ func installPeer(cmd *cobra.Command, args []string) error {
 		fabric.WithSharedOptionsForPeer(
 			fabric.WithArchFlag(cmd.Flags(), "arch"),
 			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithCryptoStoreFlag(cmd.Flags(), "crypto-store"),
 			fabric.WithCustomDeployChartsFlag(cmd.Flags(), "charts"),
 			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithEnrollmentFlag(cmd.Flags(), "enroll"),
+			fabric.WithOrderers(shared.OrdererHostnames()...),
 			fabric.WithLogger(logger),
 		),
 	)
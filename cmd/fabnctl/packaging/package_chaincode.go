@@ -0,0 +1,118 @@
+package packaging
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// chaincodeCmd represents the package cc command.
+var chaincodeCmd = &cobra.Command{
+	Use:   "cc [name]",
+	Short: "Packages chaincode into standalone lifecycle package file",
+	Long: `Packages chaincode into standalone lifecycle package file
+
+Package is the external builder one, it contains 'metadata.json' and 'code.tar.gz' with 'connection.json'
+and CouchDB indexes found in chaincode source. Service address in 'connection.json' refers to peer and organization
+by placeholders, which are substituted by the peer's builder, so the same package can be installed on any peer
+with 'install cc --package' command. Package ID the peer would compute for it is printed once it is written.
+
+With --type golang, node or java the source package is produced instead, which contains chaincode source code
+built and launched by the peer itself.
+
+Examples:
+  # Package chaincode with its CouchDB indexes:
+  fabnctl package cc assets --source ./assets -v 1.2.0
+
+  # Take version from the git tag or commit of chaincode source and set output file:
+  fabnctl package cc assets --source ./assets --version-from-git --output ./dist/assets.tar.gz
+
+  # Package Node.js chaincode source:
+  fabnctl package cc assets --type node --source ./assets -v 1.2.0
+
+  # Install packaged chaincode:
+  fabnctl install cc assets -d example.com -C supply-channel -o org1 -p peer0 -v 1.2.0 --package assets_1.2.0.tar.gz`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("%q requires exactly 1 argument: [name] (chaincode name)",
+				cmd.CommandPath())
+		}
+		return nil
+	},
+	RunE: shared.WithHandleErrors(func(cmd *cobra.Command, args []string) error {
+		return packageChaincode(cmd, args[0])
+	}),
+}
+
+func init() {
+	cmd.AddCommand(chaincodeCmd)
+
+	chaincodeCmd.Flags().String("source", "",
+		"Chaincode source path, which CouchDB indexes are packaged, or which is packaged as a whole for source types (alias: --path)",
+	)
+	chaincodeCmd.Flags().String("type", "external", "Chaincode package type: 'external', 'golang', 'node' or 'java'")
+	chaincodeCmd.Flags().StringP("version", "v", "1.0", "Chaincode version, which package label carries")
+	chaincodeCmd.Flags().Bool("version-from-git", false,
+		"Take version from the git tag or commit of the chaincode source (requires --source)",
+	)
+	chaincodeCmd.Flags().String("output", "", "Package file path (default: '[name]_[version].tar.gz')")
+
+	chaincodeCmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "path" {
+			name = "source"
+		}
+		return pflag.NormalizedName(name)
+	})
+}
+
+func packageChaincode(cmd *cobra.Command, name string) error {
+	var logger = term.NewLogger()
+
+	chaincode, err := fabric.NewChaincode(name,
+		fabric.WithSharedOptionsForChaincode(
+			fabric.WithLogger(logger),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to parse parameter 'output': %w", err)
+	}
+
+	var buffer bytes.Buffer
+
+	packageID, err := chaincode.Package(&buffer,
+		fabric.WithSourceFlag(cmd.Flags(), "source"),
+		fabric.WithChaincodeTypeFlag(cmd.Flags(), "type"),
+		fabric.WithVersionFlag(cmd.Flags(), "version"),
+		fabric.WithVersionFromGitFlag(cmd.Flags(), "version-from-git"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Package file is named after its label by default, which is the package ID prefix:
+	if len(output) == 0 {
+		output = fmt.Sprintf("%s.tar.gz", packageID[:strings.LastIndex(packageID, ":")])
+	}
+
+	if err = ioutil.WriteFile(output, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write package file: %w", err)
+	}
+
+	logger.Successf("Chaincode '%s' successfully packaged into '%s'", name, output)
+	logger.Infof("Package ID: %s", packageID)
+
+	return nil
+}
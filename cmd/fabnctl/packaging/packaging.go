@@ -0,0 +1,22 @@
+// Package packaging provides commands for producing standalone network component packages.
+package packaging
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// cmd represents the package command.
+var cmd = &cobra.Command{
+	Use:   "package",
+	Short: "Provides methods for producing standalone packages of network components",
+	Long: `Provides methods for producing standalone packages of network components.
+
+Examples:
+  # Package chaincode into lifecycle package file:
+  fabnctl package cc assets --source ./assets -v 1.2.0`,
+}
+
+// AddTo adds package commands to `root` cobra.Command.
+func AddTo(root *cobra.Command) {
+	root.AddCommand(cmd)
+}
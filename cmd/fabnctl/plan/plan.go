@@ -0,0 +1,177 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// cmd represents the plan command.
+var cmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Shows difference between network configuration and deployed network",
+	Long: `Shows difference between network configuration and deployed network
+
+Compares network configuration with the cluster state and prints helm releases,
+secrets, channel members and chaincode definitions that 'fabnctl apply' would change.
+Nothing is changed in the cluster.
+
+Examples:
+  # Plan network configuration changes:
+  fabnctl plan -f ./network-config.yaml
+
+  # Print planned changes as JSON:
+  fabnctl plan -f ./network-config.yaml -o json`,
+
+	RunE: shared.WithHandleErrors(plan),
+}
+
+func init() {
+	cmd.Flags().StringP("config", "f", "./network-config.yaml",
+		"Network structure config file path required for deployment",
+	)
+	cmd.Flags().StringP("output", "o", "text", "Output format. One of: text, json")
+}
+
+func plan(cmd *cobra.Command, _ []string) error {
+	var (
+		err    error
+		output string
+	)
+
+	// Parsing flags:
+	if output, err = cmd.Flags().GetString("output"); err != nil {
+		return fmt.Errorf("%w: failed to parse 'output' parameter", term.ErrInvalidArgs)
+	}
+
+	if output != "text" && output != "json" {
+		return fmt.Errorf("%w: unsupported output format '%s'", term.ErrInvalidArgs, output)
+	}
+
+	netConfig, err := shared.LoadNetworkConfig(cmd, "config")
+	if err != nil {
+		return err
+	}
+
+	network, err := fabric.NewNetwork(netConfig,
+		fabric.WithDomainFlag(cmd.Flags(), "domain"),
+		fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	networkPlan, err := network.Plan(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(networkPlan)
+	}
+
+	printPlan(cmd.OutOrStdout(), networkPlan)
+
+	return nil
+}
+
+func printPlan(w io.Writer, plan *fabric.NetworkPlan) {
+	if plan.Empty() {
+		_, _ = fmt.Fprintf(w, "Network on '%s' domain is up to date, no changes planned\n", plan.Domain)
+		return
+	}
+
+	var releases, secrets []string
+
+	for _, component := range plan.Components {
+		if len(component.ReleaseAction) != 0 {
+			releases = append(releases, fmt.Sprintf("  %s %s (chart '%s'): %s",
+				actionSign(component.ReleaseAction), component.Release, component.Chart, component.ReleaseAction,
+			))
+		}
+
+		for _, secret := range component.Secrets {
+			secrets = append(secrets, fmt.Sprintf("  %s %s: %s", actionSign(secret.Action), secret.Name, secret.Action))
+		}
+	}
+
+	if len(releases) != 0 {
+		_, _ = fmt.Fprintf(w, "Helm releases:\n%s\n", strings.Join(releases, "\n"))
+	}
+
+	if len(secrets) != 0 {
+		_, _ = fmt.Fprintf(w, "Secrets:\n%s\n", strings.Join(secrets, "\n"))
+	}
+
+	if len(plan.Channels) != 0 {
+		_, _ = fmt.Fprintln(w, "Channels:")
+
+		for _, ch := range plan.Channels {
+			if ch.Create {
+				_, _ = fmt.Fprintf(w, "  + %s: create\n", ch.ChannelID)
+			} else {
+				_, _ = fmt.Fprintf(w, "  ~ %s:\n", ch.ChannelID)
+			}
+
+			for _, member := range ch.NewMembers {
+				_, _ = fmt.Fprintf(w, "    + add '%s' organization to channel definition as '%s': %s\n",
+					member.Org, member.Name, member.Action,
+				)
+			}
+
+			for _, member := range ch.MissingPeers {
+				_, _ = fmt.Fprintf(w, "    + join '%s' peer of '%s' organization\n", member.Peer, member.Org)
+			}
+
+			if len(ch.MissingAnchors) != 0 {
+				_, _ = fmt.Fprintf(w, "    + set anchor peers of %s\n", strings.Join(ch.MissingAnchors, ", "))
+			}
+		}
+	}
+
+	if len(plan.Chaincodes) != 0 {
+		_, _ = fmt.Fprintln(w, "Chaincodes:")
+
+		for _, cc := range plan.Chaincodes {
+			if !cc.Committed {
+				_, _ = fmt.Fprintf(w, "  + %s on '%s' channel: commit version %s, sequence %d\n",
+					cc.Name, cc.ChannelID, cc.TargetVersion, cc.TargetSequence,
+				)
+				continue
+			}
+
+			_, _ = fmt.Fprintf(w, "  ~ %s on '%s' channel: version %s -> %s, sequence %d -> %d\n",
+				cc.Name, cc.ChannelID,
+				cc.CommittedVersion, cc.TargetVersion,
+				cc.CommittedSequence, cc.TargetSequence,
+			)
+
+			for _, change := range cc.DefinitionChanges {
+				_, _ = fmt.Fprintf(w, "    ~ %s\n", change)
+			}
+		}
+	}
+}
+
+func actionSign(action fabric.PlanAction) string {
+	if action == fabric.PlanUpgrade {
+		return "~"
+	}
+
+	return "+"
+}
+
+// AddTo adds plan command to `root` cobra.Command.
+func AddTo(root *cobra.Command) {
+	root.AddCommand(cmd)
+}
@@ -0,0 +1,26 @@
+package rollback
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+)
+
+// cmd represents the rollback command.
+var cmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Provides method for rolling network components back to their previous deployment",
+	Long: `Provides method for rolling network components back to their previous deployment.
+
+Examples:
+  # Roll chaincode back to its previous version
+  fabnctl rollback cc assets -d example.com -C supply-channel -o org1 -p peer0 -o org2 -p peer0`,
+}
+
+func init() {
+	shared.AddConfirmFlag(cmd)
+}
+
+// AddTo adds rollback commands to `root` cobra.Command.
+func AddTo(root *cobra.Command) {
+	root.AddCommand(cmd)
+}
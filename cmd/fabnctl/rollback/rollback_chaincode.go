@@ -0,0 +1,89 @@
+package rollback
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// chaincodeCmd represents the rollback cc command.
+var chaincodeCmd = &cobra.Command{
+	Use:   "cc [name]",
+	Short: "Rolls the Fabric chaincode back to its previous deployment",
+	Long: `Rolls the Fabric chaincode back to its previous deployment
+
+Previous chaincode definition along with its package is recommitted as a new sequence,
+and chaincode releases on organization peers are rolled back to the revisions running that package.
+Chaincode must pass readiness checks before the definition gets committed, otherwise rollback is aborted.
+Deployments are recorded by 'install cc' command, thus only those installed with it can be rolled back.
+
+Examples:
+  # Roll chaincode back:
+  fabnctl rollback cc assets -d example.com -C supply-channel -o org1 -p peer0
+
+  # Roll chaincode back on multiply organization and peers:
+  fabnctl rollback cc assets -d example.com -C supply-channel -o org1 -p peer0 -o org2 -p peer1`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("%q requires exactly 1 argument: [name] (chaincode name)",
+				cmd.CommandPath())
+		}
+		return nil
+	},
+	RunE: shared.WithHandleErrors(func(cmd *cobra.Command, args []string) error {
+		return rollbackChaincode(cmd, args[0])
+	}),
+}
+
+func init() {
+	cmd.AddCommand(chaincodeCmd)
+
+	chaincodeCmd.Flags().StringArrayP("org", "o", nil,
+		"Organization owning chaincode. Can be used multiple times to pass list of organizations (required)")
+	chaincodeCmd.Flags().StringArrayP("peers", "p", nil,
+		"Peer hostname. Can be used multiply time to pass list of peers by (required)")
+	chaincodeCmd.Flags().StringP("channel", "C", "", "Channel name (required)")
+	chaincodeCmd.Flags().Int("parallelism", 4, "Maximum number of peers processed at the same time")
+	chaincodeCmd.Flags().Bool("continue-on-error", false,
+		"Proceed with the rest of peers when some of them fail, reporting failures in the end",
+	)
+
+	_ = chaincodeCmd.MarkFlagRequired("org")
+	_ = chaincodeCmd.MarkFlagRequired("peers")
+	_ = chaincodeCmd.MarkFlagRequired("channel")
+}
+
+func rollbackChaincode(cmd *cobra.Command, name string) error {
+	var logger = term.NewLogger()
+
+	chaincode, err := fabric.NewChaincode(name,
+		fabric.WithChannelFlag(cmd.Flags(), "channel"),
+		fabric.WithChaincodePeersFlag(cmd.Flags(), "org", "peers"),
+		fabric.WithSharedOptionsForChaincode(
+			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithOrderers(shared.OrdererHostnames()...),
+			fabric.WithParallelismFlag(cmd.Flags(), "parallelism"),
+			fabric.WithContinueOnErrorFlag(cmd.Flags(), "continue-on-error"),
+			fabric.WithLogger(logger),
+		),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if !shared.Confirm(cmd, logger, fmt.Sprintf("Roll '%s' chaincode back to its previous deployment?", name)) {
+		return nil
+	}
+
+	if err = chaincode.Rollback(cmd.Context()); err != nil {
+		return err
+	}
+
+	return nil
+}
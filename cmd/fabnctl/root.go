@@ -2,11 +2,23 @@ package fabnctl
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/apply"
 	"github.com/timoth-y/fabnctl/cmd/fabnctl/build"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/certs"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/chaincode"
 	"github.com/timoth-y/fabnctl/cmd/fabnctl/gen"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/identity"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/inspect"
 	"github.com/timoth-y/fabnctl/cmd/fabnctl/install"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/packaging"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/plan"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/rollback"
 	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/status"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/teardown"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/uninstall"
 	"github.com/timoth-y/fabnctl/cmd/fabnctl/update"
+	"github.com/timoth-y/fabnctl/pkg/kube"
 )
 
 // rootCmd represents the base command when called without any subcommands.
@@ -22,12 +34,27 @@ func Execute() {
 }
 
 func init() {
+	cobra.OnInitialize(func() {
+		cobra.CheckErr(kube.Error())
+	})
+
 	shared.AddGlobalFlags(rootCmd)
 
 	gen.AddTo(rootCmd)
 	build.AddTo(rootCmd)
+	packaging.AddTo(rootCmd)
+	chaincode.AddTo(rootCmd)
 	install.AddTo(rootCmd)
 	update.AddTo(rootCmd)
+	apply.AddTo(rootCmd)
+	plan.AddTo(rootCmd)
+	rollback.AddTo(rootCmd)
+	uninstall.AddTo(rootCmd)
+	teardown.AddTo(rootCmd)
+	status.AddTo(rootCmd)
+	inspect.AddTo(rootCmd)
+	identity.AddTo(rootCmd)
+	certs.AddTo(rootCmd)
 }
 
 
@@ -0,0 +1,21 @@
+package shared
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// AddConfirmFlag adds flag allowing to skip interactive confirmation to the `cmd`.
+func AddConfirmFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolP("yes", "y", false, "Skip interactive confirmation")
+}
+
+// Confirm asks to confirm the action described by `label`,
+// unless the confirmation is skipped with 'yes' flag.
+func Confirm(cmd *cobra.Command, logger *term.Logger, label string) bool {
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		return true
+	}
+
+	return logger.PromptConfirm(label)
+}
@@ -6,10 +6,12 @@ import (
 )
 
 var (
-	TargetArch string
-	Domain     string
-	ChartsPath string
-	Namespace  string
+	TargetArch  string
+	Domain      string
+	ChartsPath  string
+	Namespace   string
+	Orderers    []string
+	CryptoStore string
 )
 
 func AddGlobalFlags(cmd *cobra.Command) {
@@ -44,5 +46,24 @@ Supported are:
 		"namespace scope for the deployment request",
 	)
 
+	cmd.PersistentFlags().StringArrayVar(
+		&Orderers,
+		"orderer",
+		nil,
+		`Orderer cluster node hostname.
+Can be used multiple times to pass list of Raft consenters, which endpoints are used in turn when one is unavailable`,
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&CryptoStore,
+		"crypto-store",
+		"",
+		`Store crypto material is read from and written to (default is 'crypto.store' config value or local).
+Supported are:
+ - Local '.crypto-config.$DOMAIN' directory: --crypto-store=local
+ - Encrypted '.crypto-config.$DOMAIN.enc' archive, passphrase is set with CRYPTO_PASSPHRASE env: --crypto-store=encrypted
+ - In-cluster secrets shared by all operators: --crypto-store=cluster`,
+	)
+
 	cmd.MarkFlagRequired("domain")
 }
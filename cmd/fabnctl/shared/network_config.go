@@ -0,0 +1,71 @@
+package shared
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/pkg/model"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// NetworkConfig is the network config validated by ValidateNetworkConfig hook before command execution.
+var NetworkConfig *model.NetworkConfig
+
+// LoadNetworkConfig decodes network config from the path passed with `flag` and validates it.
+func LoadNetworkConfig(cmd *cobra.Command, flag string) (*model.NetworkConfig, error) {
+	configPath, err := cmd.Flags().GetString(flag)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse '%s' parameter", term.ErrInvalidArgs, flag)
+	}
+
+	netConfig, err := model.NetworkConfigFromFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = netConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("network config '%s' is invalid:\n%s", configPath, err)
+	}
+
+	return netConfig, nil
+}
+
+// ValidateNetworkConfig can be used as cobra.Command pre-run hook for validating network config
+// passed with 'config' flag, so that misconfiguration is reported before any deployment starts.
+// Validation is skipped when flag is omitted and config isn't present on its default path.
+func ValidateNetworkConfig(cmd *cobra.Command, _ []string) error {
+	var flag = cmd.Flags().Lookup("config")
+	if flag == nil {
+		return nil
+	}
+
+	if _, err := os.Stat(flag.Value.String()); os.IsNotExist(err) && !flag.Changed {
+		return nil
+	}
+
+	netConfig, err := LoadNetworkConfig(cmd, "config")
+	if err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
+
+	NetworkConfig = netConfig
+
+	return nil
+}
+
+// OrdererHostnames determines hostnames of the orderer cluster nodes passed with 'orderer' flag,
+// or declared in the network config validated before command execution.
+// Returns empty slice when neither is available, so that the CLI configuration is used instead.
+func OrdererHostnames() []string {
+	if len(Orderers) != 0 {
+		return Orderers
+	}
+
+	if NetworkConfig != nil {
+		return NetworkConfig.Orderer.Hostnames()
+	}
+
+	return nil
+}
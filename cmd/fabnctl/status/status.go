@@ -0,0 +1,180 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// cmd represents the status command.
+var cmd = &cobra.Command{
+	Use:   "status",
+	Short: "Shows live state of the deployed network",
+	Long: `Shows live state of the deployed network
+
+Lists orderer, peer, CA, CouchDB, CLI and chaincode pods along with their readiness, restarts,
+image tag and helm release revision, as well as channels joined by each peer and chaincodes committed on them.
+
+Examples:
+  # Show network status:
+  fabnctl status -d example.com
+
+  # Print network status as JSON:
+  fabnctl status -d example.com -o json
+
+  # Refresh network status every 10 seconds:
+  fabnctl status -d example.com --watch --interval 10s`,
+
+	RunE: shared.WithHandleErrors(status),
+}
+
+func init() {
+	cmd.Flags().StringP("output", "o", "text", "Output format. One of: text, json")
+	cmd.Flags().BoolP("watch", "w", false, "Continuously refresh network status")
+	cmd.Flags().Duration("interval", 5*time.Second, "Refresh interval in watch mode")
+}
+
+func status(cmd *cobra.Command, _ []string) error {
+	var (
+		err      error
+		output   string
+		watch    bool
+		interval time.Duration
+	)
+
+	// Parsing flags:
+	if output, err = cmd.Flags().GetString("output"); err != nil {
+		return fmt.Errorf("%w: failed to parse 'output' parameter", term.ErrInvalidArgs)
+	}
+
+	if output != "text" && output != "json" {
+		return fmt.Errorf("%w: unsupported output format '%s'", term.ErrInvalidArgs, output)
+	}
+
+	if watch, err = cmd.Flags().GetBool("watch"); err != nil {
+		return fmt.Errorf("%w: failed to parse 'watch' parameter", term.ErrInvalidArgs)
+	}
+
+	if interval, err = cmd.Flags().GetDuration("interval"); err != nil || interval <= 0 {
+		return fmt.Errorf("%w: failed to parse 'interval' parameter", term.ErrInvalidArgs)
+	}
+
+	monitor, err := fabric.NewStatus(
+		fabric.WithDomainFlag(cmd.Flags(), "domain"),
+		fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	for {
+		networkStatus, err := monitor.Collect(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if output == "json" {
+			if err = json.NewEncoder(cmd.OutOrStdout()).Encode(networkStatus); err != nil {
+				return fmt.Errorf("failed to encode network status: %w", err)
+			}
+		} else {
+			if watch {
+				// Clear terminal screen before redrawing:
+				cmd.Print("\033[H\033[2J")
+			}
+
+			printStatus(cmd.OutOrStdout(), networkStatus)
+		}
+
+		if !watch {
+			return nil
+		}
+
+		select {
+		case <-cmd.Context().Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+func printStatus(w io.Writer, status *fabric.NetworkStatus) {
+	var table = tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	_, _ = fmt.Fprintf(w, "Namespace '%s' at %s\n\n", status.Namespace, status.Time.Format(time.RFC1123))
+
+	if len(status.Components) == 0 {
+		_, _ = fmt.Fprintln(w, "No network components found")
+		return
+	}
+
+	_, _ = fmt.Fprintln(table, "KIND\tORG\tPEER\tNAME\tPOD\tREADY\tSTATUS\tRESTARTS\tIMAGE\tRELEASE\tREVISION")
+
+	for _, c := range status.Components {
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\t%d\n",
+			c.Kind, dash(c.Org), dash(c.Peer), dash(c.Name), c.Pod, readySign(c.Ready),
+			c.Phase, c.Restarts, c.Image, c.Release, c.Revision,
+		)
+	}
+
+	_ = table.Flush()
+
+	if len(status.Peers) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(table, "ORG\tPEER\tCHANNEL\tCHAINCODES")
+
+	for _, p := range status.Peers {
+		if len(p.Error) != 0 {
+			_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", p.Org, p.Peer, "-", "error: "+p.Error)
+		}
+
+		if len(p.Channels) == 0 && len(p.Error) == 0 {
+			_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", p.Org, p.Peer, "-", "-")
+		}
+
+		for _, ch := range p.Channels {
+			var chaincodes []string
+
+			for _, cc := range ch.Chaincodes {
+				chaincodes = append(chaincodes, fmt.Sprintf("%s (version %s, sequence %d)", cc.Name, cc.Version, cc.Sequence))
+			}
+
+			_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", p.Org, p.Peer, ch.ChannelID, dash(strings.Join(chaincodes, ", ")))
+		}
+	}
+
+	_ = table.Flush()
+}
+
+func readySign(ready bool) string {
+	if ready {
+		return "✔"
+	}
+
+	return "✘"
+}
+
+func dash(value string) string {
+	if len(value) == 0 {
+		return "-"
+	}
+
+	return value
+}
+
+// AddTo adds status command to `root` cobra.Command.
+func AddTo(root *cobra.Command) {
+	root.AddCommand(cmd)
+}
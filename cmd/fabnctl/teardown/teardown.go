@@ -0,0 +1,73 @@
+package teardown
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// cmd represents the teardown command.
+var cmd = &cobra.Command{
+	Use:   "teardown",
+	Short: "Removes the whole network deployment",
+	Long: `Removes the whole network deployment: chaincodes, peers, orderer and artifacts
+helm releases, along with peer, orderer and chaincode TLS secrets, published identity secrets,
+chaincode installation state and history, persistent volume claims and jobs created during installation.
+
+Examples:
+  # Remove network:
+  fabnctl teardown -d example.com
+
+  # Remove network but keep ledgers and artifacts storage:
+  fabnctl teardown -d example.com --keep-data`,
+
+	RunE: shared.WithHandleErrors(teardown),
+}
+
+func init() {
+	cmd.Flags().Bool("keep-data", false, "Keep persistent volume claims of the removed components")
+	shared.AddConfirmFlag(cmd)
+}
+
+func teardown(cmd *cobra.Command, _ []string) error {
+	var logger = term.NewLogger()
+
+	teardown, err := fabric.NewTeardown(
+		fabric.WithDomainFlag(cmd.Flags(), "domain"),
+		fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+		fabric.WithLogger(logger),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	releases, err := teardown.Releases()
+	if err != nil {
+		return err
+	}
+
+	for _, rel := range releases {
+		logger.Infof("Release '%s' (chart '%s') will be removed", rel.Name, rel.Chart.Metadata.Name)
+	}
+
+	if !shared.Confirm(cmd, logger, fmt.Sprintf("Remove the whole network from '%s' namespace?", shared.Namespace)) {
+		return nil
+	}
+
+	if err = teardown.Run(cmd.Context(),
+		fabric.WithKeepDataFlag(cmd.Flags(), "keep-data"),
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddTo adds teardown command to `root` cobra.Command.
+func AddTo(root *cobra.Command) {
+	root.AddCommand(cmd)
+}
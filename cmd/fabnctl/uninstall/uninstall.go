@@ -0,0 +1,36 @@
+package uninstall
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+)
+
+// cmd represents the uninstall command.
+var cmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Provides method for removing network components",
+	Long: `Provides method for removing network components.
+
+Examples:
+  # Remove orderer service
+  fabnctl uninstall orderer -d example.com
+
+  # Remove peer, but keep its ledger storage
+  fabnctl uninstall peer -d example.com -o org1 -p peer0 --keep-data
+
+  # Remove chaincode services
+  fabnctl uninstall cc assets -d example.com -o org1 -p peer0 -o org2 -p peer0
+
+  # Remove network artifacts
+  fabnctl uninstall artifacts -d example.com`,
+}
+
+func init() {
+	cmd.PersistentFlags().Bool("keep-data", false, "Keep persistent volume claims of the removed components")
+	shared.AddConfirmFlag(cmd)
+}
+
+// AddTo adds uninstall commands to `root` cobra.Command.
+func AddTo(root *cobra.Command) {
+	root.AddCommand(cmd)
+}
@@ -0,0 +1,54 @@
+package uninstall
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// artifactsCmd represents the artifacts command.
+var artifactsCmd = &cobra.Command{
+	Use:   "artifacts",
+	Short: "Removes generated network artifacts from the cluster",
+	Long: `Removes generated network artifacts from the cluster
+
+Examples:
+  # Remove artifacts:
+  fabnctl uninstall artifacts -d example.com
+
+  # Remove artifacts chart but keep generated crypto materials volume:
+  fabnctl uninstall artifacts -d example.com --keep-data`,
+
+	RunE: shared.WithHandleErrors(uninstallArtifacts),
+}
+
+func init() {
+	cmd.AddCommand(artifactsCmd)
+}
+
+func uninstallArtifacts(cmd *cobra.Command, _ []string) error {
+	var logger = term.NewLogger()
+
+	artifacts, err := fabric.NewArtifacts(
+		fabric.WithDomainFlag(cmd.Flags(), "domain"),
+		fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+		fabric.WithLogger(logger),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if !shared.Confirm(cmd, logger, "Remove network artifacts?") {
+		return nil
+	}
+
+	if err = artifacts.Uninstall(cmd.Context(),
+		fabric.WithKeepDataFlag(cmd.Flags(), "keep-data"),
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
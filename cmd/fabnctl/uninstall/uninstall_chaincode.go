@@ -0,0 +1,77 @@
+package uninstall
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// chaincodeCmd represents the cc command.
+var chaincodeCmd = &cobra.Command{
+	Use:   "cc [name]",
+	Short: "Removes the Fabric chaincode services",
+	Long: `Removes the Fabric chaincode services from organization peers.
+Chaincode definition committed on the channel stays unchanged.
+
+Examples:
+  # Remove chaincode:
+  fabnctl uninstall cc assets -d example.com -o org1 -p peer0
+
+  # Remove chaincode from multiply organization and peers:
+  fabnctl uninstall cc assets -d example.com -o org1 -p peer0 -o org2 -p peer1`,
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("%q requires exactly 1 argument: [name] (chaincode name)",
+				cmd.CommandPath())
+		}
+		return nil
+	},
+	RunE: shared.WithHandleErrors(func(cmd *cobra.Command, args []string) error {
+		return uninstallChaincode(cmd, args[0])
+	}),
+}
+
+func init() {
+	cmd.AddCommand(chaincodeCmd)
+
+	chaincodeCmd.Flags().StringArrayP("org", "o", nil,
+		"Organization owning chaincode. Can be used multiple times to pass list of organizations (required)")
+	chaincodeCmd.Flags().StringArrayP("peers", "p", nil,
+		"Peer hostname. Can be used multiply time to pass list of peers by (required)")
+
+	_ = chaincodeCmd.MarkFlagRequired("org")
+	_ = chaincodeCmd.MarkFlagRequired("peers")
+}
+
+func uninstallChaincode(cmd *cobra.Command, name string) error {
+	var logger = term.NewLogger()
+
+	chaincode, err := fabric.NewChaincode(name,
+		fabric.WithChaincodePeersFlag(cmd.Flags(), "org", "peers"),
+		fabric.WithSharedOptionsForChaincode(
+			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithLogger(logger),
+		),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if !shared.Confirm(cmd, logger, fmt.Sprintf("Remove '%s' chaincode services?", name)) {
+		return nil
+	}
+
+	if err = chaincode.Uninstall(cmd.Context(),
+		fabric.WithKeepDataFlag(cmd.Flags(), "keep-data"),
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
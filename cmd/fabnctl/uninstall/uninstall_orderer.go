@@ -0,0 +1,67 @@
+package uninstall
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// ordererCmd represents the orderer command.
+var ordererCmd = &cobra.Command{
+	Use:   "orderer",
+	Short: "Removes the Fabric orderer service",
+	Long: `Removes the Fabric orderer service along with its TLS secrets and storage
+
+Examples:
+  # Remove orderer:
+  fabnctl uninstall orderer -d example.com
+
+  # Remove Raft cluster nodes:
+  fabnctl uninstall orderer -d example.com --orderer orderer1 --orderer orderer2`,
+
+	RunE: shared.WithHandleErrors(uninstallOrderer),
+}
+
+func init() {
+	cmd.AddCommand(ordererCmd)
+}
+
+func uninstallOrderer(cmd *cobra.Command, _ []string) error {
+	var (
+		logger    = term.NewLogger()
+		hostnames = shared.OrdererHostnames()
+	)
+
+	if len(hostnames) == 0 {
+		hostnames = []string{viper.GetString("fabric.orderer_hostname_name")}
+	}
+
+	if !shared.Confirm(cmd, logger, fmt.Sprintf("Remove orderer service (%s)?", strings.Join(hostnames, ", "))) {
+		return nil
+	}
+
+	for _, hostname := range hostnames {
+		orderer, err := fabric.NewOrderer(hostname,
+			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithLogger(logger),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if err = orderer.Uninstall(cmd.Context(),
+			fabric.WithKeepDataFlag(cmd.Flags(), "keep-data"),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package uninstall
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
+	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// peerCmd represents the peer command.
+var peerCmd = &cobra.Command{
+	Use:   "peer",
+	Short: "Removes the Fabric peer",
+	Long: `Removes the Fabric peer along with its CLI, CA, CouchDB, TLS secrets and storage
+
+Examples:
+  # Remove peer:
+  fabnctl uninstall peer -d example.com -o org1 -p peer0
+
+  # Remove peer but keep its ledger storage:
+  fabnctl uninstall peer -d example.com -o org1 -p peer0 --keep-data`,
+
+	RunE: shared.WithHandleErrors(uninstallPeer),
+}
+
+func init() {
+	cmd.AddCommand(peerCmd)
+
+	peerCmd.Flags().StringP("org", "o", "", "Organization owning peer (required)")
+	peerCmd.Flags().StringP("peer", "p", "peer0", "Peer hostname")
+
+	_ = peerCmd.MarkFlagRequired("org")
+}
+
+func uninstallPeer(cmd *cobra.Command, _ []string) error {
+	var (
+		err      error
+		org      string
+		peerName string
+		logger   = term.NewLogger()
+	)
+
+	// Parse flags
+	if org, err = cmd.Flags().GetString("org"); err != nil {
+		return fmt.Errorf("%w: failed to parse required parameter 'org' (organization): %s", term.ErrInvalidArgs, err)
+	}
+
+	if peerName, err = cmd.Flags().GetString("peer"); err != nil {
+		return fmt.Errorf("%w: failed to parse 'peer' parameter: %s", term.ErrInvalidArgs, err)
+	}
+
+	peer, err := fabric.NewPeer(org, peerName,
+		fabric.WithSharedOptionsForPeer(
+			fabric.WithDomainFlag(cmd.Flags(), "domain"),
+			fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+			fabric.WithLogger(logger),
+		),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if !shared.Confirm(cmd, logger, fmt.Sprintf("Remove '%s' peer of '%s' organization?", peerName, org)) {
+		return nil
+	}
+
+	if err = peer.Uninstall(cmd.Context(),
+		fabric.WithKeepDataFlag(cmd.Flags(), "keep-data"),
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
 )
 
 // cmd represents the update command
@@ -15,11 +16,18 @@ var cmd = &cobra.Command{
 Examples:
   # Update channel definition:
 	fabnctl update channel -c supply-channel --setAnchors -o org1 -o org2`,
+	PersistentPreRunE: shared.ValidateNetworkConfig,
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Println("update called")
 	},
 }
 
+func init() {
+	cmd.PersistentFlags().StringP("config", "f", "./network-config.yaml",
+		"Network structure config file path, used for resolving organization names and updating channel membership",
+	)
+}
+
 // AddTo adds update commands to `root` cobra.Command.
 func AddTo(root *cobra.Command) {
 	root.AddCommand(cmd)
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
 	"github.com/timoth-y/fabnctl/pkg/fabric"
+	"github.com/timoth-y/fabnctl/pkg/model"
 	"github.com/timoth-y/fabnctl/pkg/term"
 )
 
@@ -17,7 +18,13 @@ var updateChannelCmd = &cobra.Command{
 
 Examples:
   # Add anchor peers to channel definition:
-	fabnctl update channel -c supply-channel --setAnchors -o org1 -o org2`,
+	fabnctl update channel -c supply-channel --setAnchors -o org1 -o org2
+
+  # Add new organization to channel definition:
+	fabnctl update channel -c supply-channel --add-org org3
+
+  # Remove organization from channel definition and network config:
+	fabnctl update channel -c supply-channel --remove-org org3 -f ./network-config.yaml`,
 	RunE: shared.WithHandleErrors(updateChannel),
 }
 
@@ -27,8 +34,13 @@ func init() {
 	updateChannelCmd.Flags().StringArrayP("org", "o", nil, "Owner organization names (required)")
 	updateChannelCmd.Flags().StringP("channel", "c", "", "Channel name (required)")
 	updateChannelCmd.Flags().Bool("setAnchors", true, "Update to setup anchor peers (default option)")
+	updateChannelCmd.Flags().String("add-org", "",
+		"Organization to be added to channel definition, signed by organizations already joined to channel",
+	)
+	updateChannelCmd.Flags().String("remove-org", "",
+		"Organization to be removed from channel definition and network config channel membership",
+	)
 
-	_ = updateChannelCmd.MarkFlagRequired("org")
 	_ = updateChannelCmd.MarkFlagRequired("channel")
 }
 
@@ -37,6 +49,8 @@ func updateChannel(cmd *cobra.Command, _ []string) error {
 		err     error
 		orgs        []string
 		channelName string
+		addOrg      string
+		removeOrg   string
 		logger = term.NewLogger()
 	)
 
@@ -45,6 +59,18 @@ func updateChannel(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("%w: failed to parse required parameter 'org' (organization): %s", term.ErrInvalidArgs, err)
 	}
 
+	if addOrg, err = cmd.Flags().GetString("add-org"); err != nil {
+		return fmt.Errorf("%w: failed to parse 'add-org' parameter: %s", term.ErrInvalidArgs, err)
+	}
+
+	if removeOrg, err = cmd.Flags().GetString("remove-org"); err != nil {
+		return fmt.Errorf("%w: failed to parse 'remove-org' parameter: %s", term.ErrInvalidArgs, err)
+	}
+
+	if len(orgs) == 0 && len(addOrg) == 0 && len(removeOrg) == 0 {
+		return fmt.Errorf("%w: either 'org', 'add-org' or 'remove-org' parameter must be specified", term.ErrInvalidArgs)
+	}
+
 	if channelName, err = cmd.Flags().GetString("channel"); err != nil {
 		return fmt.Errorf("%w: failed to parse required 'channelName' parameter: %s", term.ErrInvalidArgs, err)
 	}
@@ -52,8 +78,10 @@ func updateChannel(cmd *cobra.Command, _ []string) error {
 	channel, err := fabric.NewChannel(channelName, fabric.WithSharedOptionsForChannel(
 		fabric.WithArchFlag(cmd.Flags(), "arch"),
 		fabric.WithDomainFlag(cmd.Flags(), "domain"),
+		fabric.WithCryptoStoreFlag(cmd.Flags(), "crypto-store"),
 		fabric.WithCustomDeployChartsFlag(cmd.Flags(), "charts"),
 		fabric.WithKubeNamespaceFlag(cmd.Flags(), "namespace"),
+		fabric.WithOrderers(shared.OrdererHostnames()...),
 		fabric.WithLogger(logger),
 	))
 
@@ -61,9 +89,45 @@ func updateChannel(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	if err = channel.SetAnchors(cmd.Context(), orgs...); err != nil {
-		return err
+	if len(addOrg) != 0 {
+		if err = channel.AddOrganization(cmd.Context(), addOrg, orgName(addOrg)); err != nil {
+			return err
+		}
+	}
+
+	if len(removeOrg) != 0 {
+		if err = channel.RemoveOrganization(cmd.Context(), removeOrg, orgName(removeOrg)); err != nil {
+			return err
+		}
+
+		if shared.NetworkConfig != nil {
+			configPath, _ := cmd.Flags().GetString("config")
+
+			if removed, err := model.RemoveChannelOrganization(configPath, channelName, orgName(removeOrg)); err != nil {
+				return err
+			} else if removed {
+				logger.Successf("Organization '%s' removed from '%s' channel members in %s", removeOrg, channelName, configPath)
+			}
+		}
+	}
+
+	if len(orgs) != 0 {
+		if err = channel.SetAnchors(cmd.Context(), orgs...); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// orgName resolves name of the organization by its MSP ID from the network config.
+// Organizations are registered in channel config by their names, same as 'configtx.yaml' does.
+func orgName(mspID string) string {
+	if shared.NetworkConfig != nil {
+		if org := shared.NetworkConfig.GetOrganization(mspID); org != nil {
+			return org.Name
+		}
+	}
+
+	return mspID
+}
@@ -3,6 +3,7 @@ module github.com/timoth-y/fabnctl
 go 1.16
 
 require (
+	filippo.io/age v1.0.0
 	github.com/Masterminds/sprig v2.22.0+incompatible
 	github.com/bmatcuk/doublestar/v4 v4.0.2
 	github.com/docker/buildx v0.5.1
@@ -10,6 +11,9 @@ require (
 	github.com/docker/docker v20.10.5+incompatible
 	github.com/docker/libnetwork v0.8.0-dev.2.0.20201215162534-fa125a3512ee // indirect
 	github.com/gernest/wow v0.1.0
+	github.com/golang/protobuf v1.4.3
+	github.com/hyperledger/fabric-config v0.1.0
+	github.com/hyperledger/fabric-protos-go v0.0.0-20200424173316-dd554ba3746e
 	github.com/kr/fs v0.1.0
 	github.com/manifoldco/promptui v0.8.0
 	github.com/mittwald/go-helm-client v0.5.0
@@ -23,8 +27,10 @@ require (
 	github.com/spf13/cobra v1.1.3
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.7.0
-	golang.org/x/crypto v0.0.0-20210513164829-c07d793c2f9a
+	golang.org/x/crypto v0.0.0-20210817164053-32db794688a5
 	golang.org/x/sys v0.0.0-20210906170528-6f6e22806c34 // indirect
+	gopkg.in/yaml.v3 v3.0.1
+	helm.sh/helm/v3 v3.5.1
 	k8s.io/api v0.20.6
 	k8s.io/apimachinery v0.20.6
 	k8s.io/client-go v0.20.6
@@ -0,0 +1,340 @@
+// Package blocks provides methods for decoding Hyperledger Fabric blocks and transaction envelopes
+// into human readable descriptions.
+package blocks
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/timoth-y/fabnctl/pkg/channelconfig"
+)
+
+type (
+	// BlockInfo describes block along with transactions it contains.
+	BlockInfo struct {
+		Number       uint64            `json:"number"`
+		DataHash     string            `json:"dataHash"`
+		PreviousHash string            `json:"previousHash"`
+		Transactions []TransactionInfo `json:"transactions"`
+	}
+
+	// TransactionInfo describes single transaction envelope.
+	TransactionInfo struct {
+		TxID           string          `json:"txID,omitempty"`
+		ChannelID      string          `json:"channelID"`
+		Type           string          `json:"type"`
+		Timestamp      *time.Time      `json:"timestamp,omitempty"`
+		CreatorMSPID   string          `json:"creatorMSPID,omitempty"`
+		ValidationCode string          `json:"validationCode,omitempty"`
+		Actions        []ActionInfo    `json:"actions,omitempty"`
+		Signatures     []string        `json:"signatures,omitempty"`
+		Config         json.RawMessage `json:"config,omitempty"`
+		ConfigUpdate   json.RawMessage `json:"configUpdate,omitempty"`
+	}
+
+	// ActionInfo describes chaincode invocation endorsed within the transaction.
+	ActionInfo struct {
+		Chaincode     string           `json:"chaincode,omitempty"`
+		Version       string           `json:"version,omitempty"`
+		Response      int32            `json:"response,omitempty"`
+		Endorsements  []string         `json:"endorsements"`
+		ReadWriteSets []NsReadWriteSet `json:"readWriteSets,omitempty"`
+	}
+
+	// NsReadWriteSet describes keys read and written by the transaction in chaincode namespace.
+	NsReadWriteSet struct {
+		Namespace string    `json:"namespace"`
+		Reads     []KVRead  `json:"reads,omitempty"`
+		Writes    []KVWrite `json:"writes,omitempty"`
+	}
+
+	// KVRead describes key read by the transaction along with its committed version.
+	KVRead struct {
+		Key     string `json:"key"`
+		Version string `json:"version,omitempty"`
+	}
+
+	// KVWrite describes key written or deleted by the transaction.
+	KVWrite struct {
+		Key      string `json:"key"`
+		Value    string `json:"value,omitempty"`
+		IsDelete bool   `json:"isDelete,omitempty"`
+	}
+)
+
+// Decode decodes serialized block.
+func Decode(payload []byte) (*cb.Block, error) {
+	return channelconfig.DecodeBlock(payload)
+}
+
+// Describe forms BlockInfo of the `block`, including validation codes of its transactions.
+func Describe(block *cb.Block) (*BlockInfo, error) {
+	var info = &BlockInfo{}
+
+	if block.Header != nil {
+		info.Number = block.Header.Number
+		info.DataHash = hex.EncodeToString(block.Header.DataHash)
+		info.PreviousHash = hex.EncodeToString(block.Header.PreviousHash)
+	}
+
+	if block.Data == nil {
+		return info, nil
+	}
+
+	var filter []byte
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		filter = block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	}
+
+	for i, data := range block.Data.Data {
+		var envelope = &cb.Envelope{}
+
+		if err := proto.Unmarshal(data, envelope); err != nil {
+			return nil, fmt.Errorf("failed to decode envelope of transaction %d: %w", i, err)
+		}
+
+		tx, err := DescribeEnvelope(envelope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe transaction %d: %w", i, err)
+		}
+
+		if i < len(filter) {
+			tx.ValidationCode = pb.TxValidationCode(filter[i]).String()
+		}
+
+		info.Transactions = append(info.Transactions, *tx)
+	}
+
+	return info, nil
+}
+
+// DescribeEnvelope forms TransactionInfo of the transaction `envelope`,
+// e.g. read from channel artifact '.tx' file.
+func DescribeEnvelope(envelope *cb.Envelope) (*TransactionInfo, error) {
+	var (
+		tx              = &TransactionInfo{}
+		payload         = &cb.Payload{}
+		channelHeader   = &cb.ChannelHeader{}
+		signatureHeader = &cb.SignatureHeader{}
+	)
+
+	if err := proto.Unmarshal(envelope.Payload, payload); err != nil {
+		return nil, fmt.Errorf("failed to decode envelope payload: %w", err)
+	}
+
+	if payload.Header == nil {
+		return nil, fmt.Errorf("envelope payload is missing header")
+	}
+
+	if err := proto.Unmarshal(payload.Header.ChannelHeader, channelHeader); err != nil {
+		return nil, fmt.Errorf("failed to decode channel header: %w", err)
+	}
+
+	if err := proto.Unmarshal(payload.Header.SignatureHeader, signatureHeader); err != nil {
+		return nil, fmt.Errorf("failed to decode signature header: %w", err)
+	}
+
+	tx.TxID = channelHeader.TxId
+	tx.ChannelID = channelHeader.ChannelId
+	tx.Type = cb.HeaderType(channelHeader.Type).String()
+	tx.CreatorMSPID = creatorMSPID(signatureHeader.Creator)
+
+	if ts := channelHeader.Timestamp; ts != nil {
+		var timestamp = time.Unix(ts.Seconds, int64(ts.Nanos)).UTC()
+		tx.Timestamp = &timestamp
+	}
+
+	switch cb.HeaderType(channelHeader.Type) {
+	case cb.HeaderType_ENDORSER_TRANSACTION:
+		actions, err := describeActions(payload.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		tx.Actions = actions
+	case cb.HeaderType_CONFIG:
+		var configEnvelope = &cb.ConfigEnvelope{}
+
+		if err := proto.Unmarshal(payload.Data, configEnvelope); err != nil {
+			return nil, fmt.Errorf("failed to decode config envelope: %w", err)
+		}
+
+		config, err := channelconfig.MarshalJSON(configEnvelope.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		tx.Config = config
+	case cb.HeaderType_CONFIG_UPDATE:
+		var (
+			updateEnvelope = &cb.ConfigUpdateEnvelope{}
+			update         = &cb.ConfigUpdate{}
+		)
+
+		if err := proto.Unmarshal(payload.Data, updateEnvelope); err != nil {
+			return nil, fmt.Errorf("failed to decode config update envelope: %w", err)
+		}
+
+		if err := proto.Unmarshal(updateEnvelope.ConfigUpdate, update); err != nil {
+			return nil, fmt.Errorf("failed to decode config update: %w", err)
+		}
+
+		for _, signature := range updateEnvelope.Signatures {
+			var header = &cb.SignatureHeader{}
+
+			if err := proto.Unmarshal(signature.SignatureHeader, header); err != nil {
+				return nil, fmt.Errorf("failed to decode config update signature header: %w", err)
+			}
+
+			tx.Signatures = append(tx.Signatures, creatorMSPID(header.Creator))
+		}
+
+		configUpdate, err := channelconfig.MarshalJSON(update)
+		if err != nil {
+			return nil, err
+		}
+
+		tx.ConfigUpdate = configUpdate
+	}
+
+	return tx, nil
+}
+
+// describeActions decodes chaincode actions of the endorser transaction `data`.
+func describeActions(data []byte) ([]ActionInfo, error) {
+	var (
+		transaction = &pb.Transaction{}
+		actions     []ActionInfo
+	)
+
+	if err := proto.Unmarshal(data, transaction); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	for _, txAction := range transaction.Actions {
+		var (
+			actionPayload   = &pb.ChaincodeActionPayload{}
+			responsePayload = &pb.ProposalResponsePayload{}
+			chaincodeAction = &pb.ChaincodeAction{}
+			action          = ActionInfo{Endorsements: []string{}}
+		)
+
+		if err := proto.Unmarshal(txAction.Payload, actionPayload); err != nil {
+			return nil, fmt.Errorf("failed to decode chaincode action payload: %w", err)
+		}
+
+		if actionPayload.Action == nil {
+			continue
+		}
+
+		for _, endorsement := range actionPayload.Action.Endorsements {
+			action.Endorsements = append(action.Endorsements, creatorMSPID(endorsement.Endorser))
+		}
+
+		if err := proto.Unmarshal(actionPayload.Action.ProposalResponsePayload, responsePayload); err != nil {
+			return nil, fmt.Errorf("failed to decode proposal response payload: %w", err)
+		}
+
+		if err := proto.Unmarshal(responsePayload.Extension, chaincodeAction); err != nil {
+			return nil, fmt.Errorf("failed to decode chaincode action: %w", err)
+		}
+
+		if chaincodeAction.ChaincodeId != nil {
+			action.Chaincode = chaincodeAction.ChaincodeId.Name
+			action.Version = chaincodeAction.ChaincodeId.Version
+		}
+
+		if chaincodeAction.Response != nil {
+			action.Response = chaincodeAction.Response.Status
+		}
+
+		rwSets, err := describeReadWriteSets(chaincodeAction.Results)
+		if err != nil {
+			return nil, err
+		}
+
+		action.ReadWriteSets = rwSets
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// describeReadWriteSets decodes public read/write sets from the serialized transaction simulation `results`.
+func describeReadWriteSets(results []byte) ([]NsReadWriteSet, error) {
+	var (
+		txRWSet = &rwset.TxReadWriteSet{}
+		sets    []NsReadWriteSet
+	)
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	if err := proto.Unmarshal(results, txRWSet); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction read/write set: %w", err)
+	}
+
+	for _, nsRWSet := range txRWSet.NsRwset {
+		var (
+			kvRWSet = &kvrwset.KVRWSet{}
+			set     = NsReadWriteSet{Namespace: nsRWSet.Namespace}
+		)
+
+		if err := proto.Unmarshal(nsRWSet.Rwset, kvRWSet); err != nil {
+			return nil, fmt.Errorf("failed to decode '%s' namespace read/write set: %w", nsRWSet.Namespace, err)
+		}
+
+		for _, read := range kvRWSet.Reads {
+			var kvRead = KVRead{Key: read.Key}
+
+			if read.Version != nil {
+				kvRead.Version = fmt.Sprintf("%d:%d", read.Version.BlockNum, read.Version.TxNum)
+			}
+
+			set.Reads = append(set.Reads, kvRead)
+		}
+
+		for _, write := range kvRWSet.Writes {
+			set.Writes = append(set.Writes, KVWrite{
+				Key:      write.Key,
+				Value:    readableValue(write.Value),
+				IsDelete: write.IsDelete,
+			})
+		}
+
+		sets = append(sets, set)
+	}
+
+	return sets, nil
+}
+
+// creatorMSPID extracts MSP ID from the serialized identity, or returns empty string if it can't be decoded.
+func creatorMSPID(serializedIdentity []byte) string {
+	var identity = &msp.SerializedIdentity{}
+
+	if err := proto.Unmarshal(serializedIdentity, identity); err != nil {
+		return ""
+	}
+
+	return identity.Mspid
+}
+
+// readableValue returns written `value` as is when it is valid UTF-8 text, otherwise encodes it into base64.
+func readableValue(value []byte) string {
+	if utf8.Valid(value) {
+		return string(value)
+	}
+
+	return base64.StdEncoding.EncodeToString(value)
+}
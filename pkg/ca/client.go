@@ -0,0 +1,195 @@
+// Package ca provides client for the Fabric CA REST API, which is used for registering and enrolling
+// identities against certificate authorities of the network organizations.
+package ca
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/timoth-y/fabnctl/pkg/certs"
+)
+
+// Client is the client of the single Fabric CA, identified by its name, served by the Fabric CA server.
+type Client struct {
+	url    string
+	caName string
+	http   *http.Client
+}
+
+// Identity is the enrolled identity along with certificate chain of the CA, which has issued it.
+type Identity struct {
+	Name string
+	*certs.KeyPair
+	CAChainPEM []byte
+}
+
+// Error is the error returned by the Fabric CA server.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// authorizer authorizes the request `req` with given `payload`.
+type authorizer func(req *http.Request, payload []byte) error
+
+// response is the common envelope of the Fabric CA server responses.
+type response struct {
+	Success bool            `json:"success"`
+	Result  json.RawMessage `json:"result"`
+	Errors  []Error         `json:"errors"`
+}
+
+// NewClient constructs new Client of the CA with given `caName` served on the `url`, e.g. http://localhost:7054.
+// Empty `caName` addresses the default CA of the server.
+func NewClient(url, caName string) *Client {
+	return &Client{
+		url:    strings.TrimSuffix(url, "/"),
+		caName: caName,
+		http: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%s (code: %d)", e.Message, e.Code)
+}
+
+// send performs request to the `endpoint` of the Fabric CA API and decodes its result into `result`.
+func (c *Client) send(
+	ctx context.Context,
+	method, endpoint string,
+	body interface{},
+	auth authorizer,
+	result interface{},
+) error {
+	var payload []byte
+
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode '%s' request: %w", endpoint, err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/api/v1/%s", c.url, endpoint), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to prepare '%s' request: %w", endpoint, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if err = auth(req, payload); err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send '%s' request to Fabric CA: %w", endpoint, err)
+	}
+
+	defer resp.Body.Close()
+
+	respPayload, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read '%s' response of Fabric CA: %w", endpoint, err)
+	}
+
+	var envelope response
+
+	if err = json.Unmarshal(respPayload, &envelope); err != nil {
+		return fmt.Errorf("failed to decode '%s' response of Fabric CA (status %d): %w", endpoint, resp.StatusCode, err)
+	}
+
+	if !envelope.Success || len(envelope.Errors) != 0 {
+		if len(envelope.Errors) == 0 {
+			return fmt.Errorf("'%s' request to Fabric CA failed with status %d", endpoint, resp.StatusCode)
+		}
+
+		return fmt.Errorf("'%s' request to Fabric CA failed: %w", endpoint, envelope.Errors[0])
+	}
+
+	if result != nil {
+		if err = json.Unmarshal(envelope.Result, result); err != nil {
+			return fmt.Errorf("failed to decode '%s' result of Fabric CA: %w", endpoint, err)
+		}
+	}
+
+	return nil
+}
+
+// basicAuth authorizes requests with `name` and `secret` of the identity, as it is done for enrollment.
+func basicAuth(name, secret string) authorizer {
+	return func(req *http.Request, _ []byte) error {
+		req.SetBasicAuth(name, secret)
+		return nil
+	}
+}
+
+// tokenAuth authorizes requests with token of the `registrar` identity.
+func tokenAuth(registrar *Identity) authorizer {
+	return func(req *http.Request, payload []byte) error {
+		token, err := registrar.token(req.Method, req.URL.RequestURI(), payload)
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("Authorization", token)
+		return nil
+	}
+}
+
+// token forms authorization token of the identity for the request with given `method`, `uri` and `body`,
+// the same way Fabric CA client does: it's the base64 encoded certificate along with signature over the request.
+func (id *Identity) token(method, uri string, body []byte) (string, error) {
+	key, err := certs.ParsePrivateKey(id.KeyPEM)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse private key of '%s' identity: %w", id.Name, err)
+	}
+
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return "", fmt.Errorf("private key of '%s' identity must be ECDSA one, got %T", id.Name, key)
+	}
+
+	var (
+		b64Cert = base64.StdEncoding.EncodeToString(id.CertPEM)
+		digest  = sha256.Sum256([]byte(strings.Join([]string{
+			method,
+			base64.StdEncoding.EncodeToString([]byte(uri)),
+			base64.StdEncoding.EncodeToString(body),
+			b64Cert,
+		}, ".")))
+	)
+
+	r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign request with '%s' identity: %w", id.Name, err)
+	}
+
+	// Fabric accepts only signatures with low S value:
+	if halfOrder := new(big.Int).Rsh(ecKey.Params().N, 1); s.Cmp(halfOrder) > 0 {
+		s.Sub(ecKey.Params().N, s)
+	}
+
+	signature, err := asn1.Marshal(struct {
+		R, S *big.Int
+	}{r, s})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request signature: %w", err)
+	}
+
+	return fmt.Sprintf("%s.%s", b64Cert, base64.StdEncoding.EncodeToString(signature)), nil
+}
@@ -0,0 +1,117 @@
+package ca
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/timoth-y/fabnctl/pkg/certs"
+)
+
+// EnrollmentRequest defines parameters of the identity enrollment.
+type EnrollmentRequest struct {
+	// Name is the enrollment ID of the registered identity, which is also used as certificate common name.
+	Name string
+	// Secret is the enrollment secret of the registered identity.
+	Secret string
+	// Profile is the signing profile of the CA, e.g. 'tls' for TLS certificates, or empty for the default one.
+	Profile string
+	// Hosts are DNS names or IP addresses the certificate is valid for.
+	Hosts []string
+}
+
+type enrollmentRequest struct {
+	CSR     string   `json:"certificate_request"`
+	Profile string   `json:"profile,omitempty"`
+	Hosts   []string `json:"hosts,omitempty"`
+	CAName  string   `json:"caname,omitempty"`
+}
+
+type enrollmentResult struct {
+	Cert       string `json:"Cert"`
+	ServerInfo struct {
+		CAName  string `json:"CAName"`
+		CAChain string `json:"CAChain"`
+	} `json:"ServerInfo"`
+}
+
+// Enroll generates new private key for the identity and enrolls it against the CA.
+func (c *Client) Enroll(ctx context.Context, req EnrollmentRequest) (*Identity, error) {
+	return c.enroll(ctx, "enroll", req, basicAuth(req.Name, req.Secret))
+}
+
+// Reenroll issues new certificate for the already enrolled `identity`, e.g. once it's about to expire.
+func (c *Client) Reenroll(ctx context.Context, identity *Identity, req EnrollmentRequest) (*Identity, error) {
+	req.Name = identity.Name
+
+	return c.enroll(ctx, "reenroll", req, tokenAuth(identity))
+}
+
+func (c *Client) enroll(ctx context.Context, endpoint string, req EnrollmentRequest, auth authorizer) (*Identity, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	var template = &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName: req.Name,
+		},
+	}
+
+	for _, host := range req.Hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate request for '%s': %w", req.Name, err)
+	}
+
+	var result enrollmentResult
+
+	if err = c.send(ctx, http.MethodPost, endpoint, enrollmentRequest{
+		CSR:     string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr})),
+		Profile: req.Profile,
+		Hosts:   req.Hosts,
+		CAName:  c.caName,
+	}, auth, &result); err != nil {
+		return nil, fmt.Errorf("failed to enroll '%s' identity: %w", req.Name, err)
+	}
+
+	certPEM, err := base64.StdEncoding.DecodeString(result.Cert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode certificate of '%s' identity: %w", req.Name, err)
+	}
+
+	chainPEM, err := base64.StdEncoding.DecodeString(result.ServerInfo.CAChain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode CA chain of '%s' identity: %w", req.Name, err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	return &Identity{
+		Name: req.Name,
+		KeyPair: &certs.KeyPair{
+			CertPEM: certPEM,
+			KeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		},
+		CAChainPEM: chainPEM,
+	}, nil
+}
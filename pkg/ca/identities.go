@@ -0,0 +1,74 @@
+package ca
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// IdentityInfo describes identity registered with the CA.
+type IdentityInfo struct {
+	Name           string      `json:"id"`
+	Type           string      `json:"type"`
+	Affiliation    string      `json:"affiliation"`
+	Attributes     []Attribute `json:"attrs"`
+	MaxEnrollments int         `json:"max_enrollments"`
+}
+
+// RevocationRequest defines which certificates are revoked.
+type RevocationRequest struct {
+	// Name is the enrollment ID of the identity, which all certificates are revoked.
+	Name string `json:"id,omitempty"`
+	// Serial and AKI are used to revoke single certificate, they're expected as hex strings.
+	Serial string `json:"serial,omitempty"`
+	AKI    string `json:"aki,omitempty"`
+	// Reason is the revocation reason as defined in RFC 5280, e.g. 'keycompromise' or 'superseded'.
+	Reason string `json:"reason,omitempty"`
+	// GenCRL requests CRL generated once certificates are revoked.
+	GenCRL bool   `json:"gencrl,omitempty"`
+	CAName string `json:"caname,omitempty"`
+}
+
+// RevocationResult lists revoked certificates along with CRL, if it was requested.
+type RevocationResult struct {
+	RevokedCerts []struct {
+		Serial string `json:"Serial"`
+		AKI    string `json:"AKI"`
+	} `json:"RevokedCerts"`
+	CRL string `json:"CRL"`
+}
+
+// ListIdentities retrieves identities registered with the CA, which are visible to the `registrar` identity.
+func (c *Client) ListIdentities(ctx context.Context, registrar *Identity) ([]IdentityInfo, error) {
+	var (
+		endpoint = "identities"
+		result   struct {
+			Identities []IdentityInfo `json:"identities"`
+		}
+	)
+
+	if len(c.caName) != 0 {
+		endpoint = fmt.Sprintf("%s?ca=%s", endpoint, url.QueryEscape(c.caName))
+	}
+
+	if err := c.send(ctx, http.MethodGet, endpoint, nil, tokenAuth(registrar), &result); err != nil {
+		return nil, fmt.Errorf("failed to list identities: %w", err)
+	}
+
+	return result.Identities, nil
+}
+
+// Revoke revokes certificates on behalf of the `registrar` identity, so that they're no longer valid.
+// Revoked identity can't enroll anymore, when it's revoked by the name.
+func (c *Client) Revoke(ctx context.Context, registrar *Identity, req RevocationRequest) (*RevocationResult, error) {
+	var result RevocationResult
+
+	req.CAName = c.caName
+
+	if err := c.send(ctx, http.MethodPost, "revoke", req, tokenAuth(registrar), &result); err != nil {
+		return nil, fmt.Errorf("failed to revoke '%s': %w", req.Name, err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,71 @@
+package ca
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Registration defines identity registered with the CA.
+type Registration struct {
+	// Name is the enrollment ID of the identity.
+	Name string `json:"id"`
+	// Type of the identity, e.g. 'peer', 'orderer', 'admin' or 'client'.
+	Type string `json:"type,omitempty"`
+	// Secret is the enrollment secret, which is generated by the CA if not set.
+	Secret string `json:"secret,omitempty"`
+	// Affiliation of the identity, empty one stands for the root affiliation.
+	Affiliation string `json:"affiliation"`
+	// MaxEnrollments limits the number of times the secret can be used for enrollment, 0 stands for the CA default.
+	MaxEnrollments int `json:"max_enrollments,omitempty"`
+	// Attributes of the identity.
+	Attributes []Attribute `json:"attrs,omitempty"`
+	CAName     string      `json:"caname,omitempty"`
+}
+
+// Attribute is the attribute of the registered identity.
+type Attribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// ECert defines whether the attribute is added into enrollment certificates by default.
+	ECert bool `json:"ecert,omitempty"`
+}
+
+// ErrAlreadyRegistered is returned by Client.Register when identity with such name is already registered.
+var ErrAlreadyRegistered = errors.New("identity is already registered")
+
+// Register registers identity on behalf of the `registrar` one. Returns enrollment secret of the identity.
+func (c *Client) Register(ctx context.Context, registrar *Identity, registration Registration) (string, error) {
+	var result struct {
+		Secret string `json:"secret"`
+	}
+
+	registration.CAName = c.caName
+
+	if err := c.send(ctx, http.MethodPost, "register", registration, tokenAuth(registrar), &result); err != nil {
+		if strings.Contains(err.Error(), "already registered") {
+			return "", fmt.Errorf("failed to register '%s': %w", registration.Name, ErrAlreadyRegistered)
+		}
+
+		return "", fmt.Errorf("failed to register '%s': %w", registration.Name, err)
+	}
+
+	return result.Secret, nil
+}
+
+// ModifyIdentity updates already registered identity on behalf of the `registrar` one,
+// e.g. to reset its enrollment secret. Empty fields of the `registration` are left unchanged.
+func (c *Client) ModifyIdentity(ctx context.Context, registrar *Identity, registration Registration) error {
+	registration.CAName = c.caName
+
+	if err := c.send(ctx, http.MethodPut, fmt.Sprintf("identities/%s", url.PathEscape(registration.Name)),
+		registration, tokenAuth(registrar), nil,
+	); err != nil {
+		return fmt.Errorf("failed to modify '%s' identity: %w", registration.Name, err)
+	}
+
+	return nil
+}
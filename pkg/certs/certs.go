@@ -0,0 +1,226 @@
+// Package certs provides methods for issuing X.509 certificates signed by the organization certificate authority,
+// which material is generated by cryptogen or Fabric CA.
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path"
+	"strings"
+	"time"
+)
+
+// DefaultValidity is the validity period of the issued certificates.
+const DefaultValidity = 365 * 24 * time.Hour
+
+// CA is the certificate authority capable of issuing certificates.
+type CA struct {
+	Certificate *x509.Certificate
+	CertPEM     []byte
+	PrivateKey  crypto.Signer
+}
+
+// KeyPair is the PEM encoded certificate along with its private key.
+type KeyPair struct {
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+// LoadCA loads certificate authority from the `dir` directory, which contains '*-cert.pem' certificate
+// and '*_sk' private key, as the 'ca' and 'tlsca' directories generated by cryptogen.
+func LoadCA(dir string) (*CA, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s' CA directory: %w", dir, err)
+	}
+
+	var certPEM, keyPEM []byte
+
+	for _, file := range files {
+		switch {
+		case file.IsDir():
+			continue
+		case strings.HasSuffix(file.Name(), "-cert.pem"):
+			certPEM, err = ioutil.ReadFile(path.Join(dir, file.Name()))
+		case strings.HasSuffix(file.Name(), "_sk"):
+			keyPEM, err = ioutil.ReadFile(path.Join(dir, file.Name()))
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s' CA file: %w", file.Name(), err)
+		}
+	}
+
+	if certPEM == nil {
+		return nil, fmt.Errorf("no CA certificate found in '%s' directory", dir)
+	}
+
+	if keyPEM == nil {
+		return nil, fmt.Errorf("no CA private key found in '%s' directory", dir)
+	}
+
+	return ParseCA(certPEM, keyPEM)
+}
+
+// ParseCA constructs certificate authority from its PEM encoded certificate and private key.
+func ParseCA(certPEM, keyPEM []byte) (*CA, error) {
+	cert, err := ParseCertificate(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ParsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CA{
+		Certificate: cert,
+		CertPEM:     certPEM,
+		PrivateKey:  key,
+	}, nil
+}
+
+// Issue issues new certificate for the `commonName` subject, which can be used for given `usage`,
+// e.g. x509.ExtKeyUsageServerAuth, and is valid for `hosts` DNS names or IP addresses.
+func (ca *CA) Issue(commonName string, usage []x509.ExtKeyUsage, hosts ...string) (*KeyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	return ca.issue(pkix.Name{
+		CommonName:   commonName,
+		Organization: ca.Certificate.Subject.Organization,
+		Country:      ca.Certificate.Subject.Country,
+		Province:     ca.Certificate.Subject.Province,
+		Locality:     ca.Certificate.Subject.Locality,
+	}, usage, hosts, key)
+}
+
+// Reissue issues new certificate for the same subject, usage and hosts as the `cert` one, thus renewing it.
+// Given `key` is reused unless it's nil, so that parties pinning the certificate public key keep trusting it.
+func (ca *CA) Reissue(cert *x509.Certificate, key crypto.Signer) (*KeyPair, error) {
+	var hosts = append([]string{}, cert.DNSNames...)
+
+	for _, ip := range cert.IPAddresses {
+		hosts = append(hosts, ip.String())
+	}
+
+	if key == nil {
+		var err error
+
+		if key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+			return nil, fmt.Errorf("failed to generate private key: %w", err)
+		}
+	}
+
+	return ca.issue(cert.Subject, cert.ExtKeyUsage, hosts, key)
+}
+
+// issue issues new certificate for the `subject` bound to the public part of the `key`.
+func (ca *CA) issue(subject pkix.Name, usage []x509.ExtKeyUsage, hosts []string, key crypto.Signer) (*KeyPair, error) {
+	publicKey, ok := key.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("private key of %T type isn't supported, ECDSA key expected", key)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate certificate serial number: %w", err)
+	}
+
+	var (
+		now      = time.Now()
+		template = &x509.Certificate{
+			SerialNumber:          serial,
+			Subject:               subject,
+			NotBefore:             now.Add(-5 * time.Minute),
+			NotAfter:              now.Add(DefaultValidity),
+			KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			ExtKeyUsage:           usage,
+			BasicConstraintsValid: true,
+			SubjectKeyId:          subjectKeyID(publicKey),
+			AuthorityKeyId:        ca.Certificate.SubjectKeyId,
+		}
+	)
+
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Certificate, publicKey, ca.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue certificate for '%s': %w", subject.CommonName, err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	return &KeyPair{
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+// ParseCertificate decodes PEM encoded X.509 certificate.
+func ParseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return cert, nil
+}
+
+// ParsePrivateKey decodes PEM encoded private key, either in PKCS #8 or SEC 1 form.
+func ParsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded private key found")
+	}
+
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("private key of %T type can't be used for signing", key)
+	}
+
+	return signer, nil
+}
+
+// subjectKeyID computes subject key identifier of the `key` in the same way as cryptogen does.
+func subjectKeyID(key *ecdsa.PublicKey) []byte {
+	var hash = sha256.Sum256(elliptic.Marshal(key.Curve, key.X, key.Y))
+
+	return hash[:]
+}
@@ -0,0 +1,246 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestCA generates self-signed certificate authority in the same form as cryptogen does.
+func newTestCA(t *testing.T) *CA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var template = &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			CommonName:   "tlsca.org1.org.example.com",
+			Organization: []string{"org1.org.example.com"},
+			Country:      []string{"US"},
+		},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(10 * DefaultValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          subjectKeyID(&key.PublicKey),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ca, err := ParseCA(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ca
+}
+
+func TestIssue(t *testing.T) {
+	var ca = newTestCA(t)
+
+	pair, err := ca.Issue("peer0.org1.org.example.com",
+		[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		"peer0.org1.org.example.com", "peer0-org1-org", "127.0.0.1",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cert = parseIssued(t, ca, pair)
+
+	if cert.Subject.CommonName != "peer0.org1.org.example.com" {
+		t.Errorf("unexpected subject: %s", cert.Subject)
+	}
+
+	if !reflect.DeepEqual(cert.Subject.Organization, ca.Certificate.Subject.Organization) {
+		t.Errorf("expected organization of the CA, got: %v", cert.Subject.Organization)
+	}
+
+	if !reflect.DeepEqual(cert.DNSNames, []string{"peer0.org1.org.example.com", "peer0-org1-org"}) {
+		t.Errorf("unexpected DNS names: %v", cert.DNSNames)
+	}
+
+	if len(cert.IPAddresses) != 1 || !cert.IPAddresses[0].Equal(net.ParseIP("127.0.0.1")) {
+		t.Errorf("unexpected IP addresses: %v", cert.IPAddresses)
+	}
+}
+
+func TestReissue(t *testing.T) {
+	var ca = newTestCA(t)
+
+	pair, err := ca.Issue("orderer.example.com",
+		[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		"orderer.example.com", "orderer", "10.0.0.1",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	originalKey, err := ParsePrivateKey(pair.KeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Certificate is about to expire, so that renewed validity can be told apart:
+	var template = parseIssued(t, ca, pair)
+	template.NotBefore = time.Now().Add(-DefaultValidity)
+	template.NotAfter = time.Now().Add(48 * time.Hour)
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Certificate, originalKey.Public(), ca.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("new key", func(t *testing.T) {
+		reissued, err := ca.Reissue(original, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var cert = parseIssued(t, ca, reissued)
+
+		assertRenewed(t, original, cert)
+
+		if reflect.DeepEqual(cert.PublicKey, original.PublicKey) {
+			t.Error("expected certificate to be bound to the new key")
+		}
+	})
+
+	t.Run("same key", func(t *testing.T) {
+		reissued, err := ca.Reissue(original, originalKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var cert = parseIssued(t, ca, reissued)
+
+		assertRenewed(t, original, cert)
+
+		if !reflect.DeepEqual(cert.PublicKey, original.PublicKey) {
+			t.Error("expected certificate to keep the public key")
+		}
+	})
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sec1, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, keyPEM := range map[string][]byte{
+		"SEC 1":   pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}),
+		"PKCS #8": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+	} {
+		parsed, err := ParsePrivateKey(keyPEM)
+		if err != nil {
+			t.Errorf("failed to parse %s key: %v", name, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(parsed.Public(), key.Public()) {
+			t.Errorf("parsed %s key doesn't match the original one", name)
+		}
+	}
+
+	if _, err = ParsePrivateKey([]byte("not a key")); err == nil {
+		t.Error("expected error for malformed key, got <nil>")
+	}
+}
+
+// parseIssued parses certificate of the `pair` and verifies it's signed by the `ca`.
+func parseIssued(t *testing.T, ca *CA, pair *KeyPair) *x509.Certificate {
+	t.Helper()
+
+	cert, err := ParseCertificate(pair.CertPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = cert.CheckSignatureFrom(ca.Certificate); err != nil {
+		t.Fatalf("certificate isn't signed by the CA: %v", err)
+	}
+
+	key, err := ParsePrivateKey(pair.KeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(key.Public(), cert.PublicKey) {
+		t.Fatal("private key doesn't match the certificate")
+	}
+
+	return cert
+}
+
+// assertRenewed checks that `renewed` certificate keeps identity of the `original` one with new serial and validity.
+func assertRenewed(t *testing.T, original, renewed *x509.Certificate) {
+	t.Helper()
+
+	if renewed.Subject.String() != original.Subject.String() {
+		t.Errorf("expected '%s' subject, got '%s'", original.Subject, renewed.Subject)
+	}
+
+	if !reflect.DeepEqual(renewed.DNSNames, original.DNSNames) {
+		t.Errorf("expected %v DNS names, got %v", original.DNSNames, renewed.DNSNames)
+	}
+
+	if !reflect.DeepEqual(renewed.IPAddresses, original.IPAddresses) {
+		t.Errorf("expected %v IP addresses, got %v", original.IPAddresses, renewed.IPAddresses)
+	}
+
+	if !reflect.DeepEqual(renewed.ExtKeyUsage, original.ExtKeyUsage) {
+		t.Errorf("expected %v key usage, got %v", original.ExtKeyUsage, renewed.ExtKeyUsage)
+	}
+
+	if renewed.SerialNumber.Cmp(original.SerialNumber) == 0 {
+		t.Error("expected new serial number")
+	}
+
+	if !renewed.NotBefore.After(original.NotBefore) || !renewed.NotAfter.After(original.NotAfter) {
+		t.Errorf("expected validity to be renewed, got %s - %s", renewed.NotBefore, renewed.NotAfter)
+	}
+
+	if validity := renewed.NotAfter.Sub(time.Now()); validity < DefaultValidity-time.Hour {
+		t.Errorf("expected certificate to be valid for %s, got %s", DefaultValidity, validity)
+	}
+}
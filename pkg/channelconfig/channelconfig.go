@@ -0,0 +1,136 @@
+// Package channelconfig provides methods for decoding, modifying and signing Hyperledger Fabric channel configuration
+// natively in Go, without relying on the 'peer' and 'configtxlator' CLI binaries.
+package channelconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-config/protolator"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+)
+
+// DecodeBlock decodes serialized block, e.g. fetched with 'peer channel fetch' command.
+func DecodeBlock(payload []byte) (*cb.Block, error) {
+	var block = &cb.Block{}
+
+	if err := proto.Unmarshal(payload, block); err != nil {
+		return nil, fmt.Errorf("failed to decode block: %w", err)
+	}
+
+	return block, nil
+}
+
+// ConfigFromBlock extracts channel configuration from the config `block`.
+func ConfigFromBlock(block *cb.Block) (*cb.Config, error) {
+	if block.Data == nil || len(block.Data.Data) == 0 {
+		return nil, fmt.Errorf("block contains no data")
+	}
+
+	var (
+		envelope       = &cb.Envelope{}
+		payload        = &cb.Payload{}
+		configEnvelope = &cb.ConfigEnvelope{}
+	)
+
+	if err := proto.Unmarshal(block.Data.Data[0], envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode block envelope: %w", err)
+	}
+
+	if err := proto.Unmarshal(envelope.Payload, payload); err != nil {
+		return nil, fmt.Errorf("failed to decode envelope payload: %w", err)
+	}
+
+	if payload.Header == nil {
+		return nil, fmt.Errorf("envelope payload is missing header")
+	}
+
+	var header = &cb.ChannelHeader{}
+	if err := proto.Unmarshal(payload.Header.ChannelHeader, header); err != nil {
+		return nil, fmt.Errorf("failed to decode channel header: %w", err)
+	}
+
+	if header.Type != int32(cb.HeaderType_CONFIG) {
+		return nil, fmt.Errorf("block isn't a config block, its transaction type is %s",
+			cb.HeaderType_name[header.Type],
+		)
+	}
+
+	if err := proto.Unmarshal(payload.Data, configEnvelope); err != nil {
+		return nil, fmt.Errorf("failed to decode config envelope: %w", err)
+	}
+
+	if configEnvelope.Config == nil {
+		return nil, fmt.Errorf("config envelope contains no config")
+	}
+
+	return configEnvelope.Config, nil
+}
+
+// ConfigFromBlockBytes decodes channel configuration from the serialized config block.
+func ConfigFromBlockBytes(payload []byte) (*cb.Config, error) {
+	block, err := DecodeBlock(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConfigFromBlock(block)
+}
+
+// MarshalJSON encodes `msg` into human readable JSON, in which nested messages are expanded
+// instead of being encoded as base64 bytes. Format matches one of 'configtxlator proto_decode' command.
+func MarshalJSON(msg proto.Message) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	if err := protolator.DeepMarshalJSON(&buffer, msg); err != nil {
+		return nil, fmt.Errorf("failed to encode %T into JSON: %w", msg, err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON decodes `msg` from the JSON produced by MarshalJSON.
+func UnmarshalJSON(payload []byte, msg proto.Message) error {
+	if err := protolator.DeepUnmarshalJSON(bytes.NewReader(payload), msg); err != nil {
+		return fmt.Errorf("failed to decode %T from JSON: %w", msg, err)
+	}
+
+	return nil
+}
+
+// ConfigToMap converts channel `config` into generic JSON map, which can be modified directly.
+func ConfigToMap(config *cb.Config) (map[string]interface{}, error) {
+	var tree map[string]interface{}
+
+	payload, err := MarshalJSON(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoder = json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	if err = decoder.Decode(&tree); err != nil {
+		return nil, fmt.Errorf("failed to decode config JSON: %w", err)
+	}
+
+	return tree, nil
+}
+
+// ConfigFromMap converts generic JSON map formed by ConfigToMap back to channel config.
+func ConfigFromMap(tree map[string]interface{}) (*cb.Config, error) {
+	var config = &cb.Config{}
+
+	payload, err := json.Marshal(tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config JSON: %w", err)
+	}
+
+	if err = UnmarshalJSON(payload, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
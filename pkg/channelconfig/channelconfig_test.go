@@ -0,0 +1,194 @@
+package channelconfig
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+)
+
+// loadConfig decodes channel config from the testdata config block.
+func loadConfig(t *testing.T) *cb.Config {
+	t.Helper()
+
+	payload, err := ioutil.ReadFile("testdata/config_block.pb")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := ConfigFromBlockBytes(payload)
+	if err != nil {
+		t.Fatalf("failed to decode config block: %v", err)
+	}
+
+	return config
+}
+
+func TestConfigFromBlockBytes(t *testing.T) {
+	var config = loadConfig(t)
+
+	groups := config.ChannelGroup.Groups
+	if _, ok := groups["Application"].Groups["org1"]; !ok {
+		t.Errorf("expected 'org1' organization in application group, got: %v", keys(groups["Application"].Groups))
+	}
+
+	if _, ok := groups["Orderer"].Groups["orderer"]; !ok {
+		t.Errorf("expected 'orderer' organization in orderer group, got: %v", keys(groups["Orderer"].Groups))
+	}
+}
+
+func TestConfigFromBlockErrors(t *testing.T) {
+	var tests = []struct {
+		name  string
+		block *cb.Block
+	}{
+		{
+			name:  "no data",
+			block: &cb.Block{},
+		},
+		{
+			name: "invalid envelope",
+			block: &cb.Block{Data: &cb.BlockData{
+				Data: [][]byte{[]byte("not an envelope")},
+			}},
+		},
+		{
+			name: "not a config transaction",
+			block: &cb.Block{Data: &cb.BlockData{
+				Data: [][]byte{envelopeOfType(t, cb.HeaderType_ENDORSER_TRANSACTION)},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ConfigFromBlock(tt.block); err == nil {
+				t.Error("expected error, got <nil>")
+			}
+		})
+	}
+}
+
+func TestConfigMapRoundTrip(t *testing.T) {
+	var config = loadConfig(t)
+
+	tree, err := ConfigToMap(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := ConfigFromMap(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(config, decoded) {
+		t.Error("config changed after map round trip")
+	}
+}
+
+func TestComputeUpdate(t *testing.T) {
+	var config = loadConfig(t)
+
+	tree, err := ConfigToMap(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batchSize := configNode(t, tree, "channel_group", "groups", "Orderer", "values", "BatchSize", "value")
+	batchSize["max_message_count"] = json.Number("20")
+
+	modified, err := ConfigFromMap(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := ComputeUpdate("test-channel", config, modified)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var update = &cb.ConfigUpdate{}
+	if err = proto.Unmarshal(payload, update); err != nil {
+		t.Fatal(err)
+	}
+
+	if update.ChannelId != "test-channel" {
+		t.Errorf("expected 'test-channel' channel ID, got '%s'", update.ChannelId)
+	}
+
+	var (
+		original = config.ChannelGroup.Groups["Orderer"].Values["BatchSize"]
+		written  = update.WriteSet.Groups["Orderer"].Values["BatchSize"]
+	)
+
+	if written == nil {
+		t.Fatal("expected BatchSize value in the update write set")
+	}
+
+	if written.Version != original.Version+1 {
+		t.Errorf("expected BatchSize version %d, got %d", original.Version+1, written.Version)
+	}
+
+	if _, ok := update.WriteSet.Groups["Application"]; ok {
+		t.Error("expected unchanged Application group to be left out of the write set")
+	}
+}
+
+func TestComputeUpdateNoChanges(t *testing.T) {
+	var config = loadConfig(t)
+
+	if _, err := ComputeUpdate("test-channel", config, proto.Clone(config).(*cb.Config)); err == nil {
+		t.Error("expected error for update without changes, got <nil>")
+	}
+}
+
+// configNode walks generic JSON `tree` by `keys` and returns the map found at the end.
+func configNode(t *testing.T, tree map[string]interface{}, keys ...string) map[string]interface{} {
+	t.Helper()
+
+	for _, key := range keys {
+		next, ok := tree[key].(map[string]interface{})
+		if !ok {
+			t.Fatalf("key '%s' not found in config tree", key)
+		}
+
+		tree = next
+	}
+
+	return tree
+}
+
+// envelopeOfType forms serialized envelope with the channel header of given type and empty payload data.
+func envelopeOfType(t *testing.T, headerType cb.HeaderType) []byte {
+	t.Helper()
+
+	header, err := proto.Marshal(&cb.ChannelHeader{Type: int32(headerType), ChannelId: "test-channel"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := proto.Marshal(&cb.Payload{Header: &cb.Header{ChannelHeader: header}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	envelope, err := proto.Marshal(&cb.Envelope{Payload: payload})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return envelope
+}
+
+func keys(groups map[string]*cb.ConfigGroup) []string {
+	var names []string
+
+	for name := range groups {
+		names = append(names, name)
+	}
+
+	return names
+}
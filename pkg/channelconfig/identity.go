@@ -0,0 +1,72 @@
+package channelconfig
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"path"
+
+	"github.com/hyperledger/fabric-config/configtx"
+)
+
+// SigningIdentity is the MSP identity used for signing channel config updates.
+type SigningIdentity = configtx.SigningIdentity
+
+// LoadSigningIdentity loads signing identity of the `mspID` organization member from `mspDir` directory,
+// which contains 'signcerts' and 'keystore' subdirectories as generated by cryptogen or Fabric CA client.
+func LoadSigningIdentity(mspID, mspDir string) (*SigningIdentity, error) {
+	certPEM, err := readSingleFile(path.Join(mspDir, "signcerts"))
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := readSingleFile(path.Join(mspDir, "keystore"))
+	if err != nil {
+		return nil, err
+	}
+
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return nil, fmt.Errorf("no PEM encoded certificate found in '%s' MSP signcerts", mspDir)
+	}
+
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse '%s' MSP certificate: %w", mspDir, err)
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, fmt.Errorf("no PEM encoded private key found in '%s' MSP keystore", mspDir)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		if key, err = x509.ParseECPrivateKey(keyBlock.Bytes); err != nil {
+			return nil, fmt.Errorf("failed to parse '%s' MSP private key: %w", mspDir, err)
+		}
+	}
+
+	return &SigningIdentity{
+		Certificate: cert,
+		PrivateKey:  key,
+		MSPID:       mspID,
+	}, nil
+}
+
+// readSingleFile reads the first regular file found in the `dir`.
+func readSingleFile(dir string) ([]byte, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s' directory: %w", dir, err)
+	}
+
+	for _, file := range files {
+		if !file.IsDir() {
+			return ioutil.ReadFile(path.Join(dir, file.Name()))
+		}
+	}
+
+	return nil, fmt.Errorf("no files found in '%s' directory", dir)
+}
@@ -0,0 +1,170 @@
+//go:build ignore
+// +build ignore
+
+// Generates testdata config block of the application channel and MSPs of its organizations admins:
+//
+//	go run testdata/generate.go testdata
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-config/configtx"
+	"github.com/hyperledger/fabric-config/configtx/membership"
+	"github.com/hyperledger/fabric-config/configtx/orderer"
+)
+
+var notBefore = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+func ca(cn string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must(err)
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn, Organization: []string{cn}},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.AddDate(100, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	must(err)
+	cert, err := x509.ParseCertificate(der)
+	must(err)
+	return cert, key
+}
+
+func leaf(cn, ou string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must(err)
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: cn, OrganizationalUnit: []string{ou}},
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.AddDate(100, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, parent, &key.PublicKey, parentKey)
+	must(err)
+	cert, err := x509.ParseCertificate(der)
+	must(err)
+	return cert, key
+}
+
+func msp(id string, root *x509.Certificate) configtx.MSP {
+	return configtx.MSP{
+		Name:         id,
+		RootCerts:    []*x509.Certificate{root},
+		TLSRootCerts: []*x509.Certificate{root},
+		NodeOUs: membership.NodeOUs{
+			Enable:              true,
+			ClientOUIdentifier:  membership.OUIdentifier{Certificate: root, OrganizationalUnitIdentifier: "client"},
+			PeerOUIdentifier:    membership.OUIdentifier{Certificate: root, OrganizationalUnitIdentifier: "peer"},
+			AdminOUIdentifier:   membership.OUIdentifier{Certificate: root, OrganizationalUnitIdentifier: "admin"},
+			OrdererOUIdentifier: membership.OUIdentifier{Certificate: root, OrganizationalUnitIdentifier: "orderer"},
+		},
+	}
+}
+
+func policies(mspID string, withEndorsement bool) map[string]configtx.Policy {
+	p := map[string]configtx.Policy{
+		configtx.ReadersPolicyKey: {Type: configtx.SignaturePolicyType, Rule: "OR('" + mspID + ".member')"},
+		configtx.WritersPolicyKey: {Type: configtx.SignaturePolicyType, Rule: "OR('" + mspID + ".member')"},
+		configtx.AdminsPolicyKey:  {Type: configtx.SignaturePolicyType, Rule: "OR('" + mspID + ".admin')"},
+	}
+	if withEndorsement {
+		p[configtx.EndorsementPolicyKey] = configtx.Policy{Type: configtx.SignaturePolicyType, Rule: "OR('" + mspID + ".peer')"}
+	}
+	return p
+}
+
+func implicit() map[string]configtx.Policy {
+	return map[string]configtx.Policy{
+		configtx.ReadersPolicyKey: {Type: configtx.ImplicitMetaPolicyType, Rule: "ANY Readers"},
+		configtx.WritersPolicyKey: {Type: configtx.ImplicitMetaPolicyType, Rule: "ANY Writers"},
+		configtx.AdminsPolicyKey:  {Type: configtx.ImplicitMetaPolicyType, Rule: "MAJORITY Admins"},
+	}
+}
+
+func writePEM(file, typ string, der []byte) {
+	must(os.MkdirAll(path.Dir(file), 0755))
+	must(ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0644))
+}
+
+func main() {
+	out := os.Args[1]
+
+	ordererCA, _ := ca("example.com")
+	org1CA, org1Key := ca("org1.example.com")
+	org2CA, org2Key := ca("org2.example.com")
+
+	for _, o := range []struct {
+		name string
+		ca   *x509.Certificate
+		key  *ecdsa.PrivateKey
+	}{{"org1", org1CA, org1Key}, {"org2", org2CA, org2Key}} {
+		admin, adminKey := leaf("Admin@"+o.name+".example.com", "admin", o.ca, o.key)
+		dir := path.Join(out, o.name, "msp")
+		writePEM(path.Join(dir, "signcerts", "Admin@"+o.name+".example.com-cert.pem"), "CERTIFICATE", admin.Raw)
+		keyDER, err := x509.MarshalPKCS8PrivateKey(adminKey)
+		must(err)
+		writePEM(path.Join(dir, "keystore", "priv_sk"), "PRIVATE KEY", keyDER)
+		writePEM(path.Join(dir, "cacerts", "ca."+o.name+".example.com-cert.pem"), "CERTIFICATE", o.ca.Raw)
+	}
+
+	ordererPolicies := implicit()
+	ordererPolicies[configtx.BlockValidationPolicyKey] = configtx.Policy{Type: configtx.ImplicitMetaPolicyType, Rule: "ANY Writers"}
+
+	block, err := configtx.NewApplicationChannelGenesisBlock(configtx.Channel{
+		Consortium: "",
+		Application: configtx.Application{
+			Policies: func() map[string]configtx.Policy {
+				p := implicit()
+				p[configtx.EndorsementPolicyKey] = configtx.Policy{Type: configtx.ImplicitMetaPolicyType, Rule: "MAJORITY Endorsement"}
+				p[configtx.LifecycleEndorsementPolicyKey] = configtx.Policy{Type: configtx.ImplicitMetaPolicyType, Rule: "MAJORITY Endorsement"}
+				return p
+			}(),
+			Organizations: []configtx.Organization{
+				{Name: "org1", Policies: policies("org1", true), MSP: msp("org1", org1CA)},
+			},
+			Capabilities: []string{"V2_0"},
+		},
+		Orderer: configtx.Orderer{
+			OrdererType: orderer.ConsensusTypeSolo,
+			Policies:    ordererPolicies,
+			Organizations: []configtx.Organization{
+				{Name: "orderer", Policies: policies("orderer", false), MSP: msp("orderer", ordererCA),
+					OrdererEndpoints: []string{"orderer.example.com:443"}},
+			},
+			Capabilities: []string{"V2_0"},
+			BatchTimeout: 2 * time.Second,
+			BatchSize:    orderer.BatchSize{MaxMessageCount: 10, AbsoluteMaxBytes: 99 * 1024 * 1024, PreferredMaxBytes: 512 * 1024},
+			State:        orderer.ConsensusStateNormal,
+		},
+		Capabilities: []string{"V2_0"},
+		Policies:     implicit(),
+	}, "test-channel")
+	must(err)
+
+	payload, err := proto.Marshal(block)
+	must(err)
+	must(ioutil.WriteFile(path.Join(out, "config_block.pb"), payload, 0644))
+}
@@ -0,0 +1,66 @@
+package channelconfig
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-config/configtx"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+)
+
+// ComputeUpdate computes serialized config update, which transforms `original` channel config into `modified` one.
+func ComputeUpdate(channelID string, original, modified *cb.Config) ([]byte, error) {
+	var (
+		tx      = configtx.New(original)
+		updated = tx.UpdatedConfig()
+	)
+
+	updated.Reset()
+	proto.Merge(updated, modified)
+
+	update, err := tx.ComputeMarshaledUpdate(channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute config update of '%s' channel: %w", channelID, err)
+	}
+
+	return update, nil
+}
+
+// NewUpdateEnvelope wraps serialized config `update` into transaction envelope signed by all given `signers`.
+// Envelope itself is signed by the first one, so that it can be submitted to the orderer directly.
+func NewUpdateEnvelope(update []byte, signers ...*SigningIdentity) (*cb.Envelope, error) {
+	var signatures = make([]*cb.ConfigSignature, 0, len(signers))
+
+	for _, signer := range signers {
+		signature, err := signer.CreateConfigSignature(update)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign config update by '%s': %w", signer.MSPID, err)
+		}
+
+		signatures = append(signatures, signature)
+	}
+
+	envelope, err := configtx.NewEnvelope(update, signatures...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to form config update envelope: %w", err)
+	}
+
+	if len(signers) != 0 {
+		if err = signers[0].SignEnvelope(envelope); err != nil {
+			return nil, fmt.Errorf("failed to sign config update envelope by '%s': %w", signers[0].MSPID, err)
+		}
+	}
+
+	return envelope, nil
+}
+
+// DecodeEnvelope decodes serialized transaction envelope, e.g. config update '.tx' file.
+func DecodeEnvelope(payload []byte) (*cb.Envelope, error) {
+	var envelope = &cb.Envelope{}
+
+	if err := proto.Unmarshal(payload, envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode envelope: %w", err)
+	}
+
+	return envelope, nil
+}
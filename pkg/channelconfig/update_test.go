@@ -0,0 +1,159 @@
+package channelconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+)
+
+// loadSigner loads signing identity of the `org` admin from the testdata MSP.
+func loadSigner(t *testing.T, org string) *SigningIdentity {
+	t.Helper()
+
+	signer, err := LoadSigningIdentity(org, "testdata/"+org+"/msp")
+	if err != nil {
+		t.Fatalf("failed to load '%s' signing identity: %v", org, err)
+	}
+
+	return signer
+}
+
+func TestLoadSigningIdentity(t *testing.T) {
+	var signer = loadSigner(t, "org1")
+
+	if signer.MSPID != "org1" {
+		t.Errorf("expected 'org1' MSP ID, got '%s'", signer.MSPID)
+	}
+
+	if signer.Certificate.Subject.CommonName != "Admin@org1.example.com" {
+		t.Errorf("unexpected certificate subject: %s", signer.Certificate.Subject)
+	}
+
+	if _, err := LoadSigningIdentity("org1", "testdata/missing/msp"); err == nil {
+		t.Error("expected error for missing MSP directory, got <nil>")
+	}
+}
+
+func TestNewUpdateEnvelope(t *testing.T) {
+	var (
+		update  = computeTestUpdate(t)
+		signers = []*SigningIdentity{loadSigner(t, "org1"), loadSigner(t, "org2")}
+	)
+
+	envelope, err := NewUpdateEnvelope(update, signers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(envelope.Signature) == 0 {
+		t.Error("expected envelope to be signed by the first signer")
+	}
+
+	var updateEnv = decodeUpdateEnvelope(t, envelope)
+
+	if !proto.Equal(unmarshalUpdate(t, updateEnv.ConfigUpdate), unmarshalUpdate(t, update)) {
+		t.Error("config update changed after wrapping into envelope")
+	}
+
+	verifySignatures(t, updateEnv, signers...)
+}
+
+// computeTestUpdate computes config update, which changes orderer batch timeout of the testdata channel config.
+func computeTestUpdate(t *testing.T) []byte {
+	t.Helper()
+
+	var config = loadConfig(t)
+
+	tree, err := ConfigToMap(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configNode(t, tree, "channel_group", "groups", "Orderer", "values", "BatchTimeout", "value")["timeout"] = "5s"
+
+	modified, err := ConfigFromMap(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update, err := ComputeUpdate("test-channel", config, modified)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return update
+}
+
+// verifySignatures checks that config update in `updateEnv` is signed by each of the `signers` in the given order.
+func verifySignatures(t *testing.T, updateEnv *cb.ConfigUpdateEnvelope, signers ...*SigningIdentity) {
+	t.Helper()
+
+	if len(updateEnv.Signatures) != len(signers) {
+		t.Fatalf("expected %d signatures, got %d", len(signers), len(updateEnv.Signatures))
+	}
+
+	for i, signer := range signers {
+		var (
+			signature = updateEnv.Signatures[i]
+			header    = &cb.SignatureHeader{}
+			creator   = &mb.SerializedIdentity{}
+		)
+
+		if err := proto.Unmarshal(signature.SignatureHeader, header); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := proto.Unmarshal(header.Creator, creator); err != nil {
+			t.Fatal(err)
+		}
+
+		if creator.Mspid != signer.MSPID {
+			t.Errorf("expected signature %d created by '%s', got '%s'", i, signer.MSPID, creator.Mspid)
+		}
+
+		var digest = sha256.Sum256(append(append([]byte{}, signature.SignatureHeader...), updateEnv.ConfigUpdate...))
+
+		publicKey, ok := signer.Certificate.PublicKey.(*ecdsa.PublicKey)
+		if !ok {
+			t.Fatalf("unexpected '%s' public key type: %T", signer.MSPID, signer.Certificate.PublicKey)
+		}
+
+		if !ecdsa.VerifyASN1(publicKey, digest[:], signature.Signature) {
+			t.Errorf("signature %d isn't valid signature of '%s'", i, signer.MSPID)
+		}
+	}
+}
+
+func decodeUpdateEnvelope(t *testing.T, envelope *cb.Envelope) *cb.ConfigUpdateEnvelope {
+	t.Helper()
+
+	var (
+		payload   = &cb.Payload{}
+		updateEnv = &cb.ConfigUpdateEnvelope{}
+	)
+
+	if err := proto.Unmarshal(envelope.Payload, payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := proto.Unmarshal(payload.Data, updateEnv); err != nil {
+		t.Fatal(err)
+	}
+
+	return updateEnv
+}
+
+func unmarshalUpdate(t *testing.T, payload []byte) *cb.ConfigUpdate {
+	t.Helper()
+
+	var update = &cb.ConfigUpdate{}
+	if err := proto.Unmarshal(payload, update); err != nil {
+		t.Fatal(err)
+	}
+
+	return update
+}
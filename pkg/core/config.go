@@ -22,6 +22,8 @@ func init() {
 
 	viper.SetDefault("fabric.orderer_hostname_name", "orderer")
 
+	viper.SetDefault("crypto.store", "local")
+
 	viper.Set("cli.success_emoji", "👍")
 	viper.Set("cli.ok_emoji", "👌")
 	viper.Set("cli.error_emoji", "\n❌")
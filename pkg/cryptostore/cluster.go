@@ -0,0 +1,160 @@
+package cryptostore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterStore is the Store keeping crypto material files in Kubernetes secrets labelled with 'fabnctl/cid=crypto.store'.
+// Each directory is stored as the separate secret annotated with its path, which keys are base64 encoded file names,
+// since names like 'Admin@org1.org.example.com-cert.pem' aren't valid secret keys.
+type ClusterStore struct {
+	domain    string
+	namespace string
+}
+
+// NewCluster constructs new ClusterStore instance keeping files of the network on the `domain` in the `namespace`.
+func NewCluster(domain, namespace string) *ClusterStore {
+	return &ClusterStore{
+		domain:    domain,
+		namespace: namespace,
+	}
+}
+
+func (s *ClusterStore) Read(ctx context.Context, name string) ([]byte, error) {
+	var dir, file = path.Split(name)
+
+	secret, err := kube.Client.CoreV1().Secrets(s.namespace).Get(ctx, s.secretName(dir), metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("file '%s' %w", name, ErrNotFound)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read '%s' from cluster: %w", name, err)
+	}
+
+	payload, ok := secret.Data[base64.RawURLEncoding.EncodeToString([]byte(file))]
+	if !ok {
+		return nil, fmt.Errorf("file '%s' %w", name, ErrNotFound)
+	}
+
+	return payload, nil
+}
+
+func (s *ClusterStore) Write(ctx context.Context, name string, payload []byte) error {
+	return s.WriteBatch(ctx, map[string][]byte{name: payload})
+}
+
+// WriteBatch creates or replaces all given `files`, updating secret of each directory once.
+func (s *ClusterStore) WriteBatch(ctx context.Context, files map[string][]byte) error {
+	var dirs = make(map[string]map[string][]byte)
+
+	for name, payload := range files {
+		var dir, file = path.Split(name)
+		dir = path.Clean(dir)
+
+		if dirs[dir] == nil {
+			dirs[dir] = make(map[string][]byte)
+		}
+
+		dirs[dir][file] = payload
+	}
+
+	var names = make([]string, 0, len(dirs))
+	for dir := range dirs {
+		names = append(names, dir)
+	}
+
+	sort.Strings(names)
+
+	for _, dir := range names {
+		if err := s.writeDir(ctx, dir, dirs[dir]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeDir merges `files` into the secret of the `dir` directory.
+func (s *ClusterStore) writeDir(ctx context.Context, dir string, files map[string][]byte) error {
+	var (
+		secretName = s.secretName(dir)
+		data       = make(map[string][]byte)
+	)
+
+	secret, err := kube.Client.CoreV1().Secrets(s.namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err == nil && secret.Data != nil {
+		data = secret.Data
+	} else if err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to write '%s' directory into cluster: %w", dir, err)
+	}
+
+	for file, payload := range files {
+		data[base64.RawURLEncoding.EncodeToString([]byte(file))] = payload
+	}
+
+	if _, err = kube.SecretAdapter(kube.Client.CoreV1().Secrets(s.namespace)).CreateOrUpdate(ctx, corev1.Secret{
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				"fabnctl/cid":    "crypto.store",
+				"fabnctl/domain": s.domain,
+			},
+			Annotations: map[string]string{
+				"fabnctl/dir": dir,
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to write '%s' directory into cluster: %w", dir, err)
+	}
+
+	return nil
+}
+
+func (s *ClusterStore) List(ctx context.Context, dir string) ([]string, error) {
+	secrets, err := kube.Client.CoreV1().Secrets(s.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("fabnctl/cid=crypto.store,fabnctl/domain=%s", s.domain),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list crypto store secrets: %w", err)
+	}
+
+	var names []string
+
+	for _, secret := range secrets.Items {
+		var secretDir = secret.Annotations["fabnctl/dir"]
+
+		for key := range secret.Data {
+			file, err := base64.RawURLEncoding.DecodeString(key)
+			if err != nil {
+				continue
+			}
+
+			if name := path.Join(secretDir, string(file)); inDir(name, dir) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// secretName forms name of the secret the `dir` directory is stored in.
+func (s *ClusterStore) secretName(dir string) string {
+	var hash = sha256.Sum256([]byte(path.Clean(dir)))
+
+	return fmt.Sprintf("crypto.%s.%x", s.domain, hash[:8])
+}
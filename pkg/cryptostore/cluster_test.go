@@ -0,0 +1,139 @@
+package cryptostore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// useFakeClient substitutes kube.Client with the fake clientset for the duration of the test.
+func useFakeClient(t *testing.T) *fake.Clientset {
+	t.Helper()
+
+	var (
+		client   = fake.NewSimpleClientset()
+		original kubernetes.Interface
+	)
+
+	original, kube.Client = kube.Client, client
+
+	t.Cleanup(func() {
+		kube.Client = original
+	})
+
+	return client
+}
+
+func TestClusterStore(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		client = useFakeClient(t)
+		store  = NewCluster("example.com", "network")
+	)
+
+	if err := store.WriteBatch(ctx, map[string][]byte{
+		"peerOrganizations/org1.org.example.com/ca/priv_sk":                     []byte("ca key"),
+		"peerOrganizations/org1.org.example.com/ca/ca.org1.org.example.com.pem": []byte("ca cert"),
+		"peerOrganizations/org1.org.example.com/tlsca/priv_sk":                  []byte("tlsca key"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Files of the same directory are merged into its secret:
+	if err := store.Write(ctx, "peerOrganizations/org1.org.example.com/ca/priv_sk", []byte("rotated ca key")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Write(ctx, "ordererOrganizations/example.com/msp/cacerts/ca.example.com-cert.pem",
+		[]byte("ca cert"),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := store.Read(ctx, "peerOrganizations/org1.org.example.com/ca/priv_sk")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(payload) != "rotated ca key" {
+		t.Errorf("expected 'rotated ca key', got '%s'", payload)
+	}
+
+	if _, err = store.Read(ctx, "peerOrganizations/org1.org.example.com/ca/missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for missing file, got: %v", err)
+	}
+
+	if _, err = store.Read(ctx, "peerOrganizations/org2.org.example.com/ca/priv_sk"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for missing directory, got: %v", err)
+	}
+
+	names, err := store.List(ctx, "peerOrganizations/org1.org.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{
+		"peerOrganizations/org1.org.example.com/ca/ca.org1.org.example.com.pem",
+		"peerOrganizations/org1.org.example.com/ca/priv_sk",
+		"peerOrganizations/org1.org.example.com/tlsca/priv_sk",
+	}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+
+	if names, err = store.List(ctx, ""); err != nil || len(names) != 4 {
+		t.Errorf("expected 4 files in store, got %v (err: %v)", names, err)
+	}
+
+	// Secrets of another network aren't listed:
+	if names, err = NewCluster("another.com", "network").List(ctx, ""); err != nil || len(names) != 0 {
+		t.Errorf("expected no files of another network, got %v (err: %v)", names, err)
+	}
+
+	var secrets = 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "create" {
+			secrets++
+		}
+	}
+
+	if secrets != 3 {
+		t.Errorf("expected secret per directory to be created, got %d secrets", secrets)
+	}
+}
+
+func TestClusterStoreImport(t *testing.T) {
+	var (
+		client = useFakeClient(t)
+		store  = NewCluster("example.com", "network")
+		writes int
+	)
+
+	client.PrependReactor("*", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetVerb() == "create" || action.GetVerb() == "update" {
+			writes++
+		}
+
+		return false, nil, nil
+	})
+
+	if err := WriteFiles(context.Background(), store, map[string][]byte{
+		"ca/priv_sk":               []byte("ca key"),
+		"ca/ca.example.com.pem":    []byte("ca cert"),
+		"users/Admin/msp/priv_sk":  []byte("admin key"),
+		"users/Admin/msp/cert.pem": []byte("admin cert"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each directory must be written once, rather than once per file:
+	if writes != 2 {
+		t.Errorf("expected 2 secret writes, got %d", writes)
+	}
+}
@@ -0,0 +1,221 @@
+package cryptostore
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"filippo.io/age"
+)
+
+// EncryptedStore is the Store keeping crypto material files in the gzipped tar archive encrypted with age
+// by the passphrase, so that it can be also decrypted with 'age -d' command.
+// Archive is read once and rewritten entirely on each write, so it can be safely shared through VCS.
+// Use WriteBatch for writing multiple files, so that archive is rewritten only once.
+type EncryptedStore struct {
+	file       string
+	passphrase string
+	files      map[string][]byte
+	mu         sync.Mutex
+}
+
+// NewEncrypted constructs new EncryptedStore instance keeping files in the `file` archive encrypted with `passphrase`.
+func NewEncrypted(file, passphrase string) *EncryptedStore {
+	return &EncryptedStore{
+		file:       file,
+		passphrase: passphrase,
+	}
+}
+
+func (s *EncryptedStore) Read(_ context.Context, name string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	payload, ok := s.files[name]
+	if !ok {
+		return nil, fmt.Errorf("file '%s' %w", name, ErrNotFound)
+	}
+
+	return payload, nil
+}
+
+func (s *EncryptedStore) Write(ctx context.Context, name string, payload []byte) error {
+	return s.WriteBatch(ctx, map[string][]byte{name: payload})
+}
+
+// WriteBatch creates or replaces all given `files`, rewriting the archive once.
+func (s *EncryptedStore) WriteBatch(_ context.Context, files map[string][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	for name, payload := range files {
+		s.files[name] = payload
+	}
+
+	return s.save()
+}
+
+func (s *EncryptedStore) List(_ context.Context, dir string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for name := range s.files {
+		if inDir(name, dir) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// load decrypts and unpacks the archive, unless it's already loaded. Missing archive stands for the empty store.
+func (s *EncryptedStore) load() error {
+	if s.files != nil {
+		return nil
+	}
+
+	var files = make(map[string][]byte)
+
+	payload, err := ioutil.ReadFile(s.file)
+	if os.IsNotExist(err) {
+		s.files = files
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read '%s' crypto archive: %w", s.file, err)
+	}
+
+	identity, err := age.NewScryptIdentity(s.passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to initialize decryption: %w", err)
+	}
+
+	archive, err := age.Decrypt(bytes.NewReader(payload), identity)
+	if errors.Is(err, age.ErrIncorrectIdentity) {
+		return fmt.Errorf("failed to decrypt '%s' crypto archive, passphrase is probably wrong", s.file)
+	} else if err != nil {
+		return fmt.Errorf("failed to decrypt '%s' crypto archive: %w", s.file, err)
+	}
+
+	gzipReader, err := gzip.NewReader(archive)
+	if err != nil {
+		return fmt.Errorf("failed to decompress '%s' crypto archive: %w", s.file, err)
+	}
+
+	var tarReader = tar.NewReader(gzipReader)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to unpack '%s' crypto archive: %w", s.file, err)
+		}
+
+		if files[header.Name], err = ioutil.ReadAll(tarReader); err != nil {
+			return fmt.Errorf("failed to unpack '%s' from '%s' crypto archive: %w", header.Name, s.file, err)
+		}
+	}
+
+	s.files = files
+
+	return nil
+}
+
+// save packs and encrypts files, then replaces the archive.
+func (s *EncryptedStore) save() error {
+	var (
+		payload bytes.Buffer
+		names   = make([]string, 0, len(s.files))
+	)
+
+	recipient, err := age.NewScryptRecipient(s.passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to initialize encryption: %w", err)
+	}
+
+	encryptWriter, err := age.Encrypt(&payload, recipient)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt crypto archive: %w", err)
+	}
+
+	var (
+		gzipWriter = gzip.NewWriter(encryptWriter)
+		tarWriter  = tar.NewWriter(gzipWriter)
+	)
+
+	for name := range s.files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:    name,
+			Size:    int64(len(s.files[name])),
+			Mode:    0600,
+			ModTime: time.Now(),
+		}); err != nil {
+			return fmt.Errorf("failed to pack '%s' into crypto archive: %w", name, err)
+		}
+
+		if _, err := tarWriter.Write(s.files[name]); err != nil {
+			return fmt.Errorf("failed to pack '%s' into crypto archive: %w", name, err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to pack crypto archive: %w", err)
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to compress crypto archive: %w", err)
+	}
+
+	if err := encryptWriter.Close(); err != nil {
+		return fmt.Errorf("failed to encrypt crypto archive: %w", err)
+	}
+
+	// Archive is written next to the existing one and then renamed, so that it won't be left half written:
+	var tmpFile = fmt.Sprintf("%s.tmp", s.file)
+
+	if err := os.MkdirAll(filepath.Dir(s.file), 0755); err != nil {
+		return fmt.Errorf("failed to create '%s' directory: %w", filepath.Dir(s.file), err)
+	}
+
+	if err := ioutil.WriteFile(tmpFile, payload.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write '%s' crypto archive: %w", s.file, err)
+	}
+
+	if err := os.Rename(tmpFile, s.file); err != nil {
+		return fmt.Errorf("failed to write '%s' crypto archive: %w", s.file, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package cryptostore
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func TestEncryptedStore(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		file  = filepath.Join(t.TempDir(), ".crypto-config.example.com.enc")
+		store = NewEncrypted(file, "passphrase")
+	)
+
+	if names, err := store.List(ctx, ""); err != nil || len(names) != 0 {
+		t.Fatalf("expected empty store without archive, got %v (err: %v)", names, err)
+	}
+
+	if err := store.WriteBatch(ctx, map[string][]byte{
+		"peerOrganizations/org1.org.example.com/ca/priv_sk":                     []byte("ca key"),
+		"peerOrganizations/org1.org.example.com/ca/ca.org1.org.example.com.pem": []byte("ca cert"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Write(ctx, "ordererOrganizations/example.com/tlsca/priv_sk", []byte("tlsca key")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Files must be persisted in the archive, rather than just in memory:
+	var reopened = NewEncrypted(file, "passphrase")
+
+	payload, err := reopened.Read(ctx, "peerOrganizations/org1.org.example.com/ca/priv_sk")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(payload) != "ca key" {
+		t.Errorf("expected 'ca key', got '%s'", payload)
+	}
+
+	names, err := reopened.List(ctx, "peerOrganizations/org1.org.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != 2 {
+		t.Errorf("expected 2 files of 'org1' organization, got: %v", names)
+	}
+
+	if _, err = reopened.Read(ctx, "peerOrganizations/org2.org.example.com/ca/priv_sk"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for missing file, got: %v", err)
+	}
+
+	if _, err = NewEncrypted(file, "wrong").Read(ctx, "ordererOrganizations/example.com/tlsca/priv_sk"); err == nil {
+		t.Error("expected error for wrong passphrase, got <nil>")
+	}
+}
+
+func TestEncryptedStoreAgeCompatibility(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		file = filepath.Join(t.TempDir(), "crypto.enc")
+	)
+
+	if err := NewEncrypted(file, "passphrase").Write(ctx, "tlsca/priv_sk", []byte("tlsca key")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Archive must be readable with 'age -d' and 'tar -xz' commands:
+	archive, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer archive.Close()
+
+	identity, err := age.NewScryptIdentity("passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decrypted, err := age.Decrypt(archive, identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gzipReader, err := gzip.NewReader(decrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tarReader = tar.NewReader(gzipReader)
+
+	header, err := tarReader.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := ioutil.ReadAll(tarReader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header.Name != "tlsca/priv_sk" || string(payload) != "tlsca key" {
+		t.Errorf("unexpected archive entry '%s': %s", header.Name, payload)
+	}
+}
+
+// recordingStore records writes of the underlying local store.
+type recordingStore struct {
+	*LocalStore
+	writes  []string
+	batches int
+}
+
+func (s *recordingStore) Write(ctx context.Context, name string, payload []byte) error {
+	s.writes = append(s.writes, name)
+	return s.LocalStore.Write(ctx, name, payload)
+}
+
+// recordingBatchStore additionally supports batch writes.
+type recordingBatchStore struct {
+	*recordingStore
+}
+
+func (s recordingBatchStore) WriteBatch(ctx context.Context, files map[string][]byte) error {
+	s.batches++
+
+	for name, payload := range files {
+		if err := s.LocalStore.Write(ctx, name, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestImport(t *testing.T) {
+	var (
+		ctx = context.Background()
+		dir = t.TempDir()
+	)
+
+	for name, payload := range map[string]string{
+		"ca/priv_sk":               "ca key",
+		"ca/ca.example.com.pem":    "ca cert",
+		"tlsca/priv_sk":            "tlsca key",
+		"tlsca/tlsca.example.com":  "tlsca cert",
+		"users/Admin/msp/priv_sk":  "admin key",
+		"users/Admin/msp/cert.pem": "admin cert",
+	} {
+		var filePath = filepath.Join(dir, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(filePath, []byte(payload), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("batch writer", func(t *testing.T) {
+		var store = recordingBatchStore{&recordingStore{LocalStore: NewLocal(t.TempDir())}}
+
+		if err := Import(ctx, store, dir); err != nil {
+			t.Fatal(err)
+		}
+
+		if store.batches != 1 || len(store.writes) != 0 {
+			t.Errorf("expected single batch write, got %d batches and %d writes", store.batches, len(store.writes))
+		}
+
+		assertFiles(t, store.LocalStore, 6)
+	})
+
+	t.Run("plain writer", func(t *testing.T) {
+		var store = &recordingStore{LocalStore: NewLocal(t.TempDir())}
+
+		if err := Import(ctx, store, dir); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(store.writes) != 6 {
+			t.Errorf("expected 6 writes, got %d", len(store.writes))
+		}
+
+		assertFiles(t, store.LocalStore, 6)
+	})
+}
+
+func assertFiles(t *testing.T, store Store, count int) {
+	t.Helper()
+
+	names, err := store.List(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != count {
+		t.Errorf("expected %d files in store, got: %v", count, names)
+	}
+}
@@ -0,0 +1,79 @@
+package cryptostore
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore is the Store keeping crypto material files in the local directory.
+type LocalStore struct {
+	dir string
+}
+
+// NewLocal constructs new LocalStore instance keeping files in the `dir` directory.
+func NewLocal(dir string) *LocalStore {
+	return &LocalStore{
+		dir: dir,
+	}
+}
+
+// Dir returns path of the directory files are stored in.
+func (s *LocalStore) Dir() string {
+	return s.dir
+}
+
+func (s *LocalStore) Read(_ context.Context, name string) ([]byte, error) {
+	var filePath = filepath.Join(s.dir, filepath.FromSlash(name))
+
+	payload, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("file '%s' %w", filePath, ErrNotFound)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %w", filePath, err)
+	}
+
+	return payload, nil
+}
+
+func (s *LocalStore) Write(_ context.Context, name string, payload []byte) error {
+	var filePath = filepath.Join(s.dir, filepath.FromSlash(name))
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create '%s' directory: %w", filepath.Dir(filePath), err)
+	}
+
+	if err := ioutil.WriteFile(filePath, payload, 0600); err != nil {
+		return fmt.Errorf("failed to write '%s': %w", filePath, err)
+	}
+
+	return nil
+}
+
+func (s *LocalStore) List(_ context.Context, dir string) ([]string, error) {
+	var (
+		names []string
+		root  = filepath.Join(s.dir, filepath.FromSlash(dir))
+	)
+
+	if err := filepath.Walk(root, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		name, err := filepath.Rel(s.dir, filePath)
+		if err != nil {
+			return err
+		}
+
+		names = append(names, filepath.ToSlash(name))
+
+		return nil
+	}); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to list '%s' directory: %w", root, err)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,165 @@
+// Package cryptostore provides storages for crypto material of the network generated by cryptogen or Fabric CA,
+// so that private keys aren't necessarily kept in the working directory.
+package cryptostore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/viper"
+)
+
+// Supported kinds of crypto stores.
+const (
+	// Local store keeps crypto material in the '.crypto-config.<domain>' directory, as it's downloaded from cluster.
+	Local = "local"
+	// Encrypted store keeps crypto material in the '.crypto-config.<domain>.enc' archive encrypted with passphrase.
+	Encrypted = "encrypted"
+	// Cluster store keeps crypto material in Kubernetes secrets, so it's shared by all operators of the cluster.
+	Cluster = "cluster"
+)
+
+// ErrNotFound is returned by Store.Read when requested file doesn't exist in the store.
+var ErrNotFound = errors.New("not found in crypto store")
+
+// Store defines methods for reading and writing crypto material files.
+// Files are referred by slash-separated names relative to the crypto config root,
+// e.g. 'peerOrganizations/org1.org.example.com/tlsca/priv_sk'.
+type Store interface {
+	// Read returns content of the file with given `name`.
+	Read(ctx context.Context, name string) ([]byte, error)
+	// Write creates or replaces file with given `name`.
+	Write(ctx context.Context, name string, payload []byte) error
+	// List returns names of all files under the `dir` directory recursively. Empty `dir` stands for the root.
+	List(ctx context.Context, dir string) ([]string, error)
+}
+
+// BatchWriter is implemented by stores, which write multiple files at once more efficiently than one by one.
+type BatchWriter interface {
+	// WriteBatch creates or replaces all given `files` keyed by their names.
+	WriteBatch(ctx context.Context, files map[string][]byte) error
+}
+
+// New constructs crypto store of given `kind` for the network on the `domain`, empty one stands for 'crypto.store' config.
+// Cluster store uses secrets in the `namespace`, while encrypted one takes passphrase from 'crypto.passphrase' config.
+func New(kind, domain, namespace string) (Store, error) {
+	if len(kind) == 0 {
+		kind = viper.GetString("crypto.store")
+	}
+
+	switch kind {
+	case Local, "":
+		return NewLocal(LocalDir(domain)), nil
+	case Encrypted:
+		var passphrase = viper.GetString("crypto.passphrase")
+
+		if len(passphrase) == 0 {
+			return nil, fmt.Errorf("passphrase is required for encrypted crypto store, set it with CRYPTO_PASSPHRASE env")
+		}
+
+		return NewEncrypted(fmt.Sprintf("%s.enc", LocalDir(domain)), passphrase), nil
+	case Cluster:
+		return NewCluster(domain, namespace), nil
+	default:
+		return nil, fmt.Errorf("unsupported crypto store '%s', expected one of: %s, %s, %s",
+			kind, Local, Encrypted, Cluster,
+		)
+	}
+}
+
+// LocalDir forms path of the local directory crypto material of the network on the `domain` is downloaded into.
+func LocalDir(domain string) string {
+	return fmt.Sprintf(".crypto-config.%s", domain)
+}
+
+// Import writes all files found in the local `dir` directory into the `store`.
+func Import(ctx context.Context, store Store, dir string) error {
+	var files = make(map[string][]byte)
+
+	if err := filepath.Walk(dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+
+		if files[filepath.ToSlash(name)], err = ioutil.ReadFile(filePath); err != nil {
+			return fmt.Errorf("failed to read '%s': %w", filePath, err)
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return WriteFiles(ctx, store, files)
+}
+
+// WriteFiles writes all given `files` into the `store`, at once when it's supported by the store.
+func WriteFiles(ctx context.Context, store Store, files map[string][]byte) error {
+	if batchWriter, ok := store.(BatchWriter); ok {
+		return batchWriter.WriteBatch(ctx, files)
+	}
+
+	var names = make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := store.Write(ctx, name, files[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Export writes files found under the `prefix` directory of the `store` into the local `dir` directory.
+func Export(ctx context.Context, store Store, prefix, dir string) error {
+	names, err := store.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		payload, err := store.Read(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		var filePath = filepath.Join(dir, filepath.FromSlash(name))
+
+		if err = os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+			return fmt.Errorf("failed to create '%s' directory: %w", filepath.Dir(filePath), err)
+		}
+
+		if err = ioutil.WriteFile(filePath, payload, 0600); err != nil {
+			return fmt.Errorf("failed to write '%s': %w", filePath, err)
+		}
+	}
+
+	return nil
+}
+
+// inDir checks whether the file with given `name` is located under the `dir` directory.
+func inDir(name, dir string) bool {
+	if len(dir) == 0 {
+		return true
+	}
+
+	dir = path.Clean(dir)
+
+	return name == dir || len(name) > len(dir) && name[:len(dir)] == dir && name[len(dir)] == '/'
+}
@@ -0,0 +1,56 @@
+package fabric
+
+import (
+	"context"
+
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// Artifacts defines methods for managing network crypto materials and channel artifacts.
+type Artifacts struct {
+	*sharedArgs
+}
+
+// NewArtifacts constructs new Artifacts instance.
+func NewArtifacts(options ...SharedOption) (*Artifacts, error) {
+	var args = &sharedArgs{
+		arch:          "amd64",
+		kubeNamespace: "network",
+		logger:        term.NewLogger(),
+		chartsPath:    "./network-config.yaml",
+	}
+
+	for i := range options {
+		options[i](args)
+	}
+
+	if len(args.initErrors) > 0 {
+		return nil, args.Error()
+	}
+
+	return &Artifacts{
+		sharedArgs: args,
+	}, nil
+}
+
+// Uninstall removes artifacts helm release along with 'artifacts.wait' job.
+// Persistent volume with generated artifacts is removed as well, unless WithKeepData option is passed.
+func (a *Artifacts) Uninstall(ctx context.Context, options ...UninstallOption) error {
+	args, err := newUninstallArgs(options...)
+	if err != nil {
+		return err
+	}
+
+	if err = kube.DeleteJobs(ctx, "fabnctl/cid=artifacts.wait", a.kubeNamespace); err != nil {
+		return err
+	}
+
+	if err = a.uninstallRelease(ctx, "artifacts", args.keepData); err != nil {
+		return err
+	}
+
+	a.logger.Successf("Network artifacts successfully removed!")
+
+	return nil
+}
@@ -0,0 +1,290 @@
+package fabric
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/timoth-y/fabnctl/pkg/certs"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/term"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// transportSecretKinds lists 'fabnctl/cid' labels of the secrets created by Peer.Install and Orderer.Install.
+var transportSecretKinds = []string{
+	"peer.tls.secret",
+	"peer.ca.secret",
+	"orderer.tls.secret",
+	"orderer.ca.secret",
+}
+
+// CertificateStatus describes certificate found in the crypto material of the network.
+type CertificateStatus struct {
+	// Source is either the name of the certificate file in the crypto store or the secret name along with its key.
+	Source   string    `json:"source"`
+	Subject  string    `json:"subject"`
+	SANs     []string  `json:"sans,omitempty"`
+	Issuer   string    `json:"issuer"`
+	NotAfter time.Time `json:"notAfter"`
+	DaysLeft int       `json:"daysLeft"`
+	Expiring bool      `json:"expiring"`
+}
+
+// Certificates defines methods for inspecting expiration of the network certificates.
+type Certificates struct {
+	*certsArgs
+}
+
+// NewCertificates constructs new Certificates instance.
+func NewCertificates(options ...CertsOption) (*Certificates, error) {
+	var args = &certsArgs{
+		expiryThreshold: 30,
+		sharedArgs: &sharedArgs{
+			arch:          "amd64",
+			kubeNamespace: "network",
+			logger:        term.NewLogger(),
+			chartsPath:    "./network-config.yaml",
+		},
+	}
+
+	for i := range options {
+		options[i](args)
+	}
+
+	if len(args.initErrors) > 0 {
+		return nil, args.Error()
+	}
+
+	return &Certificates{
+		certsArgs: args,
+	}, nil
+}
+
+// Check scans certificates in the crypto store and transport secrets of the peers and orderers.
+// Returned statuses are sorted by the expiration date, so that the soonest to expire come first.
+func (c *Certificates) Check(ctx context.Context) ([]CertificateStatus, error) {
+	var statuses []CertificateStatus
+
+	store, err := c.crypto()
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := store.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		c.logger.Warningf("No crypto material found in the crypto store, only secrets are checked")
+	}
+
+	for _, name := range names {
+		if !(strings.HasSuffix(name, ".pem") || strings.HasSuffix(name, ".crt")) {
+			continue
+		}
+
+		payload, err := store.Read(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		// Not every PEM file is a certificate, such ones are skipped:
+		if cert, err := certs.ParseCertificate(payload); err == nil {
+			statuses = append(statuses, c.status(name, cert))
+		}
+	}
+
+	secrets, err := kube.Client.CoreV1().Secrets(c.kubeNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("fabnctl/cid in (%s),fabnctl/domain=%s",
+			strings.Join(transportSecretKinds, ","), c.domain,
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transport secrets: %w", err)
+	}
+
+	for _, secret := range secrets.Items {
+		for key, payload := range secret.Data {
+			if !strings.HasSuffix(key, ".crt") {
+				continue
+			}
+
+			cert, err := certs.ParseCertificate(payload)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse '%s' of '%s' secret: %w", key, secret.Name, err)
+			}
+
+			statuses = append(statuses, c.status(fmt.Sprintf("secret/%s[%s]", secret.Name, key), cert))
+		}
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].NotAfter.Before(statuses[j].NotAfter)
+	})
+
+	return statuses, nil
+}
+
+// status describes `cert` found in the `source`.
+func (c *Certificates) status(source string, cert *x509.Certificate) CertificateStatus {
+	var (
+		daysLeft = int(math.Floor(time.Until(cert.NotAfter).Hours() / 24))
+		sans     = append([]string{}, cert.DNSNames...)
+	)
+
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	return CertificateStatus{
+		Source:   source,
+		Subject:  cert.Subject.String(),
+		SANs:     sans,
+		Issuer:   cert.Issuer.String(),
+		NotAfter: cert.NotAfter,
+		DaysLeft: daysLeft,
+		Expiring: daysLeft < c.expiryThreshold,
+	}
+}
+
+// tlsRotation describes TLS key pair of the network node, which is rotated.
+type tlsRotation struct {
+	// tlsDir is the crypto store directory with 'server.crt' and 'server.key' files generated by cryptogen.
+	tlsDir string
+	// caDir is the crypto store directory of the TLS CA issuing node certificates.
+	caDir string
+	// selector of the node pod, which `mountDir` directory is backed by the artifacts volume.
+	selector string
+	mountDir string
+	// deployment of the node, which is restarted once key pair is replaced.
+	deployment string
+	// keepKey defines whether the existing private key is reused.
+	keepKey bool
+}
+
+// rotateTLS re-issues TLS key pair described by `r` and replaces it both in the crypto store and in the artifacts volume,
+// then recreates transport secrets with `createSecrets` and restarts node deployment, so that it picks up new key pair.
+func (a *sharedArgs) rotateTLS(ctx context.Context, r tlsRotation, createSecrets func(context.Context) error) error {
+	var (
+		certPath = path.Join(r.tlsDir, "server.crt")
+		keyPath  = path.Join(r.tlsDir, "server.key")
+		key      crypto.Signer
+	)
+
+	certPEM, err := a.readCrypto(ctx, certPath)
+	if err != nil {
+		return fmt.Errorf("failed to read certificate from path: %s: %w", certPath, err)
+	}
+
+	cert, err := certs.ParseCertificate(certPEM)
+	if err != nil {
+		return fmt.Errorf("failed to parse '%s' certificate: %w", certPath, err)
+	}
+
+	if r.keepKey {
+		keyPEM, err := a.readCrypto(ctx, keyPath)
+		if err != nil {
+			return fmt.Errorf("failed to read private key from path: %s: %w", keyPath, err)
+		}
+
+		if key, err = certs.ParsePrivateKey(keyPEM); err != nil {
+			return fmt.Errorf("failed to parse '%s' private key: %w", keyPath, err)
+		}
+	}
+
+	ca, err := a.loadCA(ctx, r.caDir)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS CA: %w", err)
+	}
+
+	keyPair, err := ca.Reissue(cert, key)
+	if err != nil {
+		return err
+	}
+
+	issued, err := certs.ParseCertificate(keyPair.CertPEM)
+	if err != nil {
+		return err
+	}
+
+	pod, err := kube.FindPod(ctx, a.kubeNamespace, r.selector)
+	if err != nil {
+		return err
+	}
+
+	if pod == nil {
+		return fmt.Errorf("pod matching '%s' selector isn't found", r.selector)
+	}
+
+	var files = map[string][]byte{
+		"server.crt": keyPair.CertPEM,
+		"server.key": keyPair.KeyPEM,
+	}
+
+	if err = a.replaceMountedFiles(ctx, pod.Name, r.mountDir, files); err != nil {
+		return err
+	}
+
+	var storeFiles = make(map[string][]byte, len(files))
+	for name, payload := range files {
+		storeFiles[path.Join(r.tlsDir, name)] = payload
+	}
+
+	if err = a.writeCrypto(ctx, storeFiles); err != nil {
+		return err
+	}
+
+	a.logger.Okf("TLS certificate of '%s' re-issued with serial %x, valid until %s",
+		issued.Subject.CommonName, issued.SerialNumber, issued.NotAfter.Format("2006-01-02"),
+	)
+
+	if err = createSecrets(ctx); err != nil {
+		return err
+	}
+
+	return a.restartNode(ctx, r.deployment, "new TLS key pair")
+}
+
+// replaceMountedFiles replaces `files` in the `dir` directory of the `podName` pod, which is backed by the artifacts volume.
+// Files are referred by paths relative to `dir`, they are staged in the working directory of the pod container
+// and then moved to the mounted one.
+func (a *sharedArgs) replaceMountedFiles(ctx context.Context, podName, dir string, files map[string][]byte) error {
+	for name, payload := range files {
+		var staged = path.Base(name)
+
+		if err := kube.CopyToPod(ctx, podName, a.kubeNamespace, bytes.NewBuffer(payload), staged); err != nil {
+			return fmt.Errorf("failed to send '%s' to '%s' pod: %w", staged, podName, err)
+		}
+
+		if _, _, err := kube.ExecCommandInPod(ctx, podName, a.kubeNamespace,
+			"mv", staged, path.Join(dir, name),
+		); err != nil {
+			return fmt.Errorf("failed to replace '%s' in '%s' pod: %w", name, podName, err)
+		}
+	}
+
+	return nil
+}
+
+// restartNode restarts node `deployment` and waits for its readiness, so that it picks up replaced crypto material,
+// which is described by `material` in the log.
+func (a *sharedArgs) restartNode(ctx context.Context, deployment, material string) error {
+	return a.logger.Stream(func() error {
+		if err := kube.RestartDeployment(ctx, deployment, a.kubeNamespace); err != nil {
+			return err
+		}
+
+		return kube.AwaitDeploymentReady(ctx, deployment, a.kubeNamespace)
+	}, fmt.Sprintf("Restarting '%s' deployment", deployment),
+		fmt.Sprintf("Deployment '%s' restarted with %s", deployment, material),
+	)
+}
@@ -0,0 +1,46 @@
+package fabric
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+type (
+	// CertsOption allows passing additional arguments for inspecting network certificates.
+	CertsOption func(*certsArgs)
+
+	certsArgs struct {
+		expiryThreshold int
+		*sharedArgs
+	}
+)
+
+// WithExpiryThreshold sets number of days before expiration, starting from which certificate is reported as expiring.
+func WithExpiryThreshold(days int) CertsOption {
+	return func(args *certsArgs) {
+		args.expiryThreshold = days
+	}
+}
+
+// WithExpiryThresholdFlag ...
+func WithExpiryThresholdFlag(flags *pflag.FlagSet, name string) CertsOption {
+	return func(args *certsArgs) {
+		var err error
+
+		if args.expiryThreshold, err = flags.GetInt(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (expiry threshold): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithSharedOptionsForCerts ...
+func WithSharedOptionsForCerts(options ...SharedOption) CertsOption {
+	return func(args *certsArgs) {
+		for i := range options {
+			options[i](args.sharedArgs)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package fabric
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCertificateStatus(t *testing.T) {
+	var tests = []struct {
+		name      string
+		notAfter  time.Duration
+		threshold int
+		daysLeft  int
+		expiring  bool
+	}{
+		{
+			name:      "valid",
+			notAfter:  90*24*time.Hour + time.Hour,
+			threshold: 30,
+			daysLeft:  90,
+		},
+		{
+			name:      "expiring",
+			notAfter:  10*24*time.Hour + time.Hour,
+			threshold: 30,
+			daysLeft:  10,
+			expiring:  true,
+		},
+		{
+			name:      "at threshold",
+			notAfter:  30*24*time.Hour + time.Hour,
+			threshold: 30,
+			daysLeft:  30,
+		},
+		{
+			name:      "custom threshold",
+			notAfter:  10*24*time.Hour + time.Hour,
+			threshold: 7,
+			daysLeft:  10,
+		},
+		{
+			name:      "expired",
+			notAfter:  -36 * time.Hour,
+			threshold: 30,
+			daysLeft:  -2,
+			expiring:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certificates, err := NewCertificates(WithExpiryThreshold(tt.threshold))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var cert = &x509.Certificate{
+				Subject:     pkix.Name{CommonName: "peer0.org1.org.example.com"},
+				Issuer:      pkix.Name{CommonName: "tlsca.org1.org.example.com"},
+				NotAfter:    time.Now().Add(tt.notAfter),
+				DNSNames:    []string{"peer0.org1.org.example.com", "localhost"},
+				IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+			}
+
+			var status = certificates.status("peer0.org1.org.tls/tls.crt", cert)
+
+			if status.DaysLeft != tt.daysLeft {
+				t.Errorf("expected %d days left, got %d", tt.daysLeft, status.DaysLeft)
+			}
+
+			if status.Expiring != tt.expiring {
+				t.Errorf("expected expiring = %t, got %t", tt.expiring, status.Expiring)
+			}
+
+			var sans = []string{"peer0.org1.org.example.com", "localhost", "127.0.0.1"}
+			if !reflect.DeepEqual(status.SANs, sans) {
+				t.Errorf("expected %v SANs, got %v", sans, status.SANs)
+			}
+
+			if status.Subject != "CN=peer0.org1.org.example.com" || status.Issuer != "CN=tlsca.org1.org.example.com" {
+				t.Errorf("unexpected subject '%s' or issuer '%s'", status.Subject, status.Issuer)
+			}
+		})
+	}
+}
@@ -0,0 +1,587 @@
+package fabric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// defaultEndorsementPolicy is the channel config policy applied by Fabric
+// when chaincode definition doesn't specify its own endorsement policy.
+const defaultEndorsementPolicy = "/Channel/Application/Endorsement"
+
+type (
+	// chaincodeDefinition defines chaincode definition parameters, which must stay the same within the sequence.
+	// Policies are kept in canonical form, so that definitions could be compared regardless of their notation.
+	chaincodeDefinition struct {
+		Policy       string
+		Collections  []collectionDefinition
+		InitRequired bool
+	}
+
+	// collectionDefinition defines private data collection parameters in canonical form.
+	collectionDefinition struct {
+		Name              string
+		MemberOrgsPolicy  string
+		RequiredPeerCount int32
+		MaxPeerCount      int32
+		BlockToLive       uint64
+		MemberOnlyRead    bool
+		MemberOnlyWrite   bool
+		EndorsementPolicy string
+	}
+
+	// policyRule defines signature policy rule, which is either principal or N out of nested rules.
+	policyRule struct {
+		principal string
+		n         int
+		rules     []policyRule
+	}
+)
+
+// desiredDefinition forms chaincodeDefinition from the install `args`.
+func desiredDefinition(args *installArgs) (*chaincodeDefinition, error) {
+	var (
+		definition = &chaincodeDefinition{InitRequired: args.initRequired}
+		err        error
+	)
+
+	if definition.Policy, err = canonicalPolicy(args.policy, defaultEndorsementPolicy); err != nil {
+		return nil, fmt.Errorf("invalid endorsement policy: %w", err)
+	}
+
+	if len(args.collectionsConfig) == 0 {
+		return definition, nil
+	}
+
+	var collections []struct {
+		Name              string `json:"name"`
+		Policy            string `json:"policy"`
+		RequiredPeerCount int32  `json:"requiredPeerCount"`
+		MaxPeerCount      int32  `json:"maxPeerCount"`
+		BlockToLive       uint64 `json:"blockToLive"`
+		MemberOnlyRead    bool   `json:"memberOnlyRead"`
+		MemberOnlyWrite   bool   `json:"memberOnlyWrite"`
+		EndorsementPolicy *struct {
+			SignaturePolicy     string `json:"signaturePolicy"`
+			ChannelConfigPolicy string `json:"channelConfigPolicy"`
+		} `json:"endorsementPolicy"`
+	}
+
+	payload, err := ioutil.ReadFile(args.collectionsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read collections config: %w", err)
+	}
+
+	if err = json.Unmarshal(payload, &collections); err != nil {
+		return nil, fmt.Errorf("failed to decode collections config '%s': %w", args.collectionsConfig, err)
+	}
+
+	for _, collection := range collections {
+		var cd = collectionDefinition{
+			Name:              collection.Name,
+			RequiredPeerCount: collection.RequiredPeerCount,
+			MaxPeerCount:      collection.MaxPeerCount,
+			BlockToLive:       collection.BlockToLive,
+			MemberOnlyRead:    collection.MemberOnlyRead,
+			MemberOnlyWrite:   collection.MemberOnlyWrite,
+		}
+
+		if len(cd.Name) == 0 {
+			return nil, fmt.Errorf("collections config '%s' contains collection without name", args.collectionsConfig)
+		}
+
+		if cd.MemberOrgsPolicy, err = canonicalPolicy(collection.Policy, ""); err != nil {
+			return nil, fmt.Errorf("invalid policy of '%s' collection: %w", cd.Name, err)
+		}
+
+		if ep := collection.EndorsementPolicy; ep != nil {
+			var policy = ep.SignaturePolicy
+			if len(ep.ChannelConfigPolicy) != 0 {
+				policy = ep.ChannelConfigPolicy
+			}
+
+			if cd.EndorsementPolicy, err = canonicalPolicy(policy, ""); err != nil {
+				return nil, fmt.Errorf("invalid endorsement policy of '%s' collection: %w", cd.Name, err)
+			}
+		}
+
+		definition.Collections = append(definition.Collections, cd)
+	}
+
+	sortCollections(definition.Collections)
+
+	return definition, nil
+}
+
+// committedDefinition retrieves definition of the chaincode committed on the channel using `cliPodName` pod.
+func (c *Chaincode) committedDefinition(ctx context.Context, cliPodName string) (*chaincodeDefinition, error) {
+	var result struct {
+		ValidationParameter []byte `json:"validation_parameter"`
+		InitRequired        bool   `json:"init_required"`
+		Collections         *struct {
+			Config []struct {
+				Payload struct {
+					StaticCollectionConfig *struct {
+						Name             string `json:"name"`
+						MemberOrgsPolicy *struct {
+							Payload struct {
+								SignaturePolicy *signaturePolicyEnvelopeJSON
+							}
+						} `json:"member_orgs_policy"`
+						RequiredPeerCount int32                  `json:"required_peer_count"`
+						MaximumPeerCount  int32                  `json:"maximum_peer_count"`
+						BlockToLive       uint64                 `json:"block_to_live"`
+						MemberOnlyRead    bool                   `json:"member_only_read"`
+						MemberOnlyWrite   bool                   `json:"member_only_write"`
+						EndorsementPolicy *applicationPolicyJSON `json:"endorsement_policy"`
+					}
+				}
+			} `json:"config"`
+		} `json:"collections"`
+	}
+
+	stdout, _, err := kube.ExecCommandInPod(ctx, cliPodName, c.kubeNamespace,
+		"peer", "lifecycle", "chaincode", "querycommitted",
+		"-C", c.channel,
+		"-n", c.chaincodeName,
+		"-O", "json",
+	)
+	if err != nil {
+		if errors.Is(err, term.ErrRemoteCmdFailed) {
+			return nil, fmt.Errorf("failed to query '%s' chaincode definition: %w", c.chaincodeName, err)
+		}
+
+		return nil, fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
+	}
+
+	if err = json.NewDecoder(stdout).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode '%s' chaincode definition: %w", c.chaincodeName, err)
+	}
+
+	var (
+		definition = &chaincodeDefinition{InitRequired: result.InitRequired}
+		policy     = &pb.ApplicationPolicy{}
+	)
+
+	if err = proto.Unmarshal(result.ValidationParameter, policy); err != nil {
+		return nil, fmt.Errorf("failed to decode '%s' chaincode endorsement policy: %w", c.chaincodeName, err)
+	}
+
+	if definition.Policy, err = applicationPolicyString(policy); err != nil {
+		return nil, err
+	}
+
+	if result.Collections == nil {
+		return definition, nil
+	}
+
+	for _, config := range result.Collections.Config {
+		var collection = config.Payload.StaticCollectionConfig
+		if collection == nil {
+			continue
+		}
+
+		var cd = collectionDefinition{
+			Name:              collection.Name,
+			RequiredPeerCount: collection.RequiredPeerCount,
+			MaxPeerCount:      collection.MaximumPeerCount,
+			BlockToLive:       collection.BlockToLive,
+			MemberOnlyRead:    collection.MemberOnlyRead,
+			MemberOnlyWrite:   collection.MemberOnlyWrite,
+		}
+
+		if mp := collection.MemberOrgsPolicy; mp != nil && mp.Payload.SignaturePolicy != nil {
+			rule, err := policyRuleFromEnvelope(mp.Payload.SignaturePolicy.envelope())
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode policy of '%s' collection: %w", cd.Name, err)
+			}
+
+			cd.MemberOrgsPolicy = rule.String()
+		}
+
+		if collection.EndorsementPolicy != nil {
+			if cd.EndorsementPolicy, err = applicationPolicyString(collection.EndorsementPolicy.policy()); err != nil {
+				return nil, fmt.Errorf("failed to decode endorsement policy of '%s' collection: %w", cd.Name, err)
+			}
+		}
+
+		definition.Collections = append(definition.Collections, cd)
+	}
+
+	sortCollections(definition.Collections)
+
+	return definition, nil
+}
+
+// changes lists parameters of the `committed` definition, which differ from ones of the current definition.
+func (d *chaincodeDefinition) changes(committed *chaincodeDefinition) []string {
+	var changes []string
+
+	if d.Policy != committed.Policy {
+		changes = append(changes, fmt.Sprintf("endorsement policy: %s -> %s", committed.Policy, d.Policy))
+	}
+
+	if !reflect.DeepEqual(d.Collections, committed.Collections) &&
+		(len(d.Collections) != 0 || len(committed.Collections) != 0) {
+		changes = append(changes, "private data collections")
+	}
+
+	if d.InitRequired != committed.InitRequired {
+		changes = append(changes, fmt.Sprintf("init required: %t -> %t", committed.InitRequired, d.InitRequired))
+	}
+
+	return changes
+}
+
+// canonicalPolicy converts `policy` into its canonical form. Policies starting with '/' are treated as
+// channel config policy references, others are parsed as signature policies. Empty policy resolves to `fallback`.
+func canonicalPolicy(policy, fallback string) (string, error) {
+	policy = strings.TrimSpace(policy)
+
+	switch {
+	case len(policy) == 0:
+		return fallback, nil
+	case strings.HasPrefix(policy, "/"):
+		return policy, nil
+	}
+
+	rule, err := parseSignaturePolicy(policy)
+	if err != nil {
+		return "", err
+	}
+
+	return rule.String(), nil
+}
+
+// applicationPolicyString converts application `policy` into its canonical form.
+func applicationPolicyString(policy *pb.ApplicationPolicy) (string, error) {
+	switch p := policy.Type.(type) {
+	case *pb.ApplicationPolicy_SignaturePolicy:
+		rule, err := policyRuleFromEnvelope(p.SignaturePolicy)
+		if err != nil {
+			return "", err
+		}
+
+		return rule.String(), nil
+	case *pb.ApplicationPolicy_ChannelConfigPolicyReference:
+		return p.ChannelConfigPolicyReference, nil
+	}
+
+	return "", nil
+}
+
+// String formats policy rule in canonical signature policy notation.
+func (r policyRule) String() string {
+	if len(r.principal) != 0 {
+		return fmt.Sprintf("'%s'", r.principal)
+	}
+
+	var rules = make([]string, len(r.rules))
+	for i := range r.rules {
+		rules[i] = r.rules[i].String()
+	}
+
+	switch r.n {
+	case len(r.rules):
+		return fmt.Sprintf("AND(%s)", strings.Join(rules, ","))
+	case 1:
+		return fmt.Sprintf("OR(%s)", strings.Join(rules, ","))
+	}
+
+	return fmt.Sprintf("OutOf(%d,%s)", r.n, strings.Join(rules, ","))
+}
+
+var principalRegexp = regexp.MustCompile(`^([[:alnum:].-]+)\.(admin|member|client|peer|orderer)$`)
+
+// parseSignaturePolicy parses signature `policy` written in Fabric policy notation,
+// e.g. "AND('Org1MSP.member', OR('Org2MSP.peer', 'Org3MSP.peer'))" or "OutOf(2, 'Org1MSP.member', ...)".
+func parseSignaturePolicy(policy string) (policyRule, error) {
+	var (
+		input = []rune(policy)
+		pos   int
+		parse func() (policyRule, error)
+	)
+
+	skipSpaces := func() {
+		for pos < len(input) && (input[pos] == ' ' || input[pos] == '\t' || input[pos] == '\n') {
+			pos++
+		}
+	}
+
+	expect := func(r rune) error {
+		skipSpaces()
+		if pos >= len(input) || input[pos] != r {
+			return fmt.Errorf("expected '%c' at position %d of '%s'", r, pos, policy)
+		}
+
+		pos++
+		return nil
+	}
+
+	parse = func() (policyRule, error) {
+		skipSpaces()
+
+		if pos >= len(input) {
+			return policyRule{}, fmt.Errorf("unexpected end of '%s'", policy)
+		}
+
+		// Principal in quotes:
+		if quote := input[pos]; quote == '\'' || quote == '"' {
+			var end = pos + 1
+			for end < len(input) && input[end] != quote {
+				end++
+			}
+
+			if end == len(input) {
+				return policyRule{}, fmt.Errorf("unterminated principal at position %d of '%s'", pos, policy)
+			}
+
+			var principal = string(input[pos+1 : end])
+			pos = end + 1
+
+			if !principalRegexp.MatchString(principal) {
+				return policyRule{}, fmt.Errorf(
+					"invalid principal '%s', expected format is 'MSP.role', where role is one of: "+
+						"member, admin, client, peer, orderer", principal,
+				)
+			}
+
+			return policyRule{principal: principal}, nil
+		}
+
+		// Gate with nested rules:
+		var (
+			start = pos
+			rule  policyRule
+		)
+
+		for pos < len(input) && ((input[pos] >= 'a' && input[pos] <= 'z') || (input[pos] >= 'A' && input[pos] <= 'Z')) {
+			pos++
+		}
+
+		var gate = string(input[start:pos])
+
+		if err := expect('('); err != nil {
+			return policyRule{}, err
+		}
+
+		if strings.EqualFold(gate, "OutOf") {
+			skipSpaces()
+
+			var start = pos
+			for pos < len(input) && input[pos] >= '0' && input[pos] <= '9' {
+				pos++
+			}
+
+			n, err := strconv.Atoi(string(input[start:pos]))
+			if err != nil {
+				return policyRule{}, fmt.Errorf("expected number at position %d of '%s'", start, policy)
+			}
+
+			if err = expect(','); err != nil {
+				return policyRule{}, err
+			}
+
+			rule.n = n
+		}
+
+		for {
+			nested, err := parse()
+			if err != nil {
+				return policyRule{}, err
+			}
+
+			rule.rules = append(rule.rules, nested)
+
+			if skipSpaces(); pos < len(input) && input[pos] == ',' {
+				pos++
+				continue
+			}
+
+			if err = expect(')'); err != nil {
+				return policyRule{}, err
+			}
+
+			break
+		}
+
+		switch strings.ToLower(gate) {
+		case "and":
+			rule.n = len(rule.rules)
+		case "or":
+			rule.n = 1
+		case "outof":
+			if rule.n < 1 || rule.n > len(rule.rules) {
+				return policyRule{}, fmt.Errorf("OutOf requires from 1 to %d signatures, got %d", len(rule.rules), rule.n)
+			}
+		default:
+			return policyRule{}, fmt.Errorf("unknown gate '%s' in '%s', expected AND, OR or OutOf", gate, policy)
+		}
+
+		return rule, nil
+	}
+
+	rule, err := parse()
+	if err != nil {
+		return policyRule{}, err
+	}
+
+	if skipSpaces(); pos != len(input) {
+		return policyRule{}, fmt.Errorf("unexpected '%s' at the end of '%s'", string(input[pos:]), policy)
+	}
+
+	return rule, nil
+}
+
+// policyRuleFromEnvelope converts signature policy `envelope` into policyRule.
+func policyRuleFromEnvelope(envelope *cb.SignaturePolicyEnvelope) (policyRule, error) {
+	var principals = make([]string, len(envelope.Identities))
+
+	for i, identity := range envelope.Identities {
+		if identity.PrincipalClassification != msp.MSPPrincipal_ROLE {
+			return policyRule{}, fmt.Errorf("unsupported principal classification: %s", identity.PrincipalClassification)
+		}
+
+		var role = &msp.MSPRole{}
+		if err := proto.Unmarshal(identity.Principal, role); err != nil {
+			return policyRule{}, fmt.Errorf("failed to decode MSP role principal: %w", err)
+		}
+
+		principals[i] = fmt.Sprintf("%s.%s", role.MspIdentifier, strings.ToLower(role.Role.String()))
+	}
+
+	var convert func(rule *cb.SignaturePolicy) (policyRule, error)
+
+	convert = func(rule *cb.SignaturePolicy) (policyRule, error) {
+		switch r := rule.GetType().(type) {
+		case *cb.SignaturePolicy_SignedBy:
+			if int(r.SignedBy) >= len(principals) || r.SignedBy < 0 {
+				return policyRule{}, fmt.Errorf("policy refers to unknown identity %d", r.SignedBy)
+			}
+
+			return policyRule{principal: principals[r.SignedBy]}, nil
+		case *cb.SignaturePolicy_NOutOf_:
+			var result = policyRule{n: int(r.NOutOf.N)}
+
+			for _, nested := range r.NOutOf.Rules {
+				nestedRule, err := convert(nested)
+				if err != nil {
+					return policyRule{}, err
+				}
+
+				result.rules = append(result.rules, nestedRule)
+			}
+
+			return result, nil
+		}
+
+		return policyRule{}, fmt.Errorf("policy rule has unknown type")
+	}
+
+	if envelope.Rule == nil {
+		return policyRule{}, fmt.Errorf("policy has no rule")
+	}
+
+	return convert(envelope.Rule)
+}
+
+// signaturePolicyEnvelopeJSON mirrors encoding of cb.SignaturePolicyEnvelope in 'peer lifecycle' JSON output.
+type signaturePolicyEnvelopeJSON struct {
+	Rule       *signaturePolicyJSON `json:"rule"`
+	Identities []struct {
+		PrincipalClassification int32  `json:"principal_classification"`
+		Principal               []byte `json:"principal"`
+	} `json:"identities"`
+}
+
+// signaturePolicyJSON mirrors encoding of cb.SignaturePolicy in 'peer lifecycle' JSON output.
+type signaturePolicyJSON struct {
+	Type struct {
+		SignedBy *int32
+		NOutOf   *struct {
+			N     int32                 `json:"n"`
+			Rules []signaturePolicyJSON `json:"rules"`
+		}
+	}
+}
+
+// applicationPolicyJSON mirrors encoding of pb.ApplicationPolicy in 'peer lifecycle' JSON output.
+type applicationPolicyJSON struct {
+	Type struct {
+		SignaturePolicy              *signaturePolicyEnvelopeJSON
+		ChannelConfigPolicyReference string
+	}
+}
+
+func (e *signaturePolicyEnvelopeJSON) envelope() *cb.SignaturePolicyEnvelope {
+	var envelope = &cb.SignaturePolicyEnvelope{}
+
+	if e.Rule != nil {
+		envelope.Rule = e.Rule.rule()
+	}
+
+	for _, identity := range e.Identities {
+		envelope.Identities = append(envelope.Identities, &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_Classification(identity.PrincipalClassification),
+			Principal:               identity.Principal,
+		})
+	}
+
+	return envelope
+}
+
+func (p *signaturePolicyJSON) rule() *cb.SignaturePolicy {
+	if p.Type.SignedBy != nil {
+		return &cb.SignaturePolicy{Type: &cb.SignaturePolicy_SignedBy{SignedBy: *p.Type.SignedBy}}
+	}
+
+	var nOutOf = &cb.SignaturePolicy_NOutOf{}
+
+	if p.Type.NOutOf != nil {
+		nOutOf.N = p.Type.NOutOf.N
+
+		for i := range p.Type.NOutOf.Rules {
+			nOutOf.Rules = append(nOutOf.Rules, p.Type.NOutOf.Rules[i].rule())
+		}
+	}
+
+	return &cb.SignaturePolicy{Type: &cb.SignaturePolicy_NOutOf_{NOutOf: nOutOf}}
+}
+
+func (p *applicationPolicyJSON) policy() *pb.ApplicationPolicy {
+	if p.Type.SignaturePolicy != nil {
+		return &pb.ApplicationPolicy{Type: &pb.ApplicationPolicy_SignaturePolicy{
+			SignaturePolicy: p.Type.SignaturePolicy.envelope(),
+		}}
+	}
+
+	return &pb.ApplicationPolicy{Type: &pb.ApplicationPolicy_ChannelConfigPolicyReference{
+		ChannelConfigPolicyReference: p.Type.ChannelConfigPolicyReference,
+	}}
+}
+
+func sortCollections(collections []collectionDefinition) {
+	sort.Slice(collections, func(i, j int) bool {
+		return collections[i].Name < collections[j].Name
+	})
+}
+
+// shellQuote wraps `value` in single quotes, so that it could be passed to remote shell as is.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
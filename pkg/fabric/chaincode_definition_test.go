@@ -0,0 +1,257 @@
+package fabric
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+)
+
+func TestCanonicalPolicy(t *testing.T) {
+	var tests = []struct {
+		name     string
+		policy   string
+		fallback string
+		expected string
+	}{
+		{
+			name:     "empty policy",
+			policy:   "  ",
+			fallback: defaultEndorsementPolicy,
+			expected: defaultEndorsementPolicy,
+		},
+		{
+			name:     "channel config policy reference",
+			policy:   "/Channel/Application/Admins",
+			expected: "/Channel/Application/Admins",
+		},
+		{
+			name:     "single principal",
+			policy:   "'org1.member'",
+			expected: "'org1.member'",
+		},
+		{
+			name:     "double quoted principal",
+			policy:   `"org1.peer"`,
+			expected: "'org1.peer'",
+		},
+		{
+			name:     "AND gate",
+			policy:   "AND('org1.member','org2.member')",
+			expected: "AND('org1.member','org2.member')",
+		},
+		{
+			name:     "OR gate with spaces",
+			policy:   " OR( 'org1.member' ,\t'org2.admin' )\n",
+			expected: "OR('org1.member','org2.admin')",
+		},
+		{
+			name:     "case insensitive gates",
+			policy:   "and('org1.member', or('org2.peer', 'org3.client'))",
+			expected: "AND('org1.member',OR('org2.peer','org3.client'))",
+		},
+		{
+			name:     "OutOf gate",
+			policy:   "OutOf(2, 'org1.member', 'org2.member', 'org3.member')",
+			expected: "OutOf(2,'org1.member','org2.member','org3.member')",
+		},
+		{
+			name:     "OutOf of all rules is AND",
+			policy:   "OutOf(2, 'org1.member', 'org2.member')",
+			expected: "AND('org1.member','org2.member')",
+		},
+		{
+			name:     "OutOf of one rule is OR",
+			policy:   "OutOf(1, 'org1.member', 'org2.member')",
+			expected: "OR('org1.member','org2.member')",
+		},
+		{
+			name:     "nested gates",
+			policy:   `AND("org1.admin", OutOf(2, 'org2.peer', OR('org3.peer', 'org-4.example.com.orderer'), 'org5.peer'))`,
+			expected: "AND('org1.admin',OutOf(2,'org2.peer',OR('org3.peer','org-4.example.com.orderer'),'org5.peer'))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := canonicalPolicy(tt.policy, tt.fallback)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if policy != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, policy)
+			}
+		})
+	}
+}
+
+func TestCanonicalPolicyErrors(t *testing.T) {
+	var tests = []struct {
+		name   string
+		policy string
+	}{
+		{name: "unknown gate", policy: "XOR('org1.member','org2.member')"},
+		{name: "missing gate", policy: "('org1.member')"},
+		{name: "unquoted principal", policy: "AND(org1.member)"},
+		{name: "unknown role", policy: "AND('org1.owner')"},
+		{name: "principal without role", policy: "'org1'"},
+		{name: "unterminated principal", policy: "AND('org1.member)"},
+		{name: "mismatched quotes", policy: `AND('org1.member")`},
+		{name: "unclosed gate", policy: "OR('org1.member','org2.member'"},
+		{name: "empty gate", policy: "AND()"},
+		{name: "trailing comma", policy: "AND('org1.member',)"},
+		{name: "missing OutOf number", policy: "OutOf('org1.member','org2.member')"},
+		{name: "zero OutOf number", policy: "OutOf(0,'org1.member')"},
+		{name: "OutOf number exceeds rules", policy: "OutOf(3,'org1.member','org2.member')"},
+		{name: "trailing input", policy: "AND('org1.member') OR('org2.member')"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if policy, err := canonicalPolicy(tt.policy, ""); err == nil {
+				t.Errorf("expected error, got '%s'", policy)
+			}
+		})
+	}
+}
+
+func TestPolicyRuleFromEnvelope(t *testing.T) {
+	var envelope = &cb.SignaturePolicyEnvelope{
+		Rule: nOutOf(2,
+			signedBy(0),
+			nOutOf(1, signedBy(1), signedBy(2)),
+		),
+		Identities: []*msp.MSPPrincipal{
+			rolePrincipal(t, "org1", msp.MSPRole_ADMIN),
+			rolePrincipal(t, "org2", msp.MSPRole_PEER),
+			rolePrincipal(t, "org3", msp.MSPRole_MEMBER),
+		},
+	}
+
+	rule, err := policyRuleFromEnvelope(envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Committed policy must be comparable with the one passed in command flags:
+	expected, err := canonicalPolicy("AND('org1.admin', OR('org2.peer', 'org3.member'))", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rule.String() != expected {
+		t.Errorf("expected '%s', got '%s'", expected, rule.String())
+	}
+
+	envelope.Rule = signedBy(3)
+	if _, err = policyRuleFromEnvelope(envelope); err == nil {
+		t.Error("expected error for rule referring unknown identity, got <nil>")
+	}
+}
+
+func TestChaincodeDefinitionChanges(t *testing.T) {
+	var collection = collectionDefinition{
+		Name:              "private",
+		MemberOrgsPolicy:  "OR('org1.member','org2.member')",
+		RequiredPeerCount: 1,
+		MaxPeerCount:      2,
+	}
+
+	var tests = []struct {
+		name      string
+		desired   chaincodeDefinition
+		committed chaincodeDefinition
+		expected  []string
+	}{
+		{
+			name:      "same definition",
+			desired:   chaincodeDefinition{Policy: defaultEndorsementPolicy},
+			committed: chaincodeDefinition{Policy: defaultEndorsementPolicy},
+		},
+		{
+			name:      "nil and empty collections",
+			desired:   chaincodeDefinition{Policy: defaultEndorsementPolicy, Collections: []collectionDefinition{}},
+			committed: chaincodeDefinition{Policy: defaultEndorsementPolicy},
+		},
+		{
+			name:      "endorsement policy",
+			desired:   chaincodeDefinition{Policy: "AND('org1.member','org2.member')"},
+			committed: chaincodeDefinition{Policy: defaultEndorsementPolicy},
+			expected: []string{
+				"endorsement policy: " + defaultEndorsementPolicy + " -> AND('org1.member','org2.member')",
+			},
+		},
+		{
+			name:      "added collection",
+			desired:   chaincodeDefinition{Collections: []collectionDefinition{collection}},
+			committed: chaincodeDefinition{},
+			expected:  []string{"private data collections"},
+		},
+		{
+			name: "changed collection",
+			desired: chaincodeDefinition{Collections: []collectionDefinition{
+				{Name: "private", MemberOrgsPolicy: collection.MemberOrgsPolicy, RequiredPeerCount: 2, MaxPeerCount: 2},
+			}},
+			committed: chaincodeDefinition{Collections: []collectionDefinition{collection}},
+			expected:  []string{"private data collections"},
+		},
+		{
+			name:      "init required",
+			desired:   chaincodeDefinition{InitRequired: true},
+			committed: chaincodeDefinition{},
+			expected:  []string{"init required: false -> true"},
+		},
+		{
+			name:      "all parameters",
+			desired:   chaincodeDefinition{Policy: "'org1.peer'", InitRequired: true},
+			committed: chaincodeDefinition{Collections: []collectionDefinition{collection}},
+			expected: []string{
+				"endorsement policy:  -> 'org1.peer'",
+				"private data collections",
+				"init required: false -> true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var changes = tt.desired.changes(&tt.committed)
+
+			if len(changes) != len(tt.expected) {
+				t.Fatalf("expected changes %q, got %q", tt.expected, changes)
+			}
+
+			for i := range changes {
+				if changes[i] != tt.expected[i] {
+					t.Errorf("expected change '%s', got '%s'", tt.expected[i], changes[i])
+				}
+			}
+		})
+	}
+}
+
+func signedBy(index int32) *cb.SignaturePolicy {
+	return &cb.SignaturePolicy{Type: &cb.SignaturePolicy_SignedBy{SignedBy: index}}
+}
+
+func nOutOf(n int32, rules ...*cb.SignaturePolicy) *cb.SignaturePolicy {
+	return &cb.SignaturePolicy{Type: &cb.SignaturePolicy_NOutOf_{
+		NOutOf: &cb.SignaturePolicy_NOutOf{N: n, Rules: rules},
+	}}
+}
+
+func rolePrincipal(t *testing.T, mspID string, role msp.MSPRole_MSPRoleType) *msp.MSPPrincipal {
+	t.Helper()
+
+	principal, err := proto.Marshal(&msp.MSPRole{MspIdentifier: mspID, Role: role})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               principal,
+	}
+}
@@ -0,0 +1,98 @@
+package fabric
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	historyKey = "history.json"
+
+	// historyLimit is the maximum number of deployments kept in the chaincode history.
+	historyLimit = 10
+)
+
+// deploymentRecord describes chaincode definition committed on the channel along with packages installed on peers for it,
+// so that it could be recommitted later on by the rollback.
+type deploymentRecord struct {
+	Version      string            `json:"version"`
+	Sequence     int               `json:"sequence"`
+	Type         string            `json:"type"`
+	Definition   string            `json:"definition"`
+	Collections  string            `json:"collections,omitempty"`
+	InitRequired bool              `json:"initRequired,omitempty"`
+	Packages     map[string]string `json:"packages"`
+}
+
+// loadHistory retrieves recorded chaincode deployments ordered from the oldest one.
+func (c *Chaincode) loadHistory(ctx context.Context) ([]deploymentRecord, error) {
+	var history []deploymentRecord
+
+	configMap, err := kube.Client.CoreV1().ConfigMaps(c.kubeNamespace).Get(ctx, c.configMapName("history"), metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to retrieve deployment history of '%s' chaincode: %w", c.chaincodeName, err)
+	}
+
+	if err = json.Unmarshal([]byte(configMap.Data[historyKey]), &history); err != nil {
+		return nil, fmt.Errorf("failed to decode deployment history of '%s' chaincode: %w", c.chaincodeName, err)
+	}
+
+	return history, nil
+}
+
+// recordDeployment appends committed deployment `record` to the chaincode history.
+// Packages are merged into the latest record if it has the same sequence, e.g. when peers were installed in batches.
+func (c *Chaincode) recordDeployment(ctx context.Context, record deploymentRecord) error {
+	history, err := c.loadHistory(ctx)
+	if err != nil {
+		return err
+	}
+
+	if last := len(history) - 1; last >= 0 && history[last].Sequence == record.Sequence {
+		if history[last].Packages == nil {
+			history[last].Packages = make(map[string]string)
+		}
+
+		for key, packageID := range record.Packages {
+			history[last].Packages[key] = packageID
+		}
+	} else {
+		history = append(history, record)
+	}
+
+	if len(history) > historyLimit {
+		history = history[len(history)-historyLimit:]
+	}
+
+	payload, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to encode deployment history of '%s' chaincode: %w", c.chaincodeName, err)
+	}
+
+	if _, err = kube.ConfigMapAdapter(kube.Client.CoreV1().ConfigMaps(c.kubeNamespace)).CreateOrUpdate(ctx, corev1.ConfigMap{
+		Data: map[string]string{
+			historyKey: string(payload),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.configMapName("history"),
+			Namespace: c.kubeNamespace,
+			Labels: map[string]string{
+				"fabnctl/cid":       "chaincode.history",
+				"fabnctl/chaincode": c.chaincodeName,
+				"fabnctl/channel":   c.channel,
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to persist deployment history of '%s' chaincode: %w", c.chaincodeName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,509 @@
+package fabric
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// couchdbMetaDir is the chaincode META-INF directory containing CouchDB indexes,
+// either for the state database in 'indexes' or for private data collections in 'collections/<name>/indexes'.
+const couchdbMetaDir = "META-INF/statedb/couchdb"
+
+type (
+	// chaincodeIndex is the CouchDB index definition file found in chaincode source.
+	chaincodeIndex struct {
+		path       string
+		collection string
+		payload    []byte
+		definition *CouchIndex
+		err        error
+	}
+
+	// PeerIndexes describes CouchDB indexes of the chaincode databases on the organization peer.
+	PeerIndexes struct {
+		Org       string            `json:"org"`
+		Peer      string            `json:"peer"`
+		Databases []DatabaseIndexes `json:"databases,omitempty"`
+		Error     string            `json:"error,omitempty"`
+	}
+
+	// DatabaseIndexes describes CouchDB indexes existing in the chaincode database,
+	// along with those defined in chaincode source, but missing in it.
+	DatabaseIndexes struct {
+		Database   string       `json:"database"`
+		Collection string       `json:"collection,omitempty"`
+		Indexes    []CouchIndex `json:"indexes,omitempty"`
+		Missing    []string     `json:"missing,omitempty"`
+	}
+
+	// CouchIndex describes CouchDB index.
+	CouchIndex struct {
+		DDoc   string   `json:"ddoc,omitempty"`
+		Name   string   `json:"name"`
+		Fields []string `json:"fields"`
+	}
+)
+
+// ValidateIndexes lints CouchDB index definitions found in chaincode `sourcePath` against CouchDB index schema,
+// in the same way as peer does on chaincode installation, reporting result for each of them.
+func (c *Chaincode) ValidateIndexes(sourcePath string) error {
+	sourcePathAbs, err := filepath.Abs(sourcePath)
+	if err != nil {
+		return fmt.Errorf("absolute path '%s' of source does not exists: %w", sourcePath, err)
+	}
+
+	indexes, err := readIndexes(sourcePathAbs)
+	if err != nil {
+		return err
+	}
+
+	if len(indexes) == 0 {
+		c.logger.Infof("No CouchDB indexes found in '%s'", path.Join(sourcePath, couchdbMetaDir))
+		return nil
+	}
+
+	var invalid int
+
+	for _, index := range indexes {
+		if index.err != nil {
+			c.logger.Errorf(index.err, "Index '%s' is invalid", index.path)
+			invalid++
+
+			continue
+		}
+
+		c.logger.Okf("Index '%s' is valid: %s on %s",
+			index.path, index.definition.Name, strings.Join(index.definition.Fields, ", "),
+		)
+	}
+
+	if invalid != 0 {
+		return fmt.Errorf("%d of %d CouchDB indexes of '%s' chaincode are invalid", invalid, len(indexes), c.chaincodeName)
+	}
+
+	c.logger.Successf("All %d CouchDB indexes of '%s' chaincode are valid", len(indexes), c.chaincodeName)
+
+	return nil
+}
+
+// IndexesStatus queries CouchDB of each given organization peer for indexes of the chaincode databases on the channel.
+// When `sourcePath` is given, indexes defined in chaincode source are reported as missing if they aren't found.
+func (c *Chaincode) IndexesStatus(ctx context.Context, sourcePath string) ([]PeerIndexes, error) {
+	var expected []*chaincodeIndex
+
+	if len(sourcePath) != 0 {
+		sourcePathAbs, err := filepath.Abs(sourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("absolute path '%s' of source does not exists: %w", sourcePath, err)
+		}
+
+		if expected, err = readIndexes(sourcePathAbs); err != nil {
+			return nil, err
+		}
+	}
+
+	var statuses = make([]PeerIndexes, len(c.targets))
+
+	// Failures are reported per peer, thus peers are never canceled due to the failure of another one:
+	_ = c.forEachParallel(ctx, len(c.targets), func(ctx context.Context, i int) error {
+		var target = c.targets[i]
+
+		statuses[i] = PeerIndexes{Org: target.org, Peer: target.peer}
+
+		databases, err := c.peerIndexes(ctx, target, expected)
+		if err != nil {
+			statuses[i].Error = err.Error()
+			return nil
+		}
+
+		statuses[i].Databases = databases
+
+		return nil
+	})
+
+	return statuses, nil
+}
+
+// peerIndexes retrieves indexes of the chaincode databases from the `target` peer CouchDB.
+func (c *Chaincode) peerIndexes(ctx context.Context, target peerTarget, expected []*chaincodeIndex) ([]DatabaseIndexes, error) {
+	couchdbPod, err := kube.AwaitPodReady(ctx,
+		fmt.Sprintf("fabnctl/app=couchdb.%s.%s.org", target.peer, target.org), c.kubeNamespace,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find CouchDB pod: %w", err)
+	}
+
+	var allDBs []string
+
+	if err = c.queryCouchDB(ctx, couchdbPod, "_all_dbs", &allDBs); err != nil {
+		return nil, err
+	}
+
+	// Private data collections databases are named after state one, e.g. 'mychannel_assets$$pprivate':
+	var (
+		stateDB     = couchDatabaseName(c.channel, c.chaincodeName)
+		collections = make(map[string]string)
+		existingDBs = make(map[string]bool)
+		databases   []DatabaseIndexes
+	)
+
+	for _, db := range allDBs {
+		existingDBs[db] = true
+
+		if db == stateDB {
+			collections[""] = db
+		} else if strings.HasPrefix(db, stateDB+"$$p") {
+			collections[unescapeCouchName(strings.TrimPrefix(db, stateDB+"$$p"))] = db
+		}
+	}
+
+	for _, index := range expected {
+		if _, ok := collections[index.collection]; !ok {
+			var db = stateDB
+
+			if len(index.collection) != 0 {
+				db = fmt.Sprintf("%s$$p%s", stateDB, escapeCouchName(index.collection))
+			}
+
+			collections[index.collection] = db
+		}
+	}
+
+	for collection, db := range collections {
+		var (
+			result struct {
+				Indexes []struct {
+					DDoc *string `json:"ddoc"`
+					Name string  `json:"name"`
+					Type string  `json:"type"`
+					Def  struct {
+						Fields []map[string]string `json:"fields"`
+					} `json:"def"`
+				} `json:"indexes"`
+			}
+			dbIndexes = DatabaseIndexes{Database: db, Collection: collection}
+			existing  = make(map[string]bool)
+		)
+
+		// Database of the collection which has indexes defined in chaincode source may not exist yet:
+		if existingDBs[db] {
+			if err = c.queryCouchDB(ctx, couchdbPod, path.Join(url.PathEscape(db), "_index"), &result); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, index := range result.Indexes {
+			// Special '_all_docs' index exists in every database:
+			if index.Type == "special" {
+				continue
+			}
+
+			var couchIndex = CouchIndex{Name: index.Name}
+
+			if index.DDoc != nil {
+				couchIndex.DDoc = *index.DDoc
+			}
+
+			for _, field := range index.Def.Fields {
+				for name := range field {
+					couchIndex.Fields = append(couchIndex.Fields, name)
+				}
+			}
+
+			existing[couchIndex.Name] = true
+			dbIndexes.Indexes = append(dbIndexes.Indexes, couchIndex)
+		}
+
+		// Indexes without name can't be matched, since CouchDB generates it on creation:
+		for _, index := range expected {
+			if index.collection != collection || index.definition == nil || len(index.definition.Name) == 0 {
+				continue
+			}
+
+			if !existing[index.definition.Name] {
+				dbIndexes.Missing = append(dbIndexes.Missing, index.definition.Name)
+			}
+		}
+
+		databases = append(databases, dbIndexes)
+	}
+
+	sort.Slice(databases, func(i, j int) bool {
+		return databases[i].Database < databases[j].Database
+	})
+
+	return databases, nil
+}
+
+// queryCouchDB performs GET request of the `resource` on CouchDB running in `couchdbPod` and decodes its JSON response.
+func (c *Chaincode) queryCouchDB(ctx context.Context, couchdbPod, resource string, result interface{}) error {
+	stdout, _, err := kube.ExecShellInPod(ctx, couchdbPod, c.kubeNamespace, kube.FormCommand(
+		"curl", "-sSf", "-u", `"$COUCHDB_USER:$COUCHDB_PASSWORD"`,
+		shellQuote(fmt.Sprintf("http://127.0.0.1:5984/%s", resource)),
+	))
+	if err != nil {
+		if errors.Is(err, term.ErrRemoteCmdFailed) {
+			return fmt.Errorf("failed to query '%s' from CouchDB: %w", resource, err)
+		}
+
+		return fmt.Errorf("failed to execute command on '%s' pod: %w", couchdbPod, err)
+	}
+
+	if err = json.NewDecoder(stdout).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode CouchDB '%s' response: %w", resource, err)
+	}
+
+	return nil
+}
+
+// readIndexes reads CouchDB index files from META-INF directory of the chaincode `sourcePath`
+// and validates their definitions. Unreadable files are failing the read, while invalid definitions
+// are reported by the `err` field of each index, so that all of them could be listed.
+func readIndexes(sourcePath string) ([]*chaincodeIndex, error) {
+	var (
+		metaDir = filepath.Join(sourcePath, filepath.FromSlash(couchdbMetaDir))
+		dirs    = map[string]string{filepath.Join(metaDir, "indexes"): ""}
+		indexes []*chaincodeIndex
+	)
+
+	collections, err := ioutil.ReadDir(filepath.Join(metaDir, "collections"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read collections indexes directory: %w", err)
+	}
+
+	for _, collection := range collections {
+		if collection.IsDir() {
+			dirs[filepath.Join(metaDir, "collections", collection.Name(), "indexes")] = collection.Name()
+		}
+	}
+
+	for dir, collection := range dirs {
+		files, err := ioutil.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read '%s' indexes directory: %w", dir, err)
+		}
+
+		for _, file := range files {
+			if file.IsDir() {
+				continue
+			}
+
+			relPath, err := filepath.Rel(sourcePath, filepath.Join(dir, file.Name()))
+			if err != nil {
+				return nil, err
+			}
+
+			var index = &chaincodeIndex{
+				path:       filepath.ToSlash(relPath),
+				collection: collection,
+			}
+
+			if index.payload, err = ioutil.ReadFile(filepath.Join(dir, file.Name())); err != nil {
+				return nil, fmt.Errorf("failed to read '%s' index file: %w", index.path, err)
+			}
+
+			if filepath.Ext(file.Name()) != ".json" {
+				index.err = fmt.Errorf("only JSON index files with '.json' extension are supported")
+			} else {
+				index.definition, index.err = validateIndex(index.payload)
+			}
+
+			indexes = append(indexes, index)
+		}
+	}
+
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].path < indexes[j].path
+	})
+
+	return indexes, nil
+}
+
+// readValidIndexes reads CouchDB index files of the chaincode `sourcePath`, failing if any of them is invalid,
+// so that chaincode won't be packaged with index the peer would reject.
+func readValidIndexes(sourcePath string) ([]*chaincodeIndex, error) {
+	indexes, err := readIndexes(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range indexes {
+		if index.err != nil {
+			return nil, fmt.Errorf("invalid CouchDB index '%s' (see 'cc indexes validate'): %w", index.path, index.err)
+		}
+	}
+
+	return indexes, nil
+}
+
+// validateIndex checks that `payload` is a valid CouchDB JSON index definition, as required by the peer.
+func validateIndex(payload []byte) (*CouchIndex, error) {
+	var definition map[string]json.RawMessage
+
+	if err := json.Unmarshal(payload, &definition); err != nil {
+		return nil, fmt.Errorf("index definition isn't a valid JSON object: %w", err)
+	}
+
+	var index = &CouchIndex{}
+
+	for key, value := range definition {
+		switch key {
+		case "index":
+			fields, err := validateIndexFields(value)
+			if err != nil {
+				return nil, err
+			}
+
+			index.Fields = fields
+		case "ddoc", "name":
+			var str string
+
+			if err := json.Unmarshal(value, &str); err != nil || len(str) == 0 {
+				return nil, fmt.Errorf("'%s' must be a non-empty string", key)
+			}
+
+			if key == "ddoc" {
+				index.DDoc = str
+			} else {
+				index.Name = str
+			}
+		case "type":
+			var indexType string
+
+			if err := json.Unmarshal(value, &indexType); err != nil || indexType != "json" {
+				return nil, fmt.Errorf("'type' must be 'json', the only index type supported by peer")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported '%s' key", key)
+		}
+	}
+
+	if index.Fields == nil {
+		return nil, fmt.Errorf("'index' definition is required")
+	}
+
+	return index, nil
+}
+
+// validateIndexFields checks 'index' object of CouchDB index definition and returns names of the indexed fields.
+func validateIndexFields(payload []byte) ([]string, error) {
+	var (
+		index  map[string]json.RawMessage
+		fields []json.RawMessage
+		names  []string
+	)
+
+	if err := json.Unmarshal(payload, &index); err != nil {
+		return nil, fmt.Errorf("'index' must be an object")
+	}
+
+	for key, value := range index {
+		switch key {
+		case "fields":
+			if err := json.Unmarshal(value, &fields); err != nil {
+				return nil, fmt.Errorf("'index.fields' must be an array")
+			}
+		case "partial_filter_selector":
+			var selector map[string]interface{}
+
+			if err := json.Unmarshal(value, &selector); err != nil {
+				return nil, fmt.Errorf("'index.partial_filter_selector' must be an object")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported 'index.%s' key", key)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("'index.fields' must list at least one field")
+	}
+
+	// Each field is either its name, or an object mapping its name to sort direction:
+	for i, field := range fields {
+		var (
+			name   string
+			sorted map[string]string
+		)
+
+		if err := json.Unmarshal(field, &name); err == nil && len(name) != 0 {
+			names = append(names, name)
+			continue
+		}
+
+		if err := json.Unmarshal(field, &sorted); err != nil || len(sorted) != 1 {
+			return nil, fmt.Errorf("'index.fields[%d]' must be either field name or {\"<field>\": \"asc|desc\"} object", i)
+		}
+
+		for name, direction := range sorted {
+			if direction != "asc" && direction != "desc" {
+				return nil, fmt.Errorf("'index.fields[%d]' has invalid '%s' sort direction, expected 'asc' or 'desc'",
+					i, direction,
+				)
+			}
+
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// couchDatabaseName forms name of the chaincode state database in the same way as peer does,
+// e.g. 'mychannel_assets'.
+func couchDatabaseName(channel, chaincode string) string {
+	return fmt.Sprintf("%s_%s", channel, escapeCouchName(chaincode))
+}
+
+// escapeCouchName escapes upper case letters, which aren't allowed in CouchDB database names, e.g. 'myCC' to 'my$c$c'.
+func escapeCouchName(name string) string {
+	var builder strings.Builder
+
+	for _, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			builder.WriteRune('$')
+			r += 'a' - 'A'
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+// unescapeCouchName reverts escapeCouchName.
+func unescapeCouchName(name string) string {
+	var (
+		builder strings.Builder
+		escaped bool
+	)
+
+	for _, r := range name {
+		if r == '$' && !escaped {
+			escaped = true
+			continue
+		}
+
+		if escaped && r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+
+		escaped = false
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,193 @@
+package fabric
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidateIndex(t *testing.T) {
+	var tests = []struct {
+		name     string
+		payload  string
+		expected *CouchIndex
+	}{
+		{
+			name:    "field names",
+			payload: `{"index":{"fields":["owner","color"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`,
+			expected: &CouchIndex{
+				DDoc:   "indexOwnerDoc",
+				Name:   "indexOwner",
+				Fields: []string{"owner", "color"},
+			},
+		},
+		{
+			name: "sorted fields with partial filter",
+			payload: `{"index":{"fields":[{"size":"desc"},"owner"],"partial_filter_selector":{"docType":"asset"}},
+				"name":"indexSize"}`,
+			expected: &CouchIndex{
+				Name:   "indexSize",
+				Fields: []string{"size", "owner"},
+			},
+		},
+		{
+			name:    "not a JSON object",
+			payload: `["owner"]`,
+		},
+		{
+			name:    "unknown key",
+			payload: `{"index":{"fields":["owner"]},"name":"indexOwner","partitioned":true}`,
+		},
+		{
+			name:    "unknown index key",
+			payload: `{"index":{"fields":["owner"],"sort":["owner"]},"name":"indexOwner"}`,
+		},
+		{
+			name:    "non-json type",
+			payload: `{"index":{"fields":["owner"]},"name":"indexOwner","type":"text"}`,
+		},
+		{
+			name:    "empty name",
+			payload: `{"index":{"fields":["owner"]},"name":""}`,
+		},
+		{
+			name:    "missing index",
+			payload: `{"name":"indexOwner"}`,
+		},
+		{
+			name:    "empty fields",
+			payload: `{"index":{"fields":[]},"name":"indexOwner"}`,
+		},
+		{
+			name:    "fields isn't an array",
+			payload: `{"index":{"fields":"owner"},"name":"indexOwner"}`,
+		},
+		{
+			name:    "bad sort direction",
+			payload: `{"index":{"fields":[{"owner":"ascending"}]},"name":"indexOwner"}`,
+		},
+		{
+			name:    "multiple fields in sort object",
+			payload: `{"index":{"fields":[{"owner":"asc","color":"asc"}]},"name":"indexOwner"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, err := validateIndex([]byte(tt.payload))
+
+			if tt.expected == nil {
+				if err == nil {
+					t.Errorf("expected error, got %+v", index)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(index, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, index)
+			}
+		})
+	}
+}
+
+func TestEscapeCouchName(t *testing.T) {
+	var tests = []struct {
+		name    string
+		escaped string
+	}{
+		{name: "assets", escaped: "assets"},
+		{name: "myCC", escaped: "my$c$c"},
+		{name: "Assets_V2", escaped: "$assets_$v2"},
+	}
+
+	for _, tt := range tests {
+		if escaped := escapeCouchName(tt.name); escaped != tt.escaped {
+			t.Errorf("expected '%s' to be escaped as '%s', got '%s'", tt.name, tt.escaped, escaped)
+		}
+
+		if name := unescapeCouchName(tt.escaped); name != tt.name {
+			t.Errorf("expected '%s' to be unescaped as '%s', got '%s'", tt.escaped, tt.name, name)
+		}
+	}
+
+	if database := couchDatabaseName("supply-channel", "myCC"); database != "supply-channel_my$c$c" {
+		t.Errorf("unexpected database name: %s", database)
+	}
+}
+
+func TestReadIndexes(t *testing.T) {
+	var (
+		sourcePath = t.TempDir()
+		valid      = `{"index":{"fields":["owner"]},"name":"indexOwner","type":"json"}`
+	)
+
+	for name, payload := range map[string]string{
+		"indexes/indexOwner.json":                     valid,
+		"indexes/indexOwner.txt":                      valid,
+		"indexes/indexBroken.json":                    `{"index":{"fields":[]},"name":"indexBroken"}`,
+		"indexes/nested/indexNested.json":             valid,
+		"collections/private/indexes/indexOwner.json": valid,
+		"collections/private/indexOwner.json":         valid,
+		"collections/README.md":                       "collection indexes",
+	} {
+		var filePath = filepath.Join(sourcePath, filepath.FromSlash(couchdbMetaDir), filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(filePath, []byte(payload), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	indexes, err := readIndexes(sourcePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected = []struct {
+		path       string
+		collection string
+		valid      bool
+	}{
+		{path: couchdbMetaDir + "/collections/private/indexes/indexOwner.json", collection: "private", valid: true},
+		{path: couchdbMetaDir + "/indexes/indexBroken.json"},
+		{path: couchdbMetaDir + "/indexes/indexOwner.json", valid: true},
+		{path: couchdbMetaDir + "/indexes/indexOwner.txt"},
+	}
+
+	if len(indexes) != len(expected) {
+		for _, index := range indexes {
+			t.Log(index.path)
+		}
+
+		t.Fatalf("expected %d indexes, got %d", len(expected), len(indexes))
+	}
+
+	for i, index := range indexes {
+		if index.path != expected[i].path || index.collection != expected[i].collection {
+			t.Errorf("expected index %d at '%s' of '%s' collection, got '%s' of '%s' collection",
+				i, expected[i].path, expected[i].collection, index.path, index.collection,
+			)
+		}
+
+		if (index.err == nil) != expected[i].valid {
+			t.Errorf("expected index '%s' to be valid = %t, got error: %v", index.path, expected[i].valid, index.err)
+		}
+	}
+
+	if _, err = readValidIndexes(sourcePath); err == nil {
+		t.Error("expected error for invalid indexes, got <nil>")
+	}
+
+	if indexes, err = readIndexes(t.TempDir()); err != nil || len(indexes) != 0 {
+		t.Errorf("expected no indexes in source without META-INF, got %d (err: %v)", len(indexes), err)
+	}
+}
@@ -38,11 +38,11 @@ type Chaincode struct {
 // NewChaincode constructs new Chaincode instance.
 func NewChaincode(name string, options ...ChaincodeOption) (*Chaincode, error) {
 	args := &chaincodeArgs{
-		channel:  "system",
-		orgpeers: make(map[string][]string),
+		channel: "system",
 		sharedArgs: &sharedArgs{
 			arch:          "amd64",
 			kubeNamespace: "network",
+			parallelism:   1,
 			logger:        term.NewLogger(),
 			chartsPath:    "./network-config.yaml",
 		},
@@ -63,335 +63,743 @@ func NewChaincode(name string, options ...ChaincodeOption) (*Chaincode, error) {
 }
 
 func (c *Chaincode) Install(ctx context.Context, options ...ChaincodeInstallOption) error {
-	var args = &installArgs{
-		imageName: fmt.Sprintf("%s-contract:latest", c.chaincodeName),
-		version:   1,
-		sequence:  1,
-		update:    true,
+	args, err := c.newInstallArgs(options...)
+	if err != nil {
+		return err
 	}
 
-	for i := range options {
-		options[i](args)
+	definition, err := desiredDefinition(args)
+	if err != nil {
+		return err
 	}
 
-	if len(args.initErrors) > 0 {
-		return args.Error()
-	}
+	var definitionFlags = args.definitionFlags(c.collectionsConfigFile())
 
-	if committed, ver, seq, err := c.checkChaincodeCommitStatus(ctx); err != nil {
+	state, err := c.prepareInstallState(ctx, args, definition, definitionFlags)
+	if err != nil || state == nil {
 		return err
-	} else if committed {
-		c.logger.Infof(
-			"Chaincode '%s' is already committed on '%s' channel with version '%.1f' and sequence '%d'",
-			c.chaincodeName, c.channel, ver, seq,
-		)
+	}
 
-		if !args.customVersion {
-			ver += 0.1
+	if len(args.packagePath) != 0 {
+		pkg, err := readPackage(args.packagePath)
+		if err != nil {
+			return err
 		}
 
-		seq += 1
+		// Type of the prebuilt package determines whether chaincode chart is needed:
+		args.chaincodeType = pkg.metadata.Type
 
-		if args.update {
-			args.version = ver
-			args.sequence = seq
-			c.logger.Infof("It will be updated to version '%.1f' and sequence '%d'", args.version, args.sequence)
-		} else {
-			c.logger.Infof("Further steps will be skipped")
-			return nil
+		if args.chaincodeType == externalChaincodeType {
+			for _, target := range c.targets {
+				if err = c.checkPackageBuilder(ctx, target); err != nil {
+					return err
+				}
+			}
 		}
+
+		c.logger.Infof("Prebuilt %s chaincode package '%s' will be installed with version '%s'",
+			pkg.metadata.Type, pkg.id, args.version,
+		)
 	}
 
 	// Shared commands required for chaincode deployment in the letter steps:
 	var (
-		checkCommitReadinessCmd = kube.FormCommand(
-			"peer", "lifecycle", "chaincode", "checkcommitreadiness",
-			"-n", c.chaincodeName,
-			"-v", util.Vtoa(args.version),
-			"--sequence", stoa(args.sequence),
-			"-C", c.channel,
-			"-o", fmt.Sprintf("%s.%s:443", viper.GetString("fabric.orderer_hostname_name"), c.domain),
-			"--tls", "--cafile", "$ORDERER_CA",
-		)
+		checkCommitReadinessCmd = func(orderer string) string {
+			return kube.FormCommand(
+				"peer", "lifecycle", "chaincode", "checkcommitreadiness",
+				"-n", c.chaincodeName,
+				"-v", args.version,
+				"--sequence", stoa(args.sequence),
+				definitionFlags,
+				"-C", c.channel,
+				"-o", orderer,
+				"--tls", "--cafile", "$ORDERER_CA",
+			)
+		}
 
 		availableCliPod string
 	)
 
-	// Iterate over given organization and peer pairs and perform chaincode installation
-	for org, peers := range c.orgpeers {
-		for _, peer := range peers {
-			var (
-				peerPodName    = fmt.Sprintf("%s.%s.org", peer, org)
-				cliPodName     = fmt.Sprintf("cli.%s.%s.org", peer, org)
-				packageTarGzip = fmt.Sprintf("%s.%s.%s.tar.gz", c.chaincodeName, peer, org)
-				packageBuffer  bytes.Buffer
-			)
+	var (
+		targets    = c.targets
+		packageIDs = make([]string, len(targets))
+		cliPods    = make([]string, len(targets))
+		keys       = make([]string, len(targets))
+	)
 
-			c.logger.Infof("Going to install chaincode on '%s' peer of '%s' organization:", peer, org)
+	for i := range targets {
+		keys[i] = targets[i].String()
+	}
 
-			// Waiting for 'org.peer' pod readiness:
-			if ok, err := kube.WaitForPodReady(
-				ctx,
-				&peerPodName,
-				fmt.Sprintf("fabnctl/app=%s.%s.org", peer, org), c.kubeNamespace,
-			); err != nil {
-				return err
-			} else if !ok {
-				return nil
-			}
+	c.logger.Infof("Going to install chaincode on %d peers (parallelism: %d):", len(targets), c.parallelism)
 
-			// Waiting for 'org.peer.cli' pod readiness:
-			if ok, err := kube.WaitForPodReady(
-				ctx,
-				&cliPodName,
-				fmt.Sprintf("fabnctl/app=cli.%s.%s.org", peer, org),
-				c.kubeNamespace,
-			); err != nil {
-				return err
-			} else if !ok {
-				return nil
-			}
+	// Installing chaincode package on each given organization peer concurrently:
+	var (
+		progress      = c.logger.NewProgress(keys...)
+		installReport = newResultReport("chaincode installation", keys...)
+	)
 
-			var (
-				stdout    io.Reader
-				stderr    io.Reader
-				packageID string
-			)
+	progress.Start()
 
-			// Packaging chaincode into tar.gz archive:
-			if err := c.logger.Stream(func() error {
-				if err := c.packageExternalChaincodeInTarGzip(org, peer, &packageBuffer, args); err != nil {
-					return fmt.Errorf("failed to package chaincode in '%s' archive: %w", packageTarGzip, err)
-				}
-				return nil
-			}, fmt.Sprintf("Packaging chaincode into '%s' archive", packageTarGzip),
-				fmt.Sprintf("Chaincode has been packaged into '%s' archive", packageTarGzip),
-			); err != nil {
-				return nil
-			}
+	errs := c.forEachParallel(ctx, len(targets), func(ctx context.Context, i int) error {
+		var err error
 
-			// Copping chaincode package to cli pod:
-			if err := c.logger.Stream(func() error {
-				if err := kube.CopyToPod(ctx, cliPodName, c.kubeNamespace, &packageBuffer, packageTarGzip); err != nil {
-					return err
-				}
-				return nil
-			}, fmt.Sprintf("Sending chaincode package to '%s' pod", cliPodName),
-				fmt.Sprintf("Chaincode package has been sent to '%s' pod", cliPodName),
+		// Peers having the package installed during the resumed run only need their cli pod to be found:
+		if packageIDs[i] = state.packageID(keys[i]); len(packageIDs[i]) != 0 {
+			progress.Textf(keys[i], "Waiting for cli pod readiness")
+
+			if cliPods[i], err = kube.AwaitPodReady(ctx,
+				fmt.Sprintf("fabnctl/app=cli.%s.%s.org", targets[i].peer, targets[i].org), c.kubeNamespace,
 			); err != nil {
-				return nil
+				progress.Fail(keys[i], err)
+				return fmt.Errorf("failed to find cli pod of '%s' peer: %w", keys[i], err)
 			}
 
-			// Installing chaincode package:
-			if err := c.logger.Stream(func() error {
-				var err error
-				if _, stderr, err = kube.ExecCommandInPod(ctx, cliPodName, c.kubeNamespace,
-					"peer", "lifecycle", "chaincode", "install", packageTarGzip,
-				); err != nil {
-					if errors.Is(err, term.ErrRemoteCmdFailed) {
-						return fmt.Errorf("Failed to install chaincode package: %w", err)
-					}
+			var reason = fmt.Sprintf("Chaincode package was installed by the resumed run: %s", packageIDs[i])
 
-					return fmt.Errorf("Failed to execute command on '%s' pod: %w", cliPodName, err)
-				}
+			progress.Persist(keys[i], term.LogStreamInfo, reason)
+			installReport.succeed(i, reason)
 
-				return nil
-			}, "Installing chaincode package", "Chaincode package has been installed"); err != nil {
-				return c.logger.WrapWithStderrViewPrompt(err, stderr, false)
-			}
+			return nil
+		}
 
-			packageID = parseInstalledPackageID(stderr)
+		if packageIDs[i], cliPods[i], err = c.installOnPeer(ctx, targets[i], args, progress); err != nil {
+			progress.Fail(keys[i], err)
+			return fmt.Errorf("failed to install chaincode on '%s' peer: %w", keys[i], err)
+		}
 
-			fmt.Printf("%s Chaincode package identifier: %s\n", viper.GetString("cli.info_emoji"), packageID)
+		if err = c.saveInstallState(ctx, state, func(state *installState) {
+			state.Packages[keys[i]] = packageIDs[i]
+		}); err != nil {
+			progress.Fail(keys[i], err)
+			return err
+		}
 
-			// Preparing additional values for chart installation:
-			var (
-				values    = make(map[string]interface{})
-				chartSpec = &helmclient.ChartSpec{
-					ReleaseName: fmt.Sprintf("%s-cc-%s-%s", c.chaincodeName, peer, org),
-					ChartName:   path.Join(c.chartsPath, "chaincode"),
-					Namespace:   c.kubeNamespace,
-					Wait:        true,
-				}
-			)
+		progress.Complete(keys[i], fmt.Sprintf("Chaincode package installed: %s", packageIDs[i]))
+		installReport.succeed(i, fmt.Sprintf("package %s installed", packageIDs[i]))
+
+		return nil
+	})
 
-			values["image"] = map[string]interface{}{
-				"repository": args.imageName,
+	progress.Stop()
+	installReport.collect(errs)
+
+	c.logger.NewLine()
+	installReport.print(c.logger)
+
+	if err := firstError(errs); err != nil && !c.continueOnError {
+		return err
+	}
+
+	// Approving chaincode definition for each organization, once its peers have the package installed.
+	// Approval is performed with the package of the first organization's peer, which has it installed:
+	var (
+		orgs      []string
+		orgTarget = make(map[string]int)
+	)
+
+	for i, target := range targets {
+		if _, ok := orgTarget[target.org]; !ok {
+			orgs = append(orgs, target.org)
+			orgTarget[target.org] = -1
+		}
+
+		if installReport.succeeded(i) && orgTarget[target.org] < 0 {
+			orgTarget[target.org] = i
+		}
+	}
+
+	progress = c.logger.NewProgress(orgs...)
+	approveReport := newResultReport("chaincode approval", orgs...)
+
+	progress.Start()
+
+	errs = c.forEachParallel(ctx, len(orgs), func(ctx context.Context, i int) error {
+		var org = orgs[i]
+
+		if orgTarget[org] < 0 {
+			var reason = fmt.Sprintf("None of '%s' organization peers has chaincode package installed", org)
+
+			progress.Persist(org, term.LogStreamWarning, reason)
+			approveReport.skip(i, reason)
+
+			return nil
+		}
+
+		var (
+			cliPodName = cliPods[orgTarget[org]]
+			packageID  = packageIDs[orgTarget[org]]
+			approveCmd = func(orderer string) string {
+				return kube.FormCommand(
+					"peer", "lifecycle", "chaincode", "approveformyorg",
+					"-n", c.chaincodeName,
+					"-v", args.version,
+					"--sequence", stoa(args.sequence),
+					"--package-id", packageID,
+					definitionFlags,
+					"-C", c.channel,
+					"-o", orderer,
+					"--tls", "--cafile", "$ORDERER_CA",
+				)
 			}
+		)
+
+		if state.isApproved(org) {
+			var reason = fmt.Sprintf("Chaincode was approved for '%s' organization by the resumed run", org)
+
+			progress.Persist(org, term.LogStreamInfo, reason)
+			approveReport.skip(i, reason)
+
+			return nil
+		}
 
-			values["peer"] = peer
-			values["org"] = org
-			values["chaincode"] = c.chaincodeName
-			values["ccid"] = packageID
+		// Checking whether the chaincode was already approved by organization:
+		progress.Textf(org, "Checking chaincode approval")
 
-			valuesYaml, err := yaml.Marshal(values)
-			if err != nil {
-				return fmt.Errorf("failed to encode additional values: %w", err)
+		stdout, _, err := c.execWithOrdererFailover(ctx, cliPodName, checkCommitReadinessCmd)
+		if err != nil {
+			if errors.Is(err, term.ErrRemoteCmdFailed) {
+				err = fmt.Errorf("failed to check chaincode approval by '%s' organization: %w", org, err)
+			} else {
+				err = fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
 			}
 
-			chartSpec.ValuesYaml = string(valuesYaml)
+			progress.Fail(org, err)
+			return err
+		}
 
-			// Installing orderer helm chart:
-			helmCtx, cancel := context.WithTimeout(ctx, viper.GetDuration("helm.install_timeout"))
+		if checkChaincodeApprovalByOrg(stdout, org) {
+			var reason = fmt.Sprintf("Chaincode is already approved by '%s' organization", org)
 
-			if err = c.logger.Stream(func() error {
-				defer cancel()
-				if err = helm.Client.InstallOrUpgradeChart(helmCtx, chartSpec); err != nil {
-					return fmt.Errorf("failed to install chaincode helm chart: %w", err)
-				}
-				return nil
-			}, "Installing chaincode chart",
-				fmt.Sprintf("Chart 'chaincode/%s' installed successfully", chartSpec.ReleaseName),
-			); err != nil {
-				return nil
+			progress.Persist(org, term.LogStreamInfo, reason)
+			approveReport.skip(i, reason)
+
+			return nil
+		}
+
+		// Approving chaincode:
+		progress.Textf(org, "Approving chaincode")
+
+		if _, _, err = c.execWithOrdererFailover(ctx, cliPodName, approveCmd); err != nil {
+			if errors.Is(err, term.ErrRemoteCmdFailed) {
+				err = fmt.Errorf("failed to approve chaincode for '%s' organization: %w", org, err)
+			} else {
+				err = fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
 			}
 
-			// Checking whether the chaincode was already approved by organization:
-			if stdout, stderr, err = kube.ExecShellInPod(ctx,
-				cliPodName, c.kubeNamespace,
-				checkCommitReadinessCmd,
-			); err != nil {
-				if errors.Is(err, term.ErrRemoteCmdFailed) {
-					return c.logger.WrapWithStderrViewPrompt(
-						fmt.Errorf("Failed to check chaincode approval by '%s' organization: %w", org, err),
-						stderr, true,
-					)
-				}
+			progress.Fail(org, err)
+			return err
+		}
+
+		if err = c.saveInstallState(ctx, state, func(state *installState) {
+			state.Approved = append(state.Approved, org)
+		}); err != nil {
+			progress.Fail(org, err)
+			return err
+		}
+
+		progress.Complete(org, fmt.Sprintf("Chaincode has been approved for '%s' organization", org))
+
+		return nil
+	})
+
+	progress.Stop()
+	approveReport.collect(errs)
+
+	c.logger.NewLine()
+	approveReport.print(c.logger)
+
+	if err := firstError(errs); err != nil && !c.continueOnError {
+		return err
+	}
+
+	// Further steps are performed with peers having the package installed:
+	var (
+		installed         []peerTarget
+		installedPackages = make(map[string]string)
+	)
+
+	for i := range targets {
+		if installReport.succeeded(i) {
+			installed = append(installed, targets[i])
+			installedPackages[keys[i]] = packageIDs[i]
+			availableCliPod = cliPods[i]
+		}
+	}
+
+	if len(installed) == 0 {
+		return installReport.failure()
+	}
+
+	// Committing chaincode on the organization peers having it installed:
+	var commitPeers = c.commitPeersFlags(installed)
+
+	var stderr io.Reader
 
-				return fmt.Errorf("Failed to execute command on '%s' pod: %w", cliPodName, err)
+	if state.Committed {
+		c.logger.Infof("Chaincode '%s' v%s was committed by the resumed run", c.chaincodeName, args.version)
+	} else {
+		// Verifying commit readiness,
+		// by checking that all organizations on channel approved chaincode:
+		if stdout, stderr, err := c.execWithOrdererFailover(ctx, availableCliPod, checkCommitReadinessCmd); err != nil {
+			if errors.Is(err, term.ErrRemoteCmdFailed) {
+				return c.logger.WrapWithStderrViewPrompt(
+					fmt.Errorf("failed to check chaincode commit readiness: %w", err),
+					stderr, true,
+				)
 			}
 
-			var approveCmd = kube.FormCommand(
-				"peer", "lifecycle", "chaincode", "approveformyorg",
+			return fmt.Errorf("failed to execute command on '%s' pod: %w", availableCliPod, err)
+		} else if ready, notApprovedBy := checkChaincodeCommitReadiness(stdout); !ready {
+			return fmt.Errorf(
+				"chaincode isn't ready to be commited, some organizations on '%s' channel haven't approved it yet: %s",
+				c.channel, strings.Join(notApprovedBy, ", "),
+			)
+		} else {
+			c.logger.Okf(
+				"Chaincode has been approved by all organizations on '%s' channel, it's ready to be committed",
+				c.channel,
+			)
+		}
+
+		var commitCmd = func(orderer string) string {
+			return kube.FormCommand(
+				"peer", "lifecycle", "chaincode", "commit",
 				"-n", c.chaincodeName,
-				"-v", util.Vtoa(args.version),
+				"-v", args.version,
 				"--sequence", stoa(args.sequence),
-				"--package-id", packageID,
-				"--init-required=false",
+				definitionFlags,
 				"-C", c.channel,
-				"-o", fmt.Sprintf("%s.%s:443", viper.GetString("fabric.orderer_hostname_name"), c.domain),
+				"-o", orderer,
 				"--tls", "--cafile", "$ORDERER_CA",
+				commitPeers,
 			)
+		}
 
-			// Approving chaincode if needed:
-			if !checkChaincodeApprovalByOrg(stdout, org) {
-				if err = c.logger.Stream(func() (err error) {
-					if _, stderr, err = kube.ExecShellInPod(ctx,
-						cliPodName, c.kubeNamespace,
-						approveCmd,
-					); err != nil {
-						if errors.Is(err, term.ErrRemoteCmdFailed) {
-							return fmt.Errorf("Failed to approve chaincode for '%s' organization: %w", org, err)
-						}
-
-						return fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
+		c.logger.NewLine()
+
+		if err := c.logger.Stream(func() (err error) {
+			if _, stderr, err = c.execWithOrdererFailover(ctx, availableCliPod, commitCmd); err != nil {
+				if errors.Is(err, term.ErrRemoteCmdFailed) {
+					return errors.Wrapf(err,
+						"Failed to commit chaincode",
+					)
+				}
+
+				return fmt.Errorf("failed to execute command on '%s' pod: %w", availableCliPod, err)
+			}
+
+			return nil
+		}, "Committing chaincode on organization peers",
+			"Chaincode has been committed on all organization peers",
+		); err != nil {
+			return c.logger.WrapWithStderrViewPrompt(err, stderr, false)
+		}
+
+		if err = c.saveInstallState(ctx, state, func(state *installState) {
+			state.Committed = true
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Recording committed deployment, so that it could be rolled back to later on:
+	var record = deploymentRecord{
+		Version:      args.version,
+		Sequence:     args.sequence,
+		Type:         args.chaincodeType,
+		Definition:   definitionFlags,
+		InitRequired: args.initRequired,
+		Packages:     installedPackages,
+	}
+
+	if len(args.collectionsConfig) != 0 {
+		payload, err := ioutil.ReadFile(args.collectionsConfig)
+		if err != nil {
+			return fmt.Errorf("failed to read collections config: %w", err)
+		}
+
+		record.Collections = string(payload)
+	}
+
+	if err = c.recordDeployment(ctx, record); err != nil {
+		return err
+	}
+
+	// Invoking 'Init' function, required before any other transaction on the new chaincode version:
+	if args.initRequired {
+		if !state.InitPending {
+			c.logger.Infof("Chaincode '%s' v%s is already initialized", c.chaincodeName, args.version)
+		} else {
+			var initCmd = func(orderer string) string {
+				return kube.FormCommand(
+					"peer", "chaincode", "invoke",
+					"-n", c.chaincodeName,
+					"-C", c.channel,
+					"--isInit",
+					"-c", shellQuote(`{"function":"Init","Args":[]}`),
+					"--waitForEvent",
+					"-o", orderer,
+					"--tls", "--cafile", "$ORDERER_CA",
+					commitPeers,
+				)
+			}
+
+			stderr = nil
+
+			if err := c.logger.Stream(func() (err error) {
+				if _, stderr, err = c.execWithOrdererFailover(ctx, availableCliPod, initCmd); err != nil {
+					if errors.Is(err, term.ErrRemoteCmdFailed) {
+						return errors.Wrapf(err, "Failed to invoke chaincode 'Init' function")
 					}
 
-					return nil
-				}, "Approving chaincode",
-					fmt.Sprintf("Chaincode has been approved for '%s' organization", org),
-				); err != nil {
-					return c.logger.WrapWithStderrViewPrompt(err, stderr, false)
+					return fmt.Errorf("failed to execute command on '%s' pod: %w", availableCliPod, err)
 				}
-			} else {
-				c.logger.Infof("Chaincode is already approved by '%s' organization", org)
+
+				return nil
+			}, "Invoking chaincode 'Init' function", "Chaincode has been initialized"); err != nil {
+				return c.logger.WrapWithStderrViewPrompt(err, stderr, false)
 			}
 
-			availableCliPod = cliPodName
+			if err = c.saveInstallState(ctx, state, func(state *installState) {
+				state.InitPending = false
+			}); err != nil {
+				return err
+			}
 		}
 	}
 
-	c.logger.NewLine()
+	// Some peers or organizations could be skipped due to failures, when continuing on errors.
+	// Installation state is kept in such case, so that they could be retried with resumed run:
+	for _, report := range []*resultReport{installReport, approveReport} {
+		if err := report.failure(); err != nil {
+			return fmt.Errorf("chaincode '%s' v%s is committed, but %w", c.chaincodeName, args.version, err)
+		}
+	}
+
+	if err = c.deleteInstallState(ctx); err != nil {
+		return err
+	}
+
+	c.logger.Successf("Chaincode '%s' v%s successfully deployed!", c.chaincodeName, args.version)
+
+	return nil
+}
+
+// installOnPeer performs installation steps for the single organization peer `target`:
+// waits for its pods readiness, packages and installs chaincode and deploys chaincode chart for the external one,
+// while reporting the current step to its `progress` line. Returns installed package ID and cli pod name.
+func (c *Chaincode) installOnPeer(
+	ctx context.Context,
+	target peerTarget,
+	args *installArgs,
+	progress *term.Progress,
+) (packageID string, cliPodName string, err error) {
+	var (
+		org            = target.org
+		peer           = target.peer
+		key            = target.String()
+		packageTarGzip = fmt.Sprintf("%s.%s.%s.tar.gz", c.chaincodeName, peer, org)
+		packageBuffer  bytes.Buffer
+	)
+
+	// Waiting for 'org.peer' and 'org.peer.cli' pods readiness:
+	progress.Textf(key, "Waiting for peer pod readiness")
+
+	if _, err = kube.AwaitPodReady(ctx, fmt.Sprintf("fabnctl/app=%s.%s.org", peer, org), c.kubeNamespace); err != nil {
+		return "", "", err
+	}
 
-	// Verifying commit readiness,
-	// by checking that all organizations on channel approved chaincode:
-	if stdout, stderr, err := kube.ExecShellInPod(ctx,
-		availableCliPod, c.kubeNamespace,
-		checkCommitReadinessCmd,
+	progress.Textf(key, "Waiting for cli pod readiness")
+
+	if cliPodName, err = kube.AwaitPodReady(ctx,
+		fmt.Sprintf("fabnctl/app=cli.%s.%s.org", peer, org), c.kubeNamespace,
 	); err != nil {
-		if errors.Is(err, term.ErrRemoteCmdFailed) {
-			return c.logger.WrapWithStderrViewPrompt(
-				fmt.Errorf("failed to check chaincode commit readiness: %w", err),
-				stderr, true,
-			)
+		return "", "", err
+	}
+
+	// Issuing certificates for mutual TLS between peer and chaincode service:
+	var (
+		tls      *chaincodeTLS
+		external = args.chaincodeType == externalChaincodeType
+	)
+
+	if args.tls && external {
+		progress.Textf(key, "Issuing chaincode TLS certificates")
+
+		if tls, err = c.issueChaincodeTLS(ctx, org, peer); err != nil {
+			return "", "", fmt.Errorf("failed to issue chaincode TLS certificates: %w", err)
 		}
+	}
 
-		return fmt.Errorf("failed to execute command on '%s' pod: %w", availableCliPod, err)
-	} else if ready, notApprovedBy := checkChaincodeCommitReadiness(stdout); !ready {
-		return fmt.Errorf(
-			"chaincode isn't ready to be commited, some organizations on '%s' channel haven't approved it yet: %s",
-			c.channel, strings.Join(notApprovedBy, ", "),
-		)
+	// Packaging chaincode into tar.gz archive, unless the prebuilt one is given:
+	if len(args.packagePath) != 0 {
+		progress.Textf(key, "Reading chaincode package from '%s'", args.packagePath)
+
+		pkg, err := readPackage(args.packagePath)
+		if err != nil {
+			return "", "", err
+		}
+
+		packageBuffer.Write(pkg.payload)
+	} else if !external {
+		progress.Textf(key, "Packaging chaincode source into '%s' archive", packageTarGzip)
+
+		if err = c.packageSourceChaincodeInTarGzip(&packageBuffer, args); err != nil {
+			return "", "", fmt.Errorf("failed to package chaincode in '%s' archive: %w", packageTarGzip, err)
+		}
 	} else {
-		c.logger.Okf(
-			"Chaincode has been approved by all organizations on '%s' channel, it's ready to be committed",
-			c.channel,
-		)
+		progress.Textf(key, "Packaging chaincode into '%s' archive", packageTarGzip)
+
+		if err = c.packageExternalChaincodeInTarGzip(
+			fmt.Sprintf("%s:7052", c.serviceName(org, peer)), &packageBuffer, args, tls,
+		); err != nil {
+			return "", "", fmt.Errorf("failed to package chaincode in '%s' archive: %w", packageTarGzip, err)
+		}
 	}
 
-	var commitCmd = kube.FormCommand(
-		"peer", "lifecycle", "chaincode", "commit",
-		"-n", c.chaincodeName,
-		"-v", util.Vtoa(args.version),
-		"--sequence", stoa(args.sequence),
-		"--init-required=false",
-		"-C", c.channel,
-		"-o", fmt.Sprintf("%s.%s:443", viper.GetString("fabric.orderer_hostname_name"), c.domain),
-		"--tls", "--cafile", "$ORDERER_CA",
+	// Copping chaincode package to cli pod:
+	progress.Textf(key, "Sending chaincode package to '%s' pod", cliPodName)
+
+	if err = kube.CopyToPod(ctx, cliPodName, c.kubeNamespace, &packageBuffer, packageTarGzip); err != nil {
+		return "", "", fmt.Errorf("failed to send chaincode package to '%s' pod: %w", cliPodName, err)
+	}
+
+	// Copping private data collections config to cli pod:
+	if len(args.collectionsConfig) != 0 {
+		progress.Textf(key, "Sending collections config to '%s' pod", cliPodName)
+
+		payload, err := ioutil.ReadFile(args.collectionsConfig)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read collections config: %w", err)
+		}
+
+		if err = kube.CopyToPod(ctx, cliPodName, c.kubeNamespace,
+			bytes.NewBuffer(payload), c.collectionsConfigFile(),
+		); err != nil {
+			return "", "", fmt.Errorf("failed to send collections config to '%s' pod: %w", cliPodName, err)
+		}
+	}
+
+	// Installing chaincode package:
+	progress.Textf(key, "Installing chaincode package")
+
+	_, stderr, err := kube.ExecCommandInPod(ctx, cliPodName, c.kubeNamespace,
+		"peer", "lifecycle", "chaincode", "install", packageTarGzip,
 	)
+	if err != nil {
+		if errors.Is(err, term.ErrRemoteCmdFailed) {
+			return "", "", fmt.Errorf("failed to install chaincode package: %w", err)
+		}
 
-	// Committing chaincode on peers of all given organizations:
-	for org, peers := range c.orgpeers {
-		for _, peer := range peers {
-			var (
-				orgHost              = fmt.Sprintf("%s.org.%s", org, c.domain)
-				peerHost             = fmt.Sprintf("%s.%s", peer, orgHost)
-				cryptoConfigPathBase = "/opt/gopath/src/github.com/hyperledger/fabric/peer/crypto-config"
-				commitCmdEnding      = kube.FormCommand(
-					"--peerAddresses", fmt.Sprintf("%s:443", peerHost),
-					"--tlsRootCertFiles", path.Join(
-						cryptoConfigPathBase,
-						"peerOrganizations", orgHost,
-						"peers", peerHost,
-						"tls", "ca.crt",
-					),
-				)
-			)
+		return "", "", fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
+	}
+
+	packageID = parseInstalledPackageID(stderr)
 
-			commitCmd = kube.FormCommand(commitCmd, commitCmdEnding)
+	// Chaincode source package is built and launched by peer, thus no chaincode chart is needed:
+	if !external {
+		return packageID, cliPodName, nil
+	}
+
+	// Preparing additional values for chart installation:
+	var (
+		values    = make(map[string]interface{})
+		chartSpec = &helmclient.ChartSpec{
+			ReleaseName: c.releaseName(org, peer),
+			ChartName:   path.Join(c.chartsPath, "chaincode"),
+			Namespace:   c.kubeNamespace,
+			Wait:        true,
 		}
+	)
+
+	values["image"] = map[string]interface{}{
+		"repository": args.imageName,
 	}
 
-	c.logger.NewLine()
+	values["peer"] = peer
+	values["org"] = org
+	values["chaincode"] = c.chaincodeName
+	values["ccid"] = packageID
 
-	var stderr io.Reader
-	if err := c.logger.Stream(func() (err error) {
-		if _, stderr, err = kube.ExecShellInPod(ctx,
-			availableCliPod, c.kubeNamespace,
-			commitCmd,
-		); err != nil {
-			if errors.Is(err, term.ErrRemoteCmdFailed) {
-				return errors.Wrapf(err,
-					"Failed to commit chaincode",
-				)
-			}
+	// Sending chaincode service key pair, which is mounted into chaincode container:
+	if tls != nil {
+		progress.Textf(key, "Creating chaincode TLS secret")
 
-			return fmt.Errorf("failed to execute command on '%s' pod: %w", availableCliPod, err)
+		secretName, err := c.createTLSSecret(ctx, org, peer, tls)
+		if err != nil {
+			return "", "", err
 		}
 
-		return nil
-	}, "Committing chaincode on organization peers",
-		"Chaincode has been committed on all organization peers",
-	); err != nil {
-		return c.logger.WrapWithStderrViewPrompt(err, stderr, false)
+		values["tls"] = map[string]interface{}{
+			"enabled": true,
+			"secret":  secretName,
+		}
 	}
 
-	c.logger.Successf("Chaincode '%s' v%.1f successfully deployed!", c.chaincodeName, args.version)
+	valuesYaml, err := yaml.Marshal(values)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode additional values: %w", err)
+	}
 
-	return nil
+	chartSpec.ValuesYaml = string(valuesYaml)
+
+	// Installing chaincode helm chart:
+	progress.Textf(key, "Installing '%s' chaincode chart", chartSpec.ReleaseName)
+
+	helmCtx, cancel := context.WithTimeout(ctx, viper.GetDuration("helm.install_timeout"))
+	defer cancel()
+
+	if err = helm.Client.InstallOrUpgradeChart(helmCtx, chartSpec); err != nil {
+		return "", "", fmt.Errorf("failed to install chaincode helm chart: %w", err)
+	}
+
+	// Chaincode must pass readiness checks before it gets committed, so that broken image won't be committed:
+	progress.Textf(key, "Waiting for chaincode deployment readiness")
+
+	if err = kube.AwaitDeploymentReady(ctx, c.deploymentName(org, peer), c.kubeNamespace); err != nil {
+		return "", "", fmt.Errorf("chaincode deployment isn't ready: %w", err)
+	}
+
+	return packageID, cliPodName, nil
 }
 
-func (c *Chaincode) packageExternalChaincodeInTarGzip(org, peer string, writer io.Writer, args *installArgs) error {
+// newInstallArgs forms installation arguments of the chaincode from given `options`.
+func (c *Chaincode) newInstallArgs(options ...ChaincodeInstallOption) (*installArgs, error) {
+	var args = &installArgs{
+		imageName:     fmt.Sprintf("%s-contract:latest", c.chaincodeName),
+		version:       "1.0",
+		sequence:      1,
+		update:        true,
+		chaincodeType: externalChaincodeType,
+	}
+
+	for i := range options {
+		options[i](args)
+	}
+
+	if args.versionFromGit {
+		if !args.withSource {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("chaincode source path is required to determine version from git"),
+			)
+		} else if version, err := util.VersionFromGit(args.sourcePathAbs); err != nil {
+			args.initErrors = append(args.initErrors, err)
+		} else {
+			args.version, args.customVersion = version, true
+		}
+	}
+
+	if len(args.packagePath) != 0 && args.tls {
+		args.initErrors = append(args.initErrors,
+			fmt.Errorf("TLS certificates are issued for each peer, thus can't be used with prebuilt package"),
+		)
+	}
+
+	if args.chaincodeType != externalChaincodeType {
+		if !args.withSource && len(args.packagePath) == 0 {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("chaincode source path is required for '%s' chaincode package", args.chaincodeType),
+			)
+		}
+
+		if args.tls {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("TLS is only applicable to external chaincode, while '%s' one is launched by peer", args.chaincodeType),
+			)
+		}
+	}
+
+	if len(args.initErrors) > 0 {
+		return nil, args.Error()
+	}
+
+	return args, nil
+}
+
+// definitionChanges compares `definition` with the one of the committed chaincode
+// and lists parameters which differ, thus requiring new sequence to be applied.
+func (c *Chaincode) definitionChanges(ctx context.Context, definition *chaincodeDefinition) ([]string, error) {
+	cliPodName, err := c.availableCliPod(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	committed, err := c.committedDefinition(ctx, cliPodName)
+	if err != nil {
+		return nil, err
+	}
+
+	return definition.changes(committed), nil
+}
+
+// collectionsConfigFile returns name of the collections config file sent to the cli pods.
+func (c *Chaincode) collectionsConfigFile() string {
+	return fmt.Sprintf("%s.collections.json", c.chaincodeName)
+}
+
+// deploymentName forms name of the chaincode deployment of the organization `peer`, as rendered by chaincode chart.
+func (c *Chaincode) deploymentName(org, peer string) string {
+	return fmt.Sprintf("%s-chaincode.%s.%s", c.chaincodeName, peer, org)
+}
+
+// releaseName forms name of the chaincode helm release of the organization `peer`.
+func (c *Chaincode) releaseName(org, peer string) string {
+	return fmt.Sprintf("%s-cc-%s-%s", c.chaincodeName, peer, org)
+}
+
+// commitPeersFlags forms 'peer lifecycle chaincode commit' flags for the `targets` peers endorsing the commit.
+func (c *Chaincode) commitPeersFlags(targets []peerTarget) string {
+	var commitPeers string
+
+	for _, target := range targets {
+		var (
+			orgHost              = fmt.Sprintf("%s.org.%s", target.org, c.domain)
+			peerHost             = fmt.Sprintf("%s.%s", target.peer, orgHost)
+			cryptoConfigPathBase = "/opt/gopath/src/github.com/hyperledger/fabric/peer/crypto-config"
+			commitCmdEnding      = kube.FormCommand(
+				"--peerAddresses", fmt.Sprintf("%s:443", peerHost),
+				"--tlsRootCertFiles", path.Join(
+					cryptoConfigPathBase,
+					"peerOrganizations", orgHost,
+					"peers", peerHost,
+					"tls", "ca.crt",
+				),
+			)
+		)
+
+		commitPeers = kube.FormCommand(commitPeers, commitCmdEnding)
+	}
+
+	return commitPeers
+}
+
+// definitionFlags forms 'peer lifecycle chaincode' flags for the definition parameters,
+// where `collectionsConfig` is the name of collections config file in the cli pod.
+func (args *installArgs) definitionFlags(collectionsConfig string) string {
+	var flags = []string{fmt.Sprintf("--init-required=%t", args.initRequired)}
+
+	if policy := strings.TrimSpace(args.policy); strings.HasPrefix(policy, "/") {
+		flags = append(flags, "--channel-config-policy", shellQuote(policy))
+	} else if len(policy) != 0 {
+		flags = append(flags, "--signature-policy", shellQuote(policy))
+	}
+
+	if len(args.collectionsConfig) != 0 {
+		flags = append(flags, "--collections-config", collectionsConfig)
+	}
+
+	return kube.FormCommand(flags...)
+}
+
+// packageExternalChaincodeInTarGzip writes external chaincode package into `writer`,
+// where connection.json points to chaincode service `address`.
+func (c *Chaincode) packageExternalChaincodeInTarGzip(
+	address string,
+	writer io.Writer,
+	args *installArgs,
+	tls *chaincodeTLS,
+) error {
 	var (
 		codeBuffer bytes.Buffer
 		mdBuffer   bytes.Buffer
@@ -404,21 +812,31 @@ func (c *Chaincode) packageExternalChaincodeInTarGzip(org, peer string, writer i
 		packageTar  = tar.NewWriter(packageGzip)
 
 		metadata = model.ChaincodeMetadata{
-			Type:  "external",
-			Label: c.chaincodeName,
+			Type:  externalChaincodeType,
+			Label: packageLabel(c.chaincodeName, args.version),
 		}
 		connection = model.ChaincodeConnection{
-			Address:     fmt.Sprintf("%s-chaincode-%s-%s:7052", c.chaincodeName, peer, org),
+			Address:     address,
 			DialTimeout: "10s",
 		}
 	)
 
+	if tls != nil {
+		tls.applyTo(&connection)
+	}
+
 	defer func() {
 		if err := packageGzip.Close(); err != nil {
 			c.logger.Error(err, "failed to close package gzip writer")
 		}
 	}()
 
+	defer func() {
+		if err := packageTar.Close(); err != nil {
+			c.logger.Error(err, "failed to close package tar writer")
+		}
+	}()
+
 	defer func() {
 		if err := codeTar.Close(); err != nil {
 			c.logger.Error(err, "failed to close code tar writer")
@@ -434,18 +852,14 @@ func (c *Chaincode) packageExternalChaincodeInTarGzip(org, peer string, writer i
 	}
 
 	if args.withSource {
-		indexesPath := path.Join(args.sourcePathAbs, "META-INF", "statedb", "couchdb", "indexes")
-		if indexes, err := ioutil.ReadDir(indexesPath); err == nil {
-			for _, index := range indexes {
-				indexBytes, err := ioutil.ReadFile(path.Join(indexesPath, index.Name()))
-				if err != nil {
-					continue
-				}
+		indexes, err := readValidIndexes(args.sourcePathAbs)
+		if err != nil {
+			return err
+		}
 
-				metaIndexPath := path.Join("META-INF", "statedb", "couchdb", "indexes", index.Name())
-				if err = util.WriteBytesToTar(metaIndexPath, bytes.NewBuffer(indexBytes), codeTar); err != nil {
-					return fmt.Errorf("failed to write '%s' into code tar archive: %w", metaIndexPath, err)
-				}
+		for _, index := range indexes {
+			if err = util.WriteBytesToTar(index.path, bytes.NewBuffer(index.payload), codeTar); err != nil {
+				return fmt.Errorf("failed to write '%s' into code tar archive: %w", index.path, err)
 			}
 		}
 	}
@@ -473,52 +887,45 @@ func (c *Chaincode) packageExternalChaincodeInTarGzip(org, peer string, writer i
 	return nil
 }
 
-func (c *Chaincode) checkChaincodeCommitStatus(ctx context.Context) (bool, float64, int, error) {
-	var (
-		availableCliPod string
-		buffer          bytes.Buffer
-	)
-
-	if pods, err := kube.Client.CoreV1().Pods("").List(ctx, metav1.ListOptions{
-		LabelSelector: "fabnctl/cid=org-peer-cli",
-	}); err != nil {
-		return false, 0, 0, fmt.Errorf("failed to find available cli pod for chaincode commit status check: %w", err)
-	} else if pods == nil || pods.Size() == 0 {
-		return false, 0, 0, fmt.Errorf("failed to find available cli pod for chaincode commit status check")
-	} else {
-		availableCliPod = pods.Items[0].Name
+func (c *Chaincode) checkChaincodeCommitStatus(ctx context.Context) (bool, string, int, error) {
+	availableCliPod, err := c.availableCliPod(ctx)
+	if err != nil {
+		return false, "", 0, err
 	}
 
 	// Checking whether the chaincode was already committed:
-	stdout, stderr, err := kube.ExecCommandInPod(
-		ctx,
-		availableCliPod, c.kubeNamespace,
-		"peer", "lifecycle", "chaincode", "querycommitted", "-C", c.channel,
-	)
-
+	chaincodes, err := committedChaincodes(ctx, c.kubeNamespace, availableCliPod, c.channel)
 	if err != nil {
-		if errors.Is(err, term.ErrRemoteCmdFailed) {
-			return false, 0, 0, c.logger.WrapWithStderrViewPrompt(
-				fmt.Errorf("failed to check сommit status for '%s' chaincode: %w", c.chaincodeName, err),
-				stderr, true,
-			)
-		}
-
-		return false, 0, 0, fmt.Errorf("Failed to execute command on '%s' pod: %w", availableCliPod, err)
+		return false, "", 0, fmt.Errorf("failed to check сommit status for '%s' chaincode: %w", c.chaincodeName, err)
 	}
 
-	if n, err := io.Copy(&buffer, stdout); err != nil || n == 0 {
-		return false, 0, 0, nil
+	for _, chaincode := range chaincodes {
+		if chaincode.Name == c.chaincodeName {
+			return true, chaincode.Version, chaincode.Sequence, nil
+		}
 	}
 
-	match := regexp.MustCompile(fmt.Sprintf("Name: %s, Version: (\\d*.\\d*), Sequence: (\\d*)", c.chaincodeName)).
-		FindStringSubmatch(buffer.String())
+	return false, "", 0, nil
+}
 
-	if len(match) < 3 {
-		return false, 0, 0, nil
+// availableCliPod finds any peer cli pod, which can be used for querying chaincodes committed on the channel.
+func (c *Chaincode) availableCliPod(ctx context.Context) (string, error) {
+	if pods, err := kube.Client.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		LabelSelector: "fabnctl/cid=org-peer-cli",
+	}); err != nil {
+		return "", fmt.Errorf("failed to find available cli pod for chaincode commit status check: %w", err)
+	} else if pods == nil || len(pods.Items) == 0 {
+		return "", fmt.Errorf("failed to find available cli pod for chaincode commit status check")
+	} else {
+		return pods.Items[0].Name, nil
 	}
+}
 
-	return true, util.Atov(match[1]), atos(match[2]), nil
+// packageLabel forms label of the chaincode package carrying its `version`, e.g. 'assets_1.2.0',
+// so that installed packages of different versions could be told apart.
+// Characters not allowed by Fabric in package labels are replaced with '-'.
+func packageLabel(name, version string) string {
+	return fmt.Sprintf("%s_%s", name, regexp.MustCompile(`[^[:alnum:]_.+-]`).ReplaceAllString(version, "-"))
 }
 
 func parseInstalledPackageID(reader io.Reader) string {
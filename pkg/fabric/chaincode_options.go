@@ -2,6 +2,7 @@ package fabric
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -16,8 +17,8 @@ type (
 	ChaincodeOption func(*chaincodeArgs)
 
 	chaincodeArgs struct {
-		channel  string
-		orgpeers map[string][]string
+		channel string
+		targets []peerTarget
 		*sharedArgs
 	}
 )
@@ -25,7 +26,7 @@ type (
 // WithChaincodePeers ...
 func WithChaincodePeers(org string, peers ...string) ChaincodeOption {
 	return func(args *chaincodeArgs) {
-		args.orgpeers[org] = append(args.orgpeers[org], peers...)
+		args.targets = appendPeerTargets(args.targets, org, peers...)
 
 		if len(peers) == 0 {
 			args.initErrors = append(args.initErrors,
@@ -60,8 +61,9 @@ func WithChaincodePeersFlag(flags *pflag.FlagSet, orgsFlag, peersFlag string) Ch
 				args.initErrors = append(args.initErrors,
 					fmt.Errorf("some passed organizations missing corresponding peer parameter: %s", org),
 				)
+				continue
 			}
-			args.orgpeers[org] = strings.Split(peers[i], ",")
+			args.targets = appendPeerTargets(args.targets, org, strings.Split(peers[i], ",")...)
 		}
 	}
 }
@@ -100,14 +102,22 @@ type (
 	ChaincodeInstallOption func(*installArgs)
 
 	installArgs struct {
-		imageName     string
-		withSource    bool
-		sourcePath    string
-		sourcePathAbs string
-		update        bool
-		customVersion bool
-		version       float64
-		sequence      int
+		imageName         string
+		withSource        bool
+		sourcePath        string
+		sourcePathAbs     string
+		update            bool
+		customVersion     bool
+		versionFromGit    bool
+		version           string
+		sequence          int
+		policy            string
+		collectionsConfig string
+		initRequired      bool
+		resume            bool
+		tls               bool
+		packagePath       string
+		chaincodeType     string
 		initErrorArgs
 	}
 )
@@ -168,8 +178,8 @@ func WithSourceFlag(flags *pflag.FlagSet, name string) ChaincodeInstallOption {
 }
 
 
-// WithVersion ...
-func WithVersion(version float64) ChaincodeInstallOption {
+// WithVersion sets custom chaincode `version`, which can be either semantic or free-form one.
+func WithVersion(version string) ChaincodeInstallOption {
 	return func(args *installArgs) {
 		args.customVersion = true
 		args.version = version
@@ -181,7 +191,7 @@ func WithVersionFlag(flags *pflag.FlagSet, name string) ChaincodeInstallOption {
 	return func(args *installArgs) {
 		var err error
 
-		if args.version, err = flags.GetFloat64(name); err != nil {
+		if args.version, err = flags.GetString(name); err != nil {
 			args.initErrors = append(args.initErrors,
 				fmt.Errorf("failed to parse parameter '%s' (version): %s", name, err),
 			)
@@ -193,6 +203,204 @@ func WithVersionFlag(flags *pflag.FlagSet, name string) ChaincodeInstallOption {
 	}
 }
 
+// WithVersionFromGit determines whether chaincode version must be taken from the git tag or commit of its source.
+func WithVersionFromGit(enabled bool) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		args.versionFromGit = enabled
+	}
+}
+
+// WithVersionFromGitFlag ...
+func WithVersionFromGitFlag(flags *pflag.FlagSet, name string) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		var err error
+
+		if args.versionFromGit, err = flags.GetBool(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (version from git): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithEndorsementPolicy sets chaincode endorsement `policy`, which is either signature policy,
+// e.g. "AND('org1.member','org2.member')", or channel config policy reference, e.g. "/Channel/Application/Endorsement".
+func WithEndorsementPolicy(policy string) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		args.policy = policy
+	}
+}
+
+// WithEndorsementPolicyFlag ...
+func WithEndorsementPolicyFlag(flags *pflag.FlagSet, name string) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		var err error
+
+		if args.policy, err = flags.GetString(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (policy): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithCollectionsConfig sets `path` to the private data collections config JSON file.
+func WithCollectionsConfig(path string) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		if len(path) == 0 {
+			return
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("collections config '%s' not found: %w", path, err),
+			)
+		}
+
+		args.collectionsConfig = path
+	}
+}
+
+// WithCollectionsConfigFlag ...
+func WithCollectionsConfigFlag(flags *pflag.FlagSet, name string) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		path, err := flags.GetString(name)
+		if err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (collections config): %s", name, err),
+			)
+		}
+
+		WithCollectionsConfig(path)(args)
+	}
+}
+
+// WithInitRequired determines whether chaincode requires 'Init' function to be invoked before any other transaction.
+func WithInitRequired(required bool) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		args.initRequired = required
+	}
+}
+
+// WithInitRequiredFlag ...
+func WithInitRequiredFlag(flags *pflag.FlagSet, name string) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		var err error
+
+		if args.initRequired, err = flags.GetBool(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (init required): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithTLS secures connection between peers and chaincode service with mutual TLS,
+// using certificates issued by the organization TLS CA.
+func WithTLS(enabled bool) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		args.tls = enabled
+	}
+}
+
+// WithTLSFlag ...
+func WithTLSFlag(flags *pflag.FlagSet, name string) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		var err error
+
+		if args.tls, err = flags.GetBool(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (TLS): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithPackage makes installation use prebuilt chaincode package, e.g. produced by Chaincode.Package,
+// instead of packaging chaincode for each peer.
+func WithPackage(path string) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		if len(path) == 0 {
+			return
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("chaincode package '%s' not found: %w", path, err),
+			)
+		}
+
+		args.packagePath = path
+	}
+}
+
+// WithPackageFlag ...
+func WithPackageFlag(flags *pflag.FlagSet, name string) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		path, err := flags.GetString(name)
+		if err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (package): %s", name, err),
+			)
+		}
+
+		WithPackage(path)(args)
+	}
+}
+
+// WithChaincodeType sets chaincode package type, which is either 'external' for chaincode run as a service,
+// or 'golang', 'node', 'java' for source package built and launched by the peer itself.
+func WithChaincodeType(chaincodeType string) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		if len(chaincodeType) == 0 {
+			return
+		}
+
+		if _, ok := sourceChaincodeTypes[chaincodeType]; !ok && chaincodeType != externalChaincodeType {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("unsupported chaincode type '%s', expected one of: external, golang, node, java", chaincodeType),
+			)
+		}
+
+		args.chaincodeType = chaincodeType
+	}
+}
+
+// WithChaincodeTypeFlag ...
+func WithChaincodeTypeFlag(flags *pflag.FlagSet, name string) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		chaincodeType, err := flags.GetString(name)
+		if err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (type): %s", name, err),
+			)
+		}
+
+		WithChaincodeType(chaincodeType)(args)
+	}
+}
+
+// WithResume makes installation continue the unfinished run from its last completed step,
+// reusing the version, sequence and package IDs it has recorded.
+func WithResume(resume bool) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		args.resume = resume
+	}
+}
+
+// WithResumeFlag ...
+func WithResumeFlag(flags *pflag.FlagSet, name string) ChaincodeInstallOption {
+	return func(args *installArgs) {
+		var err error
+
+		if args.resume, err = flags.GetBool(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (resume): %s", name, err),
+			)
+		}
+	}
+}
+
 type (
 	// ChaincodeBuildOption allows passing additional arguments for building chaincodes.
 	ChaincodeBuildOption func(*buildArgs)
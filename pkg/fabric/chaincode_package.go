@@ -0,0 +1,155 @@
+package fabric
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// peerPlaceholder is replaced with peer name by the peer's external builder,
+	// so that the standalone package could be shared among peers.
+	peerPlaceholder = "{{peer}}"
+
+	// orgPlaceholder is replaced with organization name by the peer's external builder.
+	orgPlaceholder = "{{org}}"
+)
+
+// Package writes standalone chaincode lifecycle package into `writer`, which can be installed later on any peer
+// with WithPackage option. Only source, version and type installation options are relevant for packaging.
+// Returns package ID the peer would compute for it.
+func (c *Chaincode) Package(writer io.Writer, options ...ChaincodeInstallOption) (string, error) {
+	args, err := c.newInstallArgs(options...)
+	if err != nil {
+		return "", err
+	}
+
+	if args.tls {
+		return "", fmt.Errorf("TLS certificates are issued for each peer, thus can't be embedded into standalone package")
+	}
+
+	var buffer bytes.Buffer
+
+	if args.chaincodeType != externalChaincodeType {
+		err = c.packageSourceChaincodeInTarGzip(&buffer, args)
+	} else {
+		err = c.packageExternalChaincodeInTarGzip(
+			fmt.Sprintf("%s:7052", c.serviceName(orgPlaceholder, peerPlaceholder)), &buffer, args, nil,
+		)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to package chaincode: %w", err)
+	}
+
+	var packageID = computePackageID(packageLabel(c.chaincodeName, args.version), buffer.Bytes())
+
+	if _, err = writer.Write(buffer.Bytes()); err != nil {
+		return "", fmt.Errorf("failed to write chaincode package: %w", err)
+	}
+
+	return packageID, nil
+}
+
+// checkPackageBuilder ensures that external builder of the `target` peer substitutes placeholders
+// of the standalone package. Builders of the peers installed by earlier versions lack it, so that
+// the chaincode service address would remain unresolved, until the peer is upgraded with 'fabnctl install peer'.
+func (c *Chaincode) checkPackageBuilder(ctx context.Context, target peerTarget) error {
+	var name = fmt.Sprintf("%s.%s.org.builders.config", target.peer, target.org)
+
+	configMap, err := kube.Client.CoreV1().ConfigMaps(c.kubeNamespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get external builder of '%s' peer: %w", target, err)
+	}
+
+	if !strings.Contains(configMap.Data["build"], peerPlaceholder) {
+		return fmt.Errorf(
+			"external builder of '%s' peer doesn't support prebuilt packages, "+
+				"upgrade it by running 'fabnctl install peer -o %s -p %s' again",
+			target, target.org, target.peer,
+		)
+	}
+
+	return nil
+}
+
+// chaincodePackage is the prebuilt chaincode package read from file.
+type chaincodePackage struct {
+	payload  []byte
+	metadata *model.ChaincodeMetadata
+	id       string
+}
+
+// readPackage reads chaincode package from the `packagePath` file, along with its metadata
+// and package ID the peer would compute for it.
+func readPackage(packagePath string) (*chaincodePackage, error) {
+	payload, err := ioutil.ReadFile(packagePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chaincode package: %w", err)
+	}
+
+	metadata, err := packageMetadata(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s' chaincode package: %w", packagePath, err)
+	}
+
+	if _, ok := sourceChaincodeTypes[metadata.Type]; !ok && metadata.Type != externalChaincodeType {
+		return nil, fmt.Errorf("chaincode package '%s' has unsupported '%s' type", packagePath, metadata.Type)
+	}
+
+	return &chaincodePackage{
+		payload:  payload,
+		metadata: metadata,
+		id:       computePackageID(metadata.Label, payload),
+	}, nil
+}
+
+// packageMetadata decodes 'metadata.json' file of the chaincode package `payload`.
+func packageMetadata(payload []byte) (*model.ChaincodeMetadata, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress package: %w", err)
+	}
+
+	var tarReader = tar.NewReader(gzipReader)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("package is missing 'metadata.json' file")
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read package archive: %w", err)
+		}
+
+		if header.Name != "metadata.json" {
+			continue
+		}
+
+		var metadata model.ChaincodeMetadata
+
+		if err = json.NewDecoder(tarReader).Decode(&metadata); err != nil {
+			return nil, fmt.Errorf("failed to decode 'metadata.json': %w", err)
+		}
+
+		return &metadata, nil
+	}
+}
+
+// computePackageID forms package ID in the same way as peer does, that is package label and SHA-256 hash of its payload.
+func computePackageID(label string, payload []byte) string {
+	var hash = sha256.Sum256(payload)
+
+	return fmt.Sprintf("%s:%s", label, hex.EncodeToString(hash[:]))
+}
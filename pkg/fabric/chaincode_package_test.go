@@ -0,0 +1,61 @@
+package fabric
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckPackageBuilder(t *testing.T) {
+	var builder = func(peer, build string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      peer + ".org1.org.builders.config",
+				Namespace: "network",
+			},
+			Data: map[string]string{"build": build},
+		}
+	}
+
+	var original = kube.Client
+	kube.Client = fake.NewSimpleClientset(
+		builder("peer0", "sed -e 's/{{peer}}/peer0/g' -e 's/{{org}}/org1/g' connection.json"),
+		builder("peer1", "cp connection.json $BUILD_OUTPUT_DIR/connection.json"),
+	)
+	defer func() { kube.Client = original }()
+
+	var chaincode = &Chaincode{
+		chaincodeName: "assets",
+		chaincodeArgs: &chaincodeArgs{sharedArgs: &sharedArgs{kubeNamespace: "network"}},
+	}
+
+	var tests = []struct {
+		name   string
+		peer   string
+		errMsg string
+	}{
+		{name: "upgraded builder", peer: "peer0"},
+		{name: "outdated builder", peer: "peer1", errMsg: "fabnctl install peer -o org1 -p peer1"},
+		{name: "missing builder", peer: "peer2", errMsg: "failed to get external builder"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := chaincode.checkPackageBuilder(context.Background(), peerTarget{org: "org1", peer: tt.peer})
+
+			switch {
+			case len(tt.errMsg) == 0 && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case len(tt.errMsg) != 0 && err == nil:
+				t.Error("expected error, got <nil>")
+			case err != nil && !strings.Contains(err.Error(), tt.errMsg):
+				t.Errorf("expected error containing '%s', got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package fabric
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/timoth-y/fabnctl/pkg/helm"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// Rollback recommits the previously deployed chaincode definition along with its package as a new sequence,
+// and rolls chaincode helm releases on given organization peers back to the revisions running that package.
+// Rolled back chaincode must pass readiness checks before the definition gets committed.
+func (c *Chaincode) Rollback(ctx context.Context) error {
+	history, err := c.loadHistory(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(history) < 2 {
+		return fmt.Errorf("no previous deployment of chaincode '%s' on '%s' channel is recorded",
+			c.chaincodeName, c.channel,
+		)
+	}
+
+	var (
+		current  = history[len(history)-1]
+		previous = history[len(history)-2]
+	)
+
+	committed, version, sequence, err := c.checkChaincodeCommitStatus(ctx)
+	if err != nil {
+		return err
+	} else if !committed {
+		return fmt.Errorf("chaincode '%s' isn't committed on '%s' channel", c.chaincodeName, c.channel)
+	}
+
+	if sequence != current.Sequence {
+		c.logger.Warningf(
+			"Committed sequence '%d' differs from the recorded one '%d', chaincode definition was changed elsewhere",
+			sequence, current.Sequence,
+		)
+	}
+
+	sequence += 1
+
+	c.logger.Infof("Chaincode '%s' will be rolled back from version '%s' to '%s' with sequence '%d'",
+		c.chaincodeName, version, previous.Version, sequence,
+	)
+
+	var (
+		targets    = c.targets
+		packageIDs = make([]string, len(targets))
+		cliPods    = make([]string, len(targets))
+		keys       = make([]string, len(targets))
+	)
+
+	for i := range targets {
+		keys[i] = targets[i].String()
+	}
+
+	// Rolling chaincode releases back on each given organization peer concurrently:
+	var (
+		progress       = c.logger.NewProgress(keys...)
+		rollbackReport = newResultReport("chaincode rollback", keys...)
+	)
+
+	progress.Start()
+
+	errs := c.forEachParallel(ctx, len(targets), func(ctx context.Context, i int) error {
+		if packageIDs[i] = previous.Packages[keys[i]]; len(packageIDs[i]) == 0 {
+			var err = fmt.Errorf("no package of version '%s' is recorded for '%s' peer", previous.Version, keys[i])
+
+			progress.Fail(keys[i], err)
+			return err
+		}
+
+		var err error
+
+		if cliPods[i], err = c.rollbackOnPeer(ctx, targets[i], packageIDs[i], previous, progress); err != nil {
+			progress.Fail(keys[i], err)
+			return fmt.Errorf("failed to roll chaincode back on '%s' peer: %w", keys[i], err)
+		}
+
+		progress.Complete(keys[i], fmt.Sprintf("Chaincode rolled back to package: %s", packageIDs[i]))
+		rollbackReport.succeed(i, fmt.Sprintf("package %s is running", packageIDs[i]))
+
+		return nil
+	})
+
+	progress.Stop()
+	rollbackReport.collect(errs)
+
+	c.logger.NewLine()
+	rollbackReport.print(c.logger)
+
+	if err := firstError(errs); err != nil && !c.continueOnError {
+		return err
+	}
+
+	// Approving previous definition for each organization with the package of its first rolled back peer:
+	var (
+		orgs      []string
+		orgTarget = make(map[string]int)
+		succeeded []peerTarget
+
+		availableCliPod string
+	)
+
+	for i, target := range targets {
+		if _, ok := orgTarget[target.org]; !ok {
+			orgs = append(orgs, target.org)
+			orgTarget[target.org] = -1
+		}
+
+		if rollbackReport.succeeded(i) {
+			succeeded = append(succeeded, target)
+			availableCliPod = cliPods[i]
+
+			if orgTarget[target.org] < 0 {
+				orgTarget[target.org] = i
+			}
+		}
+	}
+
+	if len(succeeded) == 0 {
+		return rollbackReport.failure()
+	}
+
+	progress = c.logger.NewProgress(orgs...)
+	approveReport := newResultReport("chaincode approval", orgs...)
+
+	progress.Start()
+
+	errs = c.forEachParallel(ctx, len(orgs), func(ctx context.Context, i int) error {
+		var org = orgs[i]
+
+		if orgTarget[org] < 0 {
+			var reason = fmt.Sprintf("None of '%s' organization peers has chaincode rolled back", org)
+
+			progress.Persist(org, term.LogStreamWarning, reason)
+			approveReport.skip(i, reason)
+
+			return nil
+		}
+
+		var (
+			cliPodName = cliPods[orgTarget[org]]
+			approveCmd = func(orderer string) string {
+				return kube.FormCommand(
+					"peer", "lifecycle", "chaincode", "approveformyorg",
+					"-n", c.chaincodeName,
+					"-v", previous.Version,
+					"--sequence", stoa(sequence),
+					"--package-id", packageIDs[orgTarget[org]],
+					previous.Definition,
+					"-C", c.channel,
+					"-o", orderer,
+					"--tls", "--cafile", "$ORDERER_CA",
+				)
+			}
+		)
+
+		progress.Textf(org, "Approving previous chaincode definition")
+
+		if _, _, err := c.execWithOrdererFailover(ctx, cliPodName, approveCmd); err != nil {
+			if errors.Is(err, term.ErrRemoteCmdFailed) {
+				err = fmt.Errorf("failed to approve chaincode for '%s' organization: %w", org, err)
+			} else {
+				err = fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
+			}
+
+			progress.Fail(org, err)
+			return err
+		}
+
+		progress.Complete(org, fmt.Sprintf("Chaincode has been approved for '%s' organization", org))
+
+		return nil
+	})
+
+	progress.Stop()
+	approveReport.collect(errs)
+
+	c.logger.NewLine()
+	approveReport.print(c.logger)
+
+	if err := firstError(errs); err != nil && !c.continueOnError {
+		return err
+	}
+
+	// Verifying commit readiness and committing previous definition on the rolled back peers:
+	var (
+		commitPeers             = c.commitPeersFlags(succeeded)
+		checkCommitReadinessCmd = func(orderer string) string {
+			return kube.FormCommand(
+				"peer", "lifecycle", "chaincode", "checkcommitreadiness",
+				"-n", c.chaincodeName,
+				"-v", previous.Version,
+				"--sequence", stoa(sequence),
+				previous.Definition,
+				"-C", c.channel,
+				"-o", orderer,
+				"--tls", "--cafile", "$ORDERER_CA",
+			)
+		}
+		commitCmd = func(orderer string) string {
+			return kube.FormCommand(
+				"peer", "lifecycle", "chaincode", "commit",
+				"-n", c.chaincodeName,
+				"-v", previous.Version,
+				"--sequence", stoa(sequence),
+				previous.Definition,
+				"-C", c.channel,
+				"-o", orderer,
+				"--tls", "--cafile", "$ORDERER_CA",
+				commitPeers,
+			)
+		}
+		stderr io.Reader
+	)
+
+	if stdout, stderr, err := c.execWithOrdererFailover(ctx, availableCliPod, checkCommitReadinessCmd); err != nil {
+		if errors.Is(err, term.ErrRemoteCmdFailed) {
+			return c.logger.WrapWithStderrViewPrompt(
+				fmt.Errorf("failed to check chaincode commit readiness: %w", err),
+				stderr, true,
+			)
+		}
+
+		return fmt.Errorf("failed to execute command on '%s' pod: %w", availableCliPod, err)
+	} else if ready, notApprovedBy := checkChaincodeCommitReadiness(stdout); !ready {
+		return fmt.Errorf(
+			"chaincode isn't ready to be commited, some organizations on '%s' channel haven't approved it yet: %s",
+			c.channel, strings.Join(notApprovedBy, ", "),
+		)
+	}
+
+	if err := c.logger.Stream(func() (err error) {
+		if _, stderr, err = c.execWithOrdererFailover(ctx, availableCliPod, commitCmd); err != nil {
+			if errors.Is(err, term.ErrRemoteCmdFailed) {
+				return errors.Wrapf(err, "Failed to commit chaincode")
+			}
+
+			return fmt.Errorf("failed to execute command on '%s' pod: %w", availableCliPod, err)
+		}
+
+		return nil
+	}, "Committing previous chaincode definition on organization peers",
+		"Previous chaincode definition has been committed on all organization peers",
+	); err != nil {
+		return c.logger.WrapWithStderrViewPrompt(err, stderr, false)
+	}
+
+	// Recording rolled back deployment, so that the rollback itself could be reverted:
+	var record = previous
+
+	record.Sequence = sequence
+
+	if err = c.recordDeployment(ctx, record); err != nil {
+		return err
+	}
+
+	// 'Init' function is invoked once per version, thus it is required for the previous version once again:
+	if previous.InitRequired {
+		var initCmd = func(orderer string) string {
+			return kube.FormCommand(
+				"peer", "chaincode", "invoke",
+				"-n", c.chaincodeName,
+				"-C", c.channel,
+				"--isInit",
+				"-c", shellQuote(`{"function":"Init","Args":[]}`),
+				"--waitForEvent",
+				"-o", orderer,
+				"--tls", "--cafile", "$ORDERER_CA",
+				commitPeers,
+			)
+		}
+
+		stderr = nil
+
+		if err := c.logger.Stream(func() (err error) {
+			if _, stderr, err = c.execWithOrdererFailover(ctx, availableCliPod, initCmd); err != nil {
+				if errors.Is(err, term.ErrRemoteCmdFailed) {
+					return errors.Wrapf(err, "Failed to invoke chaincode 'Init' function")
+				}
+
+				return fmt.Errorf("failed to execute command on '%s' pod: %w", availableCliPod, err)
+			}
+
+			return nil
+		}, "Invoking chaincode 'Init' function", "Chaincode has been initialized"); err != nil {
+			return c.logger.WrapWithStderrViewPrompt(err, stderr, false)
+		}
+	}
+
+	for _, report := range []*resultReport{rollbackReport, approveReport} {
+		if err := report.failure(); err != nil {
+			return fmt.Errorf("chaincode '%s' v%s is committed, but %w", c.chaincodeName, previous.Version, err)
+		}
+	}
+
+	c.logger.Successf("Chaincode '%s' successfully rolled back to v%s!", c.chaincodeName, previous.Version)
+
+	return nil
+}
+
+// rollbackOnPeer performs rollback steps for the single organization peer `target`:
+// waits for its cli pod readiness, rolls chaincode release back to the revision running `packageID` package
+// and waits for chaincode deployment readiness, while reporting the current step to its `progress` line.
+// Returns cli pod name.
+func (c *Chaincode) rollbackOnPeer(
+	ctx context.Context,
+	target peerTarget,
+	packageID string,
+	record deploymentRecord,
+	progress *term.Progress,
+) (string, error) {
+	var key = target.String()
+
+	progress.Textf(key, "Waiting for cli pod readiness")
+
+	cliPodName, err := kube.AwaitPodReady(ctx,
+		fmt.Sprintf("fabnctl/app=cli.%s.%s.org", target.peer, target.org), c.kubeNamespace,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	// Collections config of the previous definition is referenced by the approval command:
+	if len(record.Collections) != 0 {
+		progress.Textf(key, "Sending collections config to '%s' pod", cliPodName)
+
+		if err = kube.CopyToPod(ctx, cliPodName, c.kubeNamespace,
+			bytes.NewBufferString(record.Collections), c.collectionsConfigFile(),
+		); err != nil {
+			return "", fmt.Errorf("failed to send collections config to '%s' pod: %w", cliPodName, err)
+		}
+	}
+
+	// Chaincode source package is launched by peer itself, thus it has no release to roll back:
+	if len(record.Type) != 0 && record.Type != externalChaincodeType {
+		return cliPodName, nil
+	}
+
+	var releaseName = c.releaseName(target.org, target.peer)
+
+	progress.Textf(key, "Looking up '%s' release revision", releaseName)
+
+	history, err := helm.ReleaseHistory(releaseName)
+	if err != nil {
+		return "", err
+	}
+
+	var revision int
+
+	// The latest revision is skipped, since it's the one being rolled back:
+	for i := 1; i < len(history) && revision == 0; i++ {
+		if ccid, ok := history[i].Config["ccid"].(string); ok && ccid == packageID {
+			revision = history[i].Version
+		}
+	}
+
+	if revision == 0 {
+		return "", fmt.Errorf("no revision of '%s' release is running '%s' package", releaseName, packageID)
+	}
+
+	progress.Textf(key, "Rolling '%s' release back to revision %d", releaseName, revision)
+
+	if err = helm.RollbackRelease(releaseName, revision, viper.GetDuration("helm.install_timeout")); err != nil {
+		return "", err
+	}
+
+	progress.Textf(key, "Waiting for chaincode deployment readiness")
+
+	if err = kube.AwaitDeploymentReady(ctx, c.deploymentName(target.org, target.peer), c.kubeNamespace); err != nil {
+		return "", fmt.Errorf("chaincode deployment isn't ready: %w", err)
+	}
+
+	return cliPodName, nil
+}
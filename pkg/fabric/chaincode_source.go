@@ -0,0 +1,229 @@
+package fabric
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/timoth-y/fabnctl/pkg/model"
+	"github.com/timoth-y/fabnctl/pkg/util"
+)
+
+// externalChaincodeType is the type of chaincode packages, which are run as an external service.
+// Packages of other types contain chaincode source code, which is built and launched by the peer itself.
+const externalChaincodeType = "external"
+
+// sourceChaincodeTypes defines source package types along with directories excluded from them,
+// the same way 'peer lifecycle chaincode package' does.
+var sourceChaincodeTypes = map[string][]string{
+	"golang": nil,
+	"node":   {"node_modules"},
+	"java":   {"target", "build", "out"},
+}
+
+// goSourceExtensions lists files included into golang source packages.
+var goSourceExtensions = map[string]bool{
+	".c": true, ".h": true, ".s": true, ".go": true, ".yaml": true, ".json": true,
+}
+
+// packageSourceChaincodeInTarGzip writes chaincode source package of the given type into `writer`.
+// Source files are placed into 'src' directory of 'code.tar.gz' archive, while 'META-INF' is placed into its root.
+func (c *Chaincode) packageSourceChaincodeInTarGzip(writer io.Writer, args *installArgs) error {
+	var (
+		codeBuffer bytes.Buffer
+		mdBuffer   bytes.Buffer
+
+		codeGzip = gzip.NewWriter(&codeBuffer)
+		codeTar  = tar.NewWriter(codeGzip)
+
+		packageGzip = gzip.NewWriter(writer)
+		packageTar  = tar.NewWriter(packageGzip)
+
+		sourceRoot = args.sourcePathAbs
+		metadata   = model.ChaincodeMetadata{
+			Path:  filepath.ToSlash(args.sourcePath),
+			Type:  args.chaincodeType,
+			Label: packageLabel(c.chaincodeName, args.version),
+		}
+		include func(name string) bool
+	)
+
+	defer func() {
+		if err := packageGzip.Close(); err != nil {
+			c.logger.Error(err, "failed to close package gzip writer")
+		}
+	}()
+
+	defer func() {
+		if err := packageTar.Close(); err != nil {
+			c.logger.Error(err, "failed to close package tar writer")
+		}
+	}()
+
+	// Go chaincode is packaged as a whole module, while its path is the import path of the chaincode package:
+	if args.chaincodeType == "golang" {
+		moduleRoot, importPath, err := goModule(args.sourcePathAbs)
+		if err != nil {
+			return err
+		}
+
+		sourceRoot, metadata.Path = moduleRoot, importPath
+		include = func(name string) bool {
+			return goSourceExtensions[filepath.Ext(name)] || name == "go.mod" || name == "go.sum"
+		}
+	}
+
+	if _, err := readValidIndexes(args.sourcePathAbs); err != nil {
+		return err
+	}
+
+	// 'META-INF' directory is excluded from sources, since it's placed at the archive root:
+	var (
+		metaInf  = filepath.Join(args.sourcePathAbs, "META-INF")
+		excluded = append([]string{}, sourceChaincodeTypes[args.chaincodeType]...)
+	)
+
+	if relPath, err := filepath.Rel(sourceRoot, metaInf); err == nil {
+		excluded = append(excluded, relPath)
+	}
+
+	if err := writeSourceToTar(sourceRoot, "src", excluded, include, codeTar); err != nil {
+		return err
+	}
+
+	if isDir(metaInf) {
+		if err := writeSourceToTar(metaInf, "META-INF", nil, nil, codeTar); err != nil {
+			return err
+		}
+	}
+
+	if err := codeTar.Close(); err != nil {
+		return fmt.Errorf("failed to close code tar writer: %w", err)
+	}
+
+	if err := codeGzip.Close(); err != nil {
+		return fmt.Errorf("failed to close code gzip writer: %w", err)
+	}
+
+	if err := json.NewEncoder(&mdBuffer).Encode(metadata); err != nil {
+		return fmt.Errorf("failed to encode to 'metadata.json': %w", err)
+	}
+
+	if err := util.WriteBytesToTar("metadata.json", &mdBuffer, packageTar); err != nil {
+		return fmt.Errorf("failed to write 'metadata.json' into package tar archive: %w", err)
+	}
+
+	if err := util.WriteBytesToTar("code.tar.gz", &codeBuffer, packageTar); err != nil {
+		return fmt.Errorf("failed to write 'code.tar.gz' into package tar archive: %w", err)
+	}
+
+	return nil
+}
+
+// writeSourceToTar writes files of the `root` directory into `prefix` directory of the tar archive,
+// skipping hidden and `excluded` directories, which are relative to `root`, and files not matching `include` filter if it is set.
+func writeSourceToTar(root, prefix string, excluded []string, include func(name string) bool, writer *tar.Writer) error {
+	return filepath.Walk(root, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to read chaincode source: %w", err)
+		}
+
+		relPath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if relPath == "." {
+				return nil
+			}
+
+			if strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			for _, dir := range excluded {
+				if relPath == filepath.FromSlash(dir) {
+					return filepath.SkipDir
+				}
+			}
+
+			return nil
+		}
+
+		if !info.Mode().IsRegular() || (include != nil && !include(info.Name())) {
+			return nil
+		}
+
+		payload, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read '%s' chaincode source file: %w", relPath, err)
+		}
+
+		var tarPath = path.Join(prefix, filepath.ToSlash(relPath))
+
+		if err = util.WriteBytesToTar(tarPath, bytes.NewBuffer(payload), writer); err != nil {
+			return fmt.Errorf("failed to write '%s' into code tar archive: %w", tarPath, err)
+		}
+
+		return nil
+	})
+}
+
+// isDir checks whether the directory at `dirPath` exists.
+func isDir(dirPath string) bool {
+	info, err := os.Stat(dirPath)
+
+	return err == nil && info.IsDir()
+}
+
+// goModule finds Go module containing the `dir` package.
+// Returns module root directory and import path of the package.
+func goModule(dir string) (string, string, error) {
+	for root := dir; ; root = filepath.Dir(root) {
+		file, err := os.Open(filepath.Join(root, "go.mod"))
+		if os.IsNotExist(err) {
+			if parent := filepath.Dir(root); parent == root {
+				return "", "", fmt.Errorf("go chaincode '%s' is not a part of any Go module", dir)
+			}
+
+			continue
+		} else if err != nil {
+			return "", "", fmt.Errorf("failed to read 'go.mod' file: %w", err)
+		}
+
+		var (
+			scanner    = bufio.NewScanner(file)
+			modulePath string
+		)
+
+		for scanner.Scan() {
+			if fields := strings.Fields(scanner.Text()); len(fields) == 2 && fields[0] == "module" {
+				modulePath = strings.Trim(fields[1], `"`)
+				break
+			}
+		}
+
+		_ = file.Close()
+
+		if len(modulePath) == 0 {
+			return "", "", fmt.Errorf("module path isn't declared in '%s'", filepath.Join(root, "go.mod"))
+		}
+
+		relPath, err := filepath.Rel(root, dir)
+		if err != nil {
+			return "", "", err
+		}
+
+		return root, path.Join(modulePath, filepath.ToSlash(relPath)), nil
+	}
+}
@@ -0,0 +1,239 @@
+package fabric
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const installStateKey = "state.json"
+
+// installState records steps completed by the chaincode installation run along with values collected on them,
+// so that the interrupted run could be resumed without redoing them and incrementing sequence once again.
+// It is persisted in the config map until the run is completed.
+type installState struct {
+	Version     string            `json:"version"`
+	Sequence    int               `json:"sequence"`
+	Definition  string            `json:"definition"`
+	Packages    map[string]string `json:"packages,omitempty"`
+	Approved    []string          `json:"approved,omitempty"`
+	Committed   bool              `json:"committed,omitempty"`
+	InitPending bool              `json:"initPending,omitempty"`
+
+	mutex sync.Mutex
+}
+
+// packageID returns ID of the package installed on the peer with `key` during the recorded run, if any.
+func (s *installState) packageID(key string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.Packages[key]
+}
+
+// isApproved checks whether the chaincode was approved for `org` during the recorded run.
+func (s *installState) isApproved(org string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i := range s.Approved {
+		if s.Approved[i] == org {
+			return true
+		}
+	}
+
+	return false
+}
+
+// prepareInstallState determines version and sequence of the chaincode to be installed and records them
+// into the new installation state. When resuming, they are taken from the state of the interrupted run instead.
+// Returns nil state if chaincode is already committed and further steps should be skipped.
+func (c *Chaincode) prepareInstallState(
+	ctx context.Context,
+	args *installArgs,
+	definition *chaincodeDefinition,
+	definitionFlags string,
+) (*installState, error) {
+	state, err := c.loadInstallState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if state != nil && args.resume {
+		if state.Definition != definitionFlags {
+			return nil, fmt.Errorf(
+				"chaincode definition differs from the one of the installation run being resumed: '%s' instead of '%s'",
+				definitionFlags, state.Definition,
+			)
+		}
+
+		if args.customVersion && args.version != state.Version {
+			return nil, fmt.Errorf(
+				"version '%s' differs from '%s' of the installation run being resumed",
+				args.version, state.Version,
+			)
+		}
+
+		args.version, args.sequence = state.Version, state.Sequence
+
+		c.logger.Infof("Resuming installation of chaincode '%s' with version '%s' and sequence '%d'",
+			c.chaincodeName, args.version, args.sequence,
+		)
+
+		return state, nil
+	}
+
+	if state != nil {
+		c.logger.Warningf(
+			"Unfinished installation run of chaincode '%s' with version '%s' found, it will be discarded (see --resume)",
+			c.chaincodeName, state.Version,
+		)
+	} else if args.resume {
+		c.logger.Warningf("No unfinished installation run of chaincode '%s' found, starting from scratch",
+			c.chaincodeName,
+		)
+	}
+
+	var (
+		committedBefore  bool
+		committedVersion string
+	)
+
+	if committed, ver, seq, err := c.checkChaincodeCommitStatus(ctx); err != nil {
+		return nil, err
+	} else if committed {
+		c.logger.Infof(
+			"Chaincode '%s' is already committed on '%s' channel with version '%s' and sequence '%d'",
+			c.chaincodeName, c.channel, ver, seq,
+		)
+
+		committedBefore, committedVersion = true, ver
+
+		if changes, err := c.definitionChanges(ctx, definition); err != nil {
+			return nil, err
+		} else if len(changes) != 0 {
+			c.logger.Infof("Chaincode definition will be changed: %s", strings.Join(changes, "; "))
+		}
+
+		if !args.customVersion {
+			ver = util.NextVersion(ver)
+		} else {
+			ver = args.version
+		}
+
+		seq += 1
+
+		if args.update {
+			args.version = ver
+			args.sequence = seq
+			c.logger.Infof("It will be updated to version '%s' and sequence '%d'", args.version, args.sequence)
+		} else {
+			c.logger.Infof("Further steps will be skipped")
+			return nil, nil
+		}
+	}
+
+	state = &installState{
+		Version:    args.version,
+		Sequence:   args.sequence,
+		Definition: definitionFlags,
+		Packages:   make(map[string]string),
+		// 'Init' function is invoked only once per chaincode version:
+		InitPending: args.initRequired && !(committedBefore && committedVersion == args.version),
+	}
+
+	if err = c.saveInstallState(ctx, state, nil); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// loadInstallState retrieves state of the unfinished installation run, or returns nil if there is none.
+func (c *Chaincode) loadInstallState(ctx context.Context) (*installState, error) {
+	var state = &installState{}
+
+	configMap, err := kube.Client.CoreV1().ConfigMaps(c.kubeNamespace).Get(ctx, c.installStateName(), metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to retrieve installation state of '%s' chaincode: %w", c.chaincodeName, err)
+	}
+
+	if err = json.Unmarshal([]byte(configMap.Data[installStateKey]), state); err != nil {
+		return nil, fmt.Errorf("failed to decode installation state of '%s' chaincode: %w", c.chaincodeName, err)
+	}
+
+	if state.Packages == nil {
+		state.Packages = make(map[string]string)
+	}
+
+	return state, nil
+}
+
+// saveInstallState applies `update` to the `state` and persists it. Safe for concurrent use.
+func (c *Chaincode) saveInstallState(ctx context.Context, state *installState, update func(state *installState)) error {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	if update != nil {
+		update(state)
+	}
+
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode installation state of '%s' chaincode: %w", c.chaincodeName, err)
+	}
+
+	if _, err = kube.ConfigMapAdapter(kube.Client.CoreV1().ConfigMaps(c.kubeNamespace)).CreateOrUpdate(ctx, corev1.ConfigMap{
+		Data: map[string]string{
+			installStateKey: string(payload),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.installStateName(),
+			Namespace: c.kubeNamespace,
+			Labels: map[string]string{
+				"fabnctl/cid":       "chaincode.install.state",
+				"fabnctl/chaincode": c.chaincodeName,
+				"fabnctl/channel":   c.channel,
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to persist installation state of '%s' chaincode: %w", c.chaincodeName, err)
+	}
+
+	return nil
+}
+
+// deleteInstallState removes state of the installation run once it's completed.
+func (c *Chaincode) deleteInstallState(ctx context.Context) error {
+	if err := kube.Client.CoreV1().ConfigMaps(c.kubeNamespace).Delete(ctx,
+		c.installStateName(), metav1.DeleteOptions{},
+	); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete installation state of '%s' chaincode: %w", c.chaincodeName, err)
+	}
+
+	return nil
+}
+
+// installStateName forms name of the config map storing installation state,
+// unique for chaincode and channel, e.g. 'assets.mychannel.install-state'.
+func (c *Chaincode) installStateName() string {
+	return c.configMapName("install-state")
+}
+
+// configMapName forms name of the config map with given `suffix` storing chaincode data on the channel.
+func (c *Chaincode) configMapName(suffix string) string {
+	return regexp.MustCompile(`[^a-z0-9.-]`).ReplaceAllString(
+		strings.ToLower(fmt.Sprintf("%s.%s.%s", c.chaincodeName, c.channel, suffix)), "-",
+	)
+}
@@ -0,0 +1,99 @@
+package fabric
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"path"
+
+	"github.com/timoth-y/fabnctl/pkg/certs"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// chaincodeTLS holds key pairs securing connection between peer and chaincode service with mutual TLS.
+type chaincodeTLS struct {
+	server *certs.KeyPair
+	client *certs.KeyPair
+	caCert []byte
+}
+
+// issueChaincodeTLS issues server certificate for the chaincode service of the `peer` and client certificate for the peer itself,
+// both signed by the organization TLS CA. Certificates are issued anew on each installation, thus rotated on upgrade.
+func (c *Chaincode) issueChaincodeTLS(ctx context.Context, org, peer string) (*chaincodeTLS, error) {
+	var (
+		orgHost = fmt.Sprintf("%s.org.%s", org, c.domain)
+		caDir   = path.Join("peerOrganizations", orgHost, "tlsca")
+		service = c.serviceName(org, peer)
+	)
+
+	ca, err := c.loadCA(ctx, caDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load '%s' organization TLS CA: %w", org, err)
+	}
+
+	server, err := ca.Issue(service, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		service,
+		fmt.Sprintf("%s.%s", service, c.kubeNamespace),
+		fmt.Sprintf("%s.%s.svc", service, c.kubeNamespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", service, c.kubeNamespace),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ca.Issue(fmt.Sprintf("%s.%s", peer, orgHost), []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth})
+	if err != nil {
+		return nil, err
+	}
+
+	return &chaincodeTLS{
+		server: server,
+		client: client,
+		caCert: ca.CertPEM,
+	}, nil
+}
+
+// applyTo enables mutual TLS in chaincode `connection`, embedding peer client key pair and CA certificate into it.
+func (t *chaincodeTLS) applyTo(connection *model.ChaincodeConnection) {
+	connection.TLSRequired = true
+	connection.ClientAuthRequired = true
+	connection.ClientKey = string(t.client.KeyPEM)
+	connection.ClientCert = string(t.client.CertPEM)
+	connection.RootCert = string(t.caCert)
+}
+
+// createTLSSecret creates or updates secret with chaincode service server key pair
+// and CA certificate used for verifying peer client certificate. Returns secret name.
+func (c *Chaincode) createTLSSecret(ctx context.Context, org, peer string, tls *chaincodeTLS) (string, error) {
+	var secretName = fmt.Sprintf("%s-tls", c.serviceName(org, peer))
+
+	if _, err := kube.SecretAdapter(kube.Client.CoreV1().Secrets(c.kubeNamespace)).CreateOrUpdate(ctx, corev1.Secret{
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: tls.server.KeyPEM,
+			corev1.TLSCertKey:       tls.server.CertPEM,
+			"ca.crt":                tls.caCert,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: c.kubeNamespace,
+			Labels: map[string]string{
+				"fabnctl/cid":       "chaincode.tls.secret",
+				"fabnctl/chaincode": c.chaincodeName,
+				"fabnctl/host":      fmt.Sprintf("%s.%s.org", peer, org),
+			},
+		},
+	}); err != nil {
+		return "", fmt.Errorf("failed to create %s secret: %w", secretName, err)
+	}
+
+	return secretName, nil
+}
+
+// serviceName forms name of the chaincode service of the organization `peer`, which is used as its host name.
+func (c *Chaincode) serviceName(org, peer string) string {
+	return fmt.Sprintf("%s-chaincode-%s-%s", c.chaincodeName, peer, org)
+}
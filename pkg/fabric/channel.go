@@ -6,8 +6,6 @@ import (
 	"fmt"
 	"io"
 
-	"github.com/spf13/viper"
-	"github.com/timoth-y/fabnctl/cmd/fabnctl/shared"
 	"github.com/timoth-y/fabnctl/pkg/kube"
 	"github.com/timoth-y/fabnctl/pkg/term"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,10 +18,10 @@ type Channel struct {
 
 func NewChannel(name string, options ...ChannelOption) (*Channel, error) {
 	var args = &channelArgs{
-		orgpeers: make(map[string][]string),
 		sharedArgs: &sharedArgs{
 			arch: "amd64",
 			kubeNamespace: "network",
+			parallelism: 1,
 			logger: term.NewLogger(),
 			chartsPath: "./network-config.yaml",
 		},
@@ -43,111 +41,148 @@ func NewChannel(name string, options ...ChannelOption) (*Channel, error) {
 	}, nil
 }
 
+// Install creates the channel unless it's already created and joins given organization peers to it.
 func (c *Channel) Install(ctx context.Context) error {
-	var channelExists bool
+	return c.deploy(ctx, true)
+}
 
-	for org, peers := range c.orgpeers {
-		for _, peer := range peers {
-			var (
-				peerPodName = fmt.Sprintf("%s.%s.org", peer, org)
-				cliPodName  = fmt.Sprintf("cli.%s.%s.org", peer, org)
-			)
+// Join joins given organization peers to the already created channel.
+// Unlike Install, it fails instead of creating the channel, when it's missing.
+func (c *Channel) Join(ctx context.Context) error {
+	return c.deploy(ctx, false)
+}
 
-			c.logger.Infof("Going to setup channel on '%s' peer of '%s' organization:", peer, org)
+// deploy joins given organization peers to the channel, which is created beforehand if `create` is set.
+func (c *Channel) deploy(ctx context.Context, create bool) error {
+	var (
+		targets = c.targets
+		keys    = make([]string, len(targets))
+	)
 
-			// Waiting for 'org.peer' pod readiness:
-			if ok, err := kube.WaitForPodReady(ctx,
-				&peerPodName,
-				fmt.Sprintf("fabnctl/app=%s.%s.org", peer, org), c.kubeNamespace,
-			); err != nil {
-				return err
-			} else if !ok {
-				return nil
-			}
+	if len(targets) == 0 {
+		return fmt.Errorf("no organization peers given to setup '%s' channel on", c.channelName)
+	}
 
-			// Waiting for 'org.peer.cli' pod readiness:
-			if ok, err := kube.WaitForPodReady(
-				ctx,
-				&cliPodName,
-				fmt.Sprintf("fabnctl/app=cli.%s.%s.org", peer, org),
-				c.kubeNamespace,
-			); err != nil {
-				return err
-			} else if !ok {
-				return nil
-			}
+	for i := range targets {
+		keys[i] = targets[i].String()
+	}
 
-			var (
-				joinCmd = kube.FormCommand(
-					"peer channel join",
-					"-b", fmt.Sprintf("%s.block", c.channelName),
-				)
-
-				fetchCmd = kube.FormCommand(
-					"peer channel fetch config", fmt.Sprintf("%s.block", c.channelName),
-					"-c", c.channelName,
-					"-o", fmt.Sprintf("%s.%s:443", viper.GetString("fabric.orderer_hostname_name"), shared.Domain),
-					"--tls", "--cafile", "$ORDERER_CA",
-				)
-
-				createCmd = kube.FormCommand(
-					"peer channel create",
-					"-c", c.channelName,
-					"-f", fmt.Sprintf("./channel-artifacts/%s.tx", c.channelName),
-					"-o", fmt.Sprintf("%s.%s:443", viper.GetString("fabric.orderer_hostname_name"), shared.Domain),
-					"--tls", "--cafile", "$ORDERER_CA",
-				)
+	var (
+		joinCmd = kube.FormCommand(
+			"peer channel join",
+			"-b", fmt.Sprintf("%s.block", c.channelName),
+		)
+
+		fetchCmd = func(orderer string) string {
+			return kube.FormCommand(
+				"peer channel fetch config", fmt.Sprintf("%s.block", c.channelName),
+				"-c", c.channelName,
+				"-o", orderer,
+				"--tls", "--cafile", "$ORDERER_CA",
 			)
+		}
 
-			if !channelExists {
-				// Checking whether specified channel is already created or not,
-				// by trying to fetch in genesis block:
-				if _, _, err := kube.ExecShellInPod(ctx, cliPodName, c.kubeNamespace, fetchCmd); err == nil {
-					channelExists = true
-					c.logger.Infof("Channel '%s' already created, fetched its genesis block", c.channelName)
-				} else if !errors.Is(err, term.ErrRemoteCmdFailed) {
-					return fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
-				}
-			}
+		createCmd = func(orderer string) string {
+			return kube.FormCommand(
+				"peer channel create",
+				"-c", c.channelName,
+				"-f", fmt.Sprintf("./channel-artifacts/%s.tx", c.channelName),
+				"-o", orderer,
+				"--tls", "--cafile", "$ORDERER_CA",
+			)
+		}
 
-			var stderr io.Reader
-
-			// Creating channel in case it wasn't yet:
-			if !channelExists {
-				if err := c.logger.Stream(func() (err error) {
-					if _, stderr, err = kube.ExecShellInPod(ctx, cliPodName, c.kubeNamespace, createCmd); err != nil {
-						if errors.Is(err, term.ErrRemoteCmdFailed) {
-							return fmt.Errorf("failed to create channel")
-						}
-
-						return fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
-					}
-					return nil
-				}, "Creating channel",
-					fmt.Sprintf("Channel '%s' successfully created", c.channelName),
-				); err != nil {
-					return c.logger.WrapWithStderrViewPrompt(err, stderr, false)
-				}
-			}
+		peer, org  = targets[0].peer, targets[0].org
+		cliPodName = fmt.Sprintf("cli.%s.%s.org", peer, org)
+	)
+
+	// Channel is created once, using cli pod of the first given peer:
+	if ok, err := kube.WaitForPodReady(
+		ctx,
+		&cliPodName,
+		fmt.Sprintf("fabnctl/app=cli.%s.%s.org", peer, org),
+		c.kubeNamespace,
+	); err != nil {
+		return err
+	} else if !ok {
+		return nil
+	}
 
-			// Joining peer to channel:
-			if err := c.logger.Stream(func() (err error) {
-				if _, stderr, err = kube.ExecShellInPod(ctx, cliPodName, c.kubeNamespace, joinCmd); err != nil {
-					if errors.Is(err, term.ErrRemoteCmdFailed) {
-						return fmt.Errorf("failed to join channel: %w", err)
-					}
+	// Checking whether specified channel is already created or not,
+	// by trying to fetch in genesis block:
+	if _, _, err := c.execWithOrdererFailover(ctx, cliPodName, fetchCmd); err == nil {
+		c.logger.Infof("Channel '%s' already created, fetched its genesis block", c.channelName)
+	} else if !errors.Is(err, term.ErrRemoteCmdFailed) {
+		return fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
+	} else if !create {
+		return fmt.Errorf("failed to fetch '%s' channel genesis block with '%s' pod, "+
+			"channel must be created first: %w", c.channelName, cliPodName, err)
+	} else {
+		var stderr io.Reader
 
-					return fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
+		// Creating channel in case it wasn't yet:
+		if err = c.logger.Stream(func() (err error) {
+			if _, stderr, err = c.execWithOrdererFailover(ctx, cliPodName, createCmd); err != nil {
+				if errors.Is(err, term.ErrRemoteCmdFailed) {
+					return fmt.Errorf("failed to create channel")
 				}
-				return nil
-			}, fmt.Sprintf("Joinging '%s' organization to '%s' channel", org, c.channelName),
-				fmt.Sprintf("Organization '%s' successfully joined '%s' channel", org, c.channelName),
-			); err != nil {
-				return c.logger.WrapWithStderrViewPrompt(err, stderr, false)
+
+				return fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
 			}
+			return nil
+		}, "Creating channel",
+			fmt.Sprintf("Channel '%s' successfully created", c.channelName),
+		); err != nil {
+			return c.logger.WrapWithStderrViewPrompt(err, stderr, false)
+		}
+	}
+
+	c.logger.NewLine()
+	c.logger.Infof("Going to join %d peers to '%s' channel (parallelism: %d):",
+		len(targets), c.channelName, c.parallelism,
+	)
+
+	// Joining organization peers to channel concurrently:
+	var (
+		progress = c.logger.NewProgress(keys...)
+		report   = newResultReport("channel join", keys...)
+	)
+
+	progress.Start()
+
+	errs := c.forEachParallel(ctx, len(targets), func(ctx context.Context, i int) error {
+		joined, err := c.joinPeer(ctx, targets[i], joinCmd, progress)
+		if err != nil {
+			progress.Fail(keys[i], err)
+			return fmt.Errorf("failed to join '%s' peer to '%s' channel: %w", keys[i], c.channelName, err)
+		}
+
+		if !joined {
+			var reason = fmt.Sprintf("Peer has already joined '%s' channel", c.channelName)
 
-			c.logger.NewLine()
+			progress.Persist(keys[i], term.LogStreamInfo, reason)
+			report.skip(i, reason)
+
+			return nil
+		}
+
+		progress.Complete(keys[i], fmt.Sprintf("Peer successfully joined '%s' channel", c.channelName))
+
+		return nil
+	})
+
+	progress.Stop()
+	report.collect(errs)
+
+	c.logger.NewLine()
+	report.print(c.logger)
+
+	if err := firstError(errs); err != nil {
+		if c.continueOnError {
+			return report.failure()
 		}
+
+		return err
 	}
 
 	c.logger.Successf("Channel '%s' successfully deployed!", c.channelName)
@@ -155,13 +190,70 @@ func (c *Channel) Install(ctx context.Context) error {
 	return nil
 }
 
+// joinPeer waits for the `target` peer pods readiness and joins it to the channel with `joinCmd`,
+// while reporting the current step to its `progress` line.
+// Returns false if the peer has already joined the channel, thus joining was skipped.
+func (c *Channel) joinPeer(
+	ctx context.Context,
+	target peerTarget,
+	joinCmd string,
+	progress *term.Progress,
+) (bool, error) {
+	var key = target.String()
+
+	// Waiting for 'org.peer' and 'org.peer.cli' pods readiness:
+	progress.Textf(key, "Waiting for peer pod readiness")
+
+	if _, err := kube.AwaitPodReady(ctx,
+		fmt.Sprintf("fabnctl/app=%s.%s.org", target.peer, target.org), c.kubeNamespace,
+	); err != nil {
+		return false, err
+	}
+
+	progress.Textf(key, "Waiting for cli pod readiness")
+
+	cliPodName, err := kube.AwaitPodReady(ctx,
+		fmt.Sprintf("fabnctl/app=cli.%s.%s.org", target.peer, target.org), c.kubeNamespace,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	// Checking whether peer has already joined channel:
+	progress.Textf(key, "Checking channels joined by peer")
+
+	channels, err := joinedChannels(ctx, c.kubeNamespace, cliPodName)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range channels {
+		if channels[i] == c.channelName {
+			return false, nil
+		}
+	}
+
+	// Joining peer to channel:
+	progress.Textf(key, "Joining '%s' organization to '%s' channel", target.org, c.channelName)
+
+	if _, _, err = kube.ExecShellInPod(ctx, cliPodName, c.kubeNamespace, joinCmd); err != nil {
+		if errors.Is(err, term.ErrRemoteCmdFailed) {
+			return false, fmt.Errorf("failed to join channel: %w", err)
+		}
+
+		return false, fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
+	}
+
+	return true, nil
+}
+
 func (c *Channel) SetAnchors(ctx context.Context, orgs ...string) error {
 	for _, org := range orgs {
 		var cliPodName string
 
 		c.logger.Infof("Going to setup anchor peers of '%s' organization to the channel definition:", org)
 
-		if pods, err := kube.Client.CoreV1().Pods(shared.Namespace).List(ctx, metav1.ListOptions{
+		if pods, err := kube.Client.CoreV1().Pods(c.kubeNamespace).List(ctx, metav1.ListOptions{
 			LabelSelector: fmt.Sprintf("fabnctl/cid=org-peer-cli,fabnctl/org=%s", org),
 		}); err != nil {
 			return fmt.Errorf("failed to find CLI pod for '%s' organization: %w", org, err)
@@ -171,18 +263,20 @@ func (c *Channel) SetAnchors(ctx context.Context, orgs ...string) error {
 			cliPodName = pods.Items[0].Name
 		}
 
-		var updateCmd = kube.FormCommand(
-			"peer channel update",
-			"-c", c.channelName,
-			"-f", fmt.Sprintf("./channel-artifacts/%s-anchors.tx", org),
-			"-o", fmt.Sprintf("%s.%s:443", viper.GetString("fabric.orderer_hostname_name"), shared.Domain),
-			"--tls", "--cafile", "$ORDERER_CA",
-		)
+		var updateCmd = func(orderer string) string {
+			return kube.FormCommand(
+				"peer channel update",
+				"-c", c.channelName,
+				"-f", fmt.Sprintf("./channel-artifacts/%s-anchors.tx", org),
+				"-o", orderer,
+				"--tls", "--cafile", "$ORDERER_CA",
+			)
+		}
 
 		// Update channel with org's anchor peers:
 		var stderr io.Reader
 		if err := c.logger.Stream(func() (err error) {
-			if _, stderr, err = kube.ExecShellInPod(ctx, cliPodName, shared.Namespace, updateCmd); err != nil {
+			if _, stderr, err = c.execWithOrdererFailover(ctx, cliPodName, updateCmd); err != nil {
 				if errors.Is(err, term.ErrRemoteCmdFailed){
 					return fmt.Errorf("Failed to update channel: %w", err)
 				}
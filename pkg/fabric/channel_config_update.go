@@ -0,0 +1,344 @@
+package fabric
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/timoth-y/fabnctl/pkg/channelconfig"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/term"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// channelMember defines organization joined to the channel along with its CLI pod used for signing config updates.
+type channelMember struct {
+	org        string
+	cliPodName string
+}
+
+// channelMembers finds CLI pods of the organizations which peers are joined to the channel,
+// except the `excluded` ones. Members are sorted by organization name, so that signing order is deterministic.
+func (c *Channel) channelMembers(ctx context.Context, excluded ...string) ([]channelMember, error) {
+	pods, err := kube.Client.CoreV1().Pods(c.kubeNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "fabnctl/cid=org-peer-cli",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organizations CLI pods: %w", err)
+	}
+
+	var (
+		skip    = make(map[string]bool)
+		members []channelMember
+	)
+
+	for _, org := range excluded {
+		skip[org] = true
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+
+	for _, pod := range pods.Items {
+		var org = pod.Labels["fabnctl/org"]
+
+		if skip[org] || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		channels, err := joinedChannels(ctx, c.kubeNamespace, pod.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range channels {
+			if channels[i] == c.channelName {
+				members = append(members, channelMember{org: org, cliPodName: pod.Name})
+				skip[org] = true
+				break
+			}
+		}
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].org < members[j].org
+	})
+
+	return members, nil
+}
+
+// FetchBlock retrieves serialized channel block, where `target` is either block number, 'newest', 'oldest' or 'config'.
+// Block is fetched using CLI pod of one of the given organization peers, or of any organization joined to the channel.
+func (c *Channel) FetchBlock(ctx context.Context, target string) ([]byte, error) {
+	var cliPodName string
+
+	if len(c.targets) != 0 {
+		var target = c.targets[0]
+
+		pod, err := findPeerCliPod(ctx, c.kubeNamespace, target.org, target.peer)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(pod) == 0 {
+			return nil, fmt.Errorf("failed to find CLI pod for '%s' peer of '%s' organization", target.peer, target.org)
+		}
+
+		cliPodName = pod
+	}
+
+	if len(cliPodName) == 0 {
+		members, err := c.channelMembers(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(members) == 0 {
+			return nil, fmt.Errorf("no organizations joined to '%s' channel found", c.channelName)
+		}
+
+		cliPodName = members[0].cliPodName
+	}
+
+	return c.fetchBlock(ctx, cliPodName, target)
+}
+
+// fetchBlock retrieves serialized channel block specified by `target` using the `cliPodName` pod.
+func (c *Channel) fetchBlock(ctx context.Context, cliPodName, target string) ([]byte, error) {
+	var (
+		blockFile = fmt.Sprintf("%s.%s.block", c.channelName, target)
+		fetchCmd  = func(orderer string) string {
+			return kube.FormCommand(
+				"peer channel fetch", target, blockFile,
+				"-c", c.channelName,
+				"-o", orderer,
+				"--tls", "--cafile", "$ORDERER_CA",
+				"&&",
+				"cat", blockFile,
+			)
+		}
+		payload bytes.Buffer
+	)
+
+	stdout, _, err := c.execWithOrdererFailover(ctx, cliPodName, fetchCmd)
+	if err != nil {
+		if errors.Is(err, term.ErrRemoteCmdFailed) {
+			return nil, fmt.Errorf("failed to fetch '%s' block of '%s' channel: %w", target, c.channelName, err)
+		}
+
+		return nil, fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
+	}
+
+	if _, err = io.Copy(&payload, stdout); err != nil {
+		return nil, fmt.Errorf("failed to read '%s' block of '%s' channel: %w", target, c.channelName, err)
+	}
+
+	return payload.Bytes(), nil
+}
+
+// fetchConfig retrieves the latest configuration block of the channel using the `cliPodName` pod
+// and decodes channel config from it.
+func (c *Channel) fetchConfig(ctx context.Context, cliPodName string) (*cb.Config, error) {
+	payload, err := c.fetchBlock(ctx, cliPodName, "config")
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := channelconfig.ConfigFromBlockBytes(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode '%s' channel config: %w", c.channelName, err)
+	}
+
+	return config, nil
+}
+
+// adminIdentity loads signing identity of the `org` organization admin from the crypto store.
+func (c *Channel) adminIdentity(ctx context.Context, org string) (*channelconfig.SigningIdentity, error) {
+	mspDir, cleanup, err := c.cryptoDir(ctx, path.Join(
+		"peerOrganizations", fmt.Sprintf("%s.org.%s", org, c.domain),
+		"users", fmt.Sprintf("Admin@%s.org.%s", org, c.domain),
+		"msp",
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	defer cleanup()
+
+	return channelconfig.LoadSigningIdentity(org, mspDir)
+}
+
+// updateConfig modifies channel configuration with `modify` function, computes config update transaction,
+// collects signatures of all `members` and submits it to the orderer using the first member's CLI pod.
+// Members are signing update locally with their admin identities found in crypto materials,
+// the rest of them are signing it in their CLI pods.
+// Channel modification policy is expected to be satisfied by admins of the given members.
+func (c *Channel) updateConfig(
+	ctx context.Context,
+	members []channelMember,
+	modify func(config map[string]interface{}) error,
+) error {
+	if len(members) == 0 {
+		return fmt.Errorf("no organizations joined to '%s' channel found to sign config update", c.channelName)
+	}
+
+	var (
+		submitter    = members[0]
+		updateTx     = fmt.Sprintf("%s.update.tx", c.channelName)
+		localSigners []*channelconfig.SigningIdentity
+		podSigners   []channelMember
+		update       []byte
+		envelope     []byte
+		stderr       io.Reader
+	)
+
+	// Fetching current channel config and computing update with changes applied to it:
+	if err := c.logger.Stream(func() error {
+		original, err := c.fetchConfig(ctx, submitter.cliPodName)
+		if err != nil {
+			return err
+		}
+
+		tree, err := channelconfig.ConfigToMap(original)
+		if err != nil {
+			return err
+		}
+
+		if err = modify(tree); err != nil {
+			return err
+		}
+
+		modified, err := channelconfig.ConfigFromMap(tree)
+		if err != nil {
+			return err
+		}
+
+		update, err = channelconfig.ComputeUpdate(c.channelName, original, modified)
+
+		return err
+	}, fmt.Sprintf("Computing '%s' channel config update", c.channelName),
+		fmt.Sprintf("Config update of '%s' channel computed", c.channelName),
+	); err != nil {
+		return err
+	}
+
+	// Submitter signs transaction on update, the others are signing it locally whenever possible:
+	for _, member := range members[1:] {
+		identity, err := c.adminIdentity(ctx, member.org)
+		if err != nil {
+			podSigners = append(podSigners, member)
+			continue
+		}
+
+		localSigners = append(localSigners, identity)
+		c.logger.Okf("Config update signed by '%s' organization admin", member.org)
+	}
+
+	if tx, err := channelconfig.NewUpdateEnvelope(update, localSigners...); err != nil {
+		return err
+	} else if envelope, err = proto.Marshal(tx); err != nil {
+		return fmt.Errorf("failed to encode config update envelope: %w", err)
+	}
+
+	if err := kube.CopyToPod(ctx, submitter.cliPodName, c.kubeNamespace, bytes.NewBuffer(envelope), updateTx); err != nil {
+		return fmt.Errorf("failed to copy '%s' into '%s' pod: %w", updateTx, submitter.cliPodName, err)
+	}
+
+	// Collecting signatures of the members which admin identities aren't available locally:
+	var holder = submitter.cliPodName
+
+	for _, member := range podSigners {
+		if err := c.logger.Stream(func() (err error) {
+			stderr = nil
+
+			if err = c.transferFile(ctx, holder, member.cliPodName, updateTx); err != nil {
+				return err
+			}
+
+			if _, stderr, err = kube.ExecShellInPod(ctx, member.cliPodName, c.kubeNamespace, kube.FormCommand(
+				"peer channel signconfigtx",
+				"-f", updateTx,
+			)); err != nil {
+				if errors.Is(err, term.ErrRemoteCmdFailed) {
+					return fmt.Errorf("failed to sign config update: %w", err)
+				}
+
+				return fmt.Errorf("failed to execute command on '%s' pod: %w", member.cliPodName, err)
+			}
+
+			holder = member.cliPodName
+
+			return nil
+		}, fmt.Sprintf("Signing config update by '%s' organization", member.org),
+			fmt.Sprintf("Config update signed by '%s' organization", member.org),
+		); err != nil {
+			return c.logger.WrapWithStderrViewPrompt(err, stderr, false)
+		}
+	}
+
+	// Submitting signed config update:
+	var updateCmd = func(orderer string) string {
+		return kube.FormCommand(
+			"peer channel update",
+			"-c", c.channelName,
+			"-f", updateTx,
+			"-o", orderer,
+			"--tls", "--cafile", "$ORDERER_CA",
+		)
+	}
+
+	if err := c.logger.Stream(func() (err error) {
+		stderr = nil
+
+		if err = c.transferFile(ctx, holder, submitter.cliPodName, updateTx); err != nil {
+			return err
+		}
+
+		if _, stderr, err = c.execWithOrdererFailover(ctx, submitter.cliPodName, updateCmd); err != nil {
+			if errors.Is(err, term.ErrRemoteCmdFailed) {
+				return fmt.Errorf("failed to update channel: %w", err)
+			}
+
+			return fmt.Errorf("failed to execute command on '%s' pod: %w", submitter.cliPodName, err)
+		}
+
+		return nil
+	}, fmt.Sprintf("Submitting config update by '%s' organization", submitter.org),
+		fmt.Sprintf("Channel '%s' successfully updated", c.channelName),
+	); err != nil {
+		return c.logger.WrapWithStderrViewPrompt(err, stderr, false)
+	}
+
+	return nil
+}
+
+// transferFile copies `file` from the working directory of the `srcPod` to the working directory of the `destPod`.
+func (c *Channel) transferFile(ctx context.Context, srcPod, destPod, file string) error {
+	if srcPod == destPod {
+		return nil
+	}
+
+	stdout, _, err := kube.ExecCommandInPod(ctx, srcPod, c.kubeNamespace, "cat", file)
+	if err != nil {
+		return fmt.Errorf("failed to read '%s' from '%s' pod: %w", file, srcPod, err)
+	}
+
+	var buffer bytes.Buffer
+	if _, err = io.Copy(&buffer, stdout); err != nil {
+		return fmt.Errorf("failed to read '%s' from '%s' pod: %w", file, srcPod, err)
+	}
+
+	if err = kube.CopyToPod(ctx, destPod, c.kubeNamespace, &buffer, file); err != nil {
+		return fmt.Errorf("failed to copy '%s' into '%s' pod: %w", file, destPod, err)
+	}
+
+	return nil
+}
@@ -11,7 +11,7 @@ type (
 	ChannelOption func(*channelArgs)
 
 	channelArgs struct {
-		orgpeers      map[string][]string
+		targets       []peerTarget
 		initErrors    []error
 		*sharedArgs
 	}
@@ -20,7 +20,7 @@ type (
 // WithChannelPeers ...
 func WithChannelPeers(org string, peers ...string) ChannelOption {
 	return func(args *channelArgs) {
-		args.orgpeers[org] = append(args.orgpeers[org], peers...)
+		args.targets = appendPeerTargets(args.targets, org, peers...)
 
 		if len(peers) == 0 {
 			args.initErrors = append(args.initErrors,
@@ -55,8 +55,9 @@ func WithChannelPeersFlag(flags *pflag.FlagSet, orgsFlag, peersFlag string) Chan
 				args.initErrors = append(args.initErrors,
 					fmt.Errorf("some passed organizations missing corresponding peer parameter: %s", org),
 				)
+				continue
 			}
-			args.orgpeers[org] = strings.Split(peers[i], ",")
+			args.targets = appendPeerTargets(args.targets, org, strings.Split(peers[i], ",")...)
 		}
 	}
 }
@@ -0,0 +1,462 @@
+package fabric
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/term"
+	"sigs.k8s.io/yaml"
+)
+
+// mspNodeOUs describes 'NodeOUs' section of the MSP 'config.yaml' file generated by cryptogen.
+type mspNodeOUs struct {
+	NodeOUs struct {
+		Enable              bool          `json:"Enable"`
+		ClientOUIdentifier  *ouIdentifier `json:"ClientOUIdentifier"`
+		PeerOUIdentifier    *ouIdentifier `json:"PeerOUIdentifier"`
+		AdminOUIdentifier   *ouIdentifier `json:"AdminOUIdentifier"`
+		OrdererOUIdentifier *ouIdentifier `json:"OrdererOUIdentifier"`
+	} `json:"NodeOUs"`
+}
+
+type ouIdentifier struct {
+	Certificate                  string `json:"Certificate"`
+	OrganizationalUnitIdentifier string `json:"OrganizationalUnitIdentifier"`
+}
+
+// AddOrganization adds MSP definition of the `org` organization to the channel configuration,
+// so that its peers would be able to join the channel afterwards.
+// MSP is formed from the crypto store materials and registered in the application group under `orgName`.
+// Config update is signed by every organization already joined to the channel and submitted to the orderer.
+func (c *Channel) AddOrganization(ctx context.Context, org, orgName string) error {
+	if len(orgName) == 0 {
+		orgName = org
+	}
+
+	c.logger.Infof("Going to add '%s' organization to '%s' channel definition:", org, c.channelName)
+
+	mspDir, cleanup, err := c.cryptoDir(ctx, path.Join(
+		"peerOrganizations", fmt.Sprintf("%s.org.%s", org, c.domain),
+		"msp",
+	))
+	if err != nil {
+		return err
+	}
+
+	group, err := orgConfigGroup(org, mspDir)
+	cleanup()
+	if err != nil {
+		return err
+	}
+
+	members, err := c.channelMembers(ctx, org)
+	if err != nil {
+		return err
+	}
+
+	if err = c.updateConfig(ctx, members, func(config map[string]interface{}) error {
+		application, err := configGroup(config, "channel_group", "Application")
+		if err != nil {
+			return err
+		}
+
+		orgs, _ := application["groups"].(map[string]interface{})
+		if orgs == nil {
+			orgs = make(map[string]interface{})
+			application["groups"] = orgs
+		}
+
+		if _, exists := orgs[orgName]; exists {
+			return fmt.Errorf("organization '%s' is already a member of '%s' channel", orgName, c.channelName)
+		}
+
+		orgs[orgName] = group
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	c.logger.Successf("Organization '%s' successfully added to '%s' channel!", org, c.channelName)
+
+	return nil
+}
+
+// RemoveOrganization removes MSP definition of the `org` organization registered under `orgName`
+// from the channel configuration, thus revoking its channel membership.
+// Config update is signed by every other organization joined to the channel and submitted to the orderer.
+// Committed chaincodes, which endorsement policies still reference the organization, are reported with warnings.
+func (c *Channel) RemoveOrganization(ctx context.Context, org, orgName string) error {
+	if len(orgName) == 0 {
+		orgName = org
+	}
+
+	c.logger.Infof("Going to remove '%s' organization from '%s' channel definition:", org, c.channelName)
+
+	members, err := c.channelMembers(ctx, org)
+	if err != nil {
+		return err
+	}
+
+	if len(members) != 0 {
+		references, err := c.endorsementReferences(ctx, members[0].cliPodName, org)
+		if err != nil {
+			return err
+		}
+
+		for _, reference := range references {
+			if len(reference.policyReference) != 0 {
+				c.logger.Warningf(
+					"Endorsement policy of '%s' chaincode refers to '%s' channel policy, "+
+						"so it cannot be determined whether it references '%s' organization",
+					reference.chaincode, reference.policyReference, org,
+				)
+				continue
+			}
+
+			c.logger.Warningf(
+				"Endorsement policy of '%s' chaincode still references '%s' organization, "+
+					"its definition must be updated to keep transactions endorsable", reference.chaincode, org,
+			)
+		}
+	}
+
+	if err = c.updateConfig(ctx, members, func(config map[string]interface{}) error {
+		application, err := configGroup(config, "channel_group", "Application")
+		if err != nil {
+			return err
+		}
+
+		orgs, _ := application["groups"].(map[string]interface{})
+		if _, exists := orgs[orgName]; !exists {
+			return fmt.Errorf("organization '%s' isn't a member of '%s' channel", orgName, c.channelName)
+		}
+
+		delete(orgs, orgName)
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	c.logger.Successf("Organization '%s' successfully removed from '%s' channel!", org, c.channelName)
+
+	return nil
+}
+
+// endorsementReference describes committed chaincode, which endorsement policy references the organization.
+// Policies referring to channel config policies can't be resolved to organizations,
+// so such chaincodes are described by their `policyReference` instead.
+type endorsementReference struct {
+	chaincode       string
+	policyReference string
+}
+
+// endorsementReferences finds chaincodes committed on the channel,
+// which signature endorsement policies explicitly reference `mspID` organization.
+func (c *Channel) endorsementReferences(ctx context.Context, cliPodName, mspID string) ([]endorsementReference, error) {
+	chaincodes, err := committedChaincodes(ctx, c.kubeNamespace, cliPodName, c.channelName)
+	if err != nil {
+		return nil, err
+	}
+
+	var references []endorsementReference
+
+	for _, chaincode := range chaincodes {
+		var definition struct {
+			ValidationParameter []byte `json:"validation_parameter"`
+		}
+
+		stdout, _, err := kube.ExecCommandInPod(ctx, cliPodName, c.kubeNamespace,
+			"peer", "lifecycle", "chaincode", "querycommitted",
+			"-C", c.channelName,
+			"-n", chaincode.Name,
+			"-O", "json",
+		)
+		if err != nil {
+			if errors.Is(err, term.ErrRemoteCmdFailed) {
+				return nil, fmt.Errorf("failed to query '%s' chaincode definition: %w", chaincode.Name, err)
+			}
+
+			return nil, fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
+		}
+
+		if err = json.NewDecoder(stdout).Decode(&definition); err != nil {
+			return nil, fmt.Errorf("failed to decode '%s' chaincode definition: %w", chaincode.Name, err)
+		}
+
+		reference, err := policyReferencesMSP(definition.ValidationParameter, mspID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode '%s' chaincode endorsement policy: %w", chaincode.Name, err)
+		}
+
+		if reference != nil {
+			reference.chaincode = chaincode.Name
+			references = append(references, *reference)
+		}
+	}
+
+	return references, nil
+}
+
+// policyReferencesMSP decodes serialized application `policy` and checks whether its MSP role principals
+// reference `mspID` organization. Returns <nil> reference if they don't.
+func policyReferencesMSP(policy []byte, mspID string) (*endorsementReference, error) {
+	var appPolicy = &pb.ApplicationPolicy{}
+
+	if err := proto.Unmarshal(policy, appPolicy); err != nil {
+		return nil, err
+	}
+
+	switch p := appPolicy.Type.(type) {
+	case *pb.ApplicationPolicy_ChannelConfigPolicyReference:
+		return &endorsementReference{policyReference: p.ChannelConfigPolicyReference}, nil
+	case *pb.ApplicationPolicy_SignaturePolicy:
+		for _, identity := range p.SignaturePolicy.GetIdentities() {
+			if identity.PrincipalClassification != msp.MSPPrincipal_ROLE {
+				continue
+			}
+
+			var role = &msp.MSPRole{}
+			if err := proto.Unmarshal(identity.Principal, role); err != nil {
+				return nil, fmt.Errorf("failed to decode MSP role principal: %w", err)
+			}
+
+			if role.MspIdentifier == mspID {
+				return &endorsementReference{}, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// configGroup navigates channel config JSON through the nested groups by their `keys`,
+// where the first key refers to the root group of the config.
+func configGroup(config map[string]interface{}, keys ...string) (map[string]interface{}, error) {
+	var group = config
+
+	for i, key := range keys {
+		var groups = group
+
+		if i != 0 {
+			groups, _ = group["groups"].(map[string]interface{})
+		}
+
+		next, ok := groups[key].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("channel config is missing '%s' group", key)
+		}
+
+		group = next
+	}
+
+	return group, nil
+}
+
+// orgConfigGroup forms channel config group of the `mspID` organization in configtxlator JSON format,
+// using MSP certificates located in `mspDir`. Policies match those declared in 'configtx.yaml' by the artifacts chart.
+func orgConfigGroup(mspID, mspDir string) (map[string]interface{}, error) {
+	if _, err := os.Stat(mspDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf(
+			"MSP of '%s' organization not found in '%s' directory, generate its crypto materials first", mspID, mspDir,
+		)
+	}
+
+	rootCerts, err := readMSPCerts(mspDir, "cacerts")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rootCerts) == 0 {
+		return nil, fmt.Errorf("MSP of '%s' organization has no CA certificates in '%s' directory", mspID, mspDir)
+	}
+
+	tlsRootCerts, err := readMSPCerts(mspDir, "tlscacerts")
+	if err != nil {
+		return nil, err
+	}
+
+	admins, err := readMSPCerts(mspDir, "admincerts")
+	if err != nil {
+		return nil, err
+	}
+
+	var mspConfig = map[string]interface{}{
+		"name":                            mspID,
+		"root_certs":                      rootCerts,
+		"intermediate_certs":              []string{},
+		"admins":                          admins,
+		"revocation_list":                 []string{},
+		"tls_root_certs":                  tlsRootCerts,
+		"tls_intermediate_certs":          []string{},
+		"organizational_unit_identifiers": []interface{}{},
+		"crypto_config": map[string]interface{}{
+			"signature_hash_family":             "SHA2",
+			"identity_identifier_hash_function": "SHA256",
+		},
+	}
+
+	if nodeOUs, err := readMSPNodeOUs(mspDir); err != nil {
+		return nil, err
+	} else if nodeOUs != nil {
+		mspConfig["fabric_node_ous"] = nodeOUs
+	}
+
+	var policy = func(roles ...string) map[string]interface{} {
+		var (
+			identities []interface{}
+			rules      []interface{}
+		)
+
+		for i, role := range roles {
+			identities = append(identities, map[string]interface{}{
+				"principal": map[string]interface{}{
+					"msp_identifier": mspID,
+					"role":           role,
+				},
+				"principal_classification": "ROLE",
+			})
+
+			rules = append(rules, map[string]interface{}{"signed_by": i})
+		}
+
+		return map[string]interface{}{
+			"mod_policy": "Admins",
+			"policy": map[string]interface{}{
+				"type": 1,
+				"value": map[string]interface{}{
+					"identities": identities,
+					"rule": map[string]interface{}{
+						"n_out_of": map[string]interface{}{
+							"n":     1,
+							"rules": rules,
+						},
+					},
+					"version": 0,
+				},
+			},
+			"version": "0",
+		}
+	}
+
+	return map[string]interface{}{
+		"groups":     map[string]interface{}{},
+		"mod_policy": "Admins",
+		"policies": map[string]interface{}{
+			"Readers":     policy("ADMIN", "PEER", "CLIENT", "MEMBER"),
+			"Writers":     policy("ADMIN", "CLIENT", "MEMBER"),
+			"Admins":      policy("ADMIN", "MEMBER"),
+			"Endorsement": policy("PEER", "MEMBER"),
+		},
+		"values": map[string]interface{}{
+			"MSP": map[string]interface{}{
+				"mod_policy": "Admins",
+				"value": map[string]interface{}{
+					"config": mspConfig,
+					"type":   0,
+				},
+				"version": "0",
+			},
+		},
+		"version": "0",
+	}, nil
+}
+
+// readMSPCerts reads certificates from the `subDir` of the `mspDir` encoded into base64.
+// Returns empty list when such directory doesn't exist.
+func readMSPCerts(mspDir, subDir string) ([]string, error) {
+	var certs = []string{}
+
+	files, err := ioutil.ReadDir(path.Join(mspDir, subDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return certs, nil
+		}
+
+		return nil, fmt.Errorf("failed to read '%s' MSP directory: %w", subDir, err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		payload, err := ioutil.ReadFile(path.Join(mspDir, subDir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificate from path: %s: %w", file.Name(), err)
+		}
+
+		certs = append(certs, base64.StdEncoding.EncodeToString(payload))
+	}
+
+	return certs, nil
+}
+
+// readMSPNodeOUs reads 'config.yaml' of the `mspDir` and forms 'fabric_node_ous' MSP config value.
+// Returns <nil> if Node OUs aren't enabled.
+func readMSPNodeOUs(mspDir string) (map[string]interface{}, error) {
+	var (
+		configPath = path.Join(mspDir, "config.yaml")
+		config     mspNodeOUs
+	)
+
+	payload, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read MSP config from path: %s: %w", configPath, err)
+	}
+
+	if err = yaml.Unmarshal(payload, &config); err != nil {
+		return nil, fmt.Errorf("failed to decode MSP config from path: %s: %w", configPath, err)
+	}
+
+	if !config.NodeOUs.Enable {
+		return nil, nil
+	}
+
+	var nodeOUs = map[string]interface{}{
+		"enable": true,
+	}
+
+	for key, identifier := range map[string]*ouIdentifier{
+		"client_ou_identifier":  config.NodeOUs.ClientOUIdentifier,
+		"peer_ou_identifier":    config.NodeOUs.PeerOUIdentifier,
+		"admin_ou_identifier":   config.NodeOUs.AdminOUIdentifier,
+		"orderer_ou_identifier": config.NodeOUs.OrdererOUIdentifier,
+	} {
+		if identifier == nil {
+			continue
+		}
+
+		var value = map[string]interface{}{
+			"organizational_unit_identifier": identifier.OrganizationalUnitIdentifier,
+		}
+
+		if len(identifier.Certificate) != 0 {
+			cert, err := ioutil.ReadFile(path.Join(mspDir, identifier.Certificate))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read Node OU certificate from path: %s: %w", identifier.Certificate, err)
+			}
+
+			value["certificate"] = base64.StdEncoding.EncodeToString(cert)
+		}
+
+		nodeOUs[key] = value
+	}
+
+	return nodeOUs, nil
+}
@@ -0,0 +1,100 @@
+package fabric
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+)
+
+func TestPolicyReferencesMSP(t *testing.T) {
+	var longMSPID = strings.Repeat("org", 50)
+
+	var tests = []struct {
+		name            string
+		policy          *pb.ApplicationPolicy
+		referenced      bool
+		policyReference string
+	}{
+		{
+			name: "referenced by signature policy",
+			policy: signaturePolicy(nOutOf(1, signedBy(0), signedBy(1)),
+				rolePrincipal(t, "org2", msp.MSPRole_PEER),
+				rolePrincipal(t, "org1", msp.MSPRole_MEMBER),
+			),
+			referenced: true,
+		},
+		{
+			name: "not referenced by signature policy",
+			policy: signaturePolicy(signedBy(0),
+				rolePrincipal(t, "org10", msp.MSPRole_MEMBER),
+			),
+		},
+		{
+			name: "MSP ID longer than 127 bytes",
+			policy: signaturePolicy(signedBy(0),
+				rolePrincipal(t, longMSPID, msp.MSPRole_MEMBER),
+			),
+		},
+		{
+			name: "non-role principal",
+			policy: signaturePolicy(signedBy(0), &msp.MSPPrincipal{
+				PrincipalClassification: msp.MSPPrincipal_IDENTITY,
+				Principal:               append([]byte{0x0a, 4}, "org1"...),
+			}),
+		},
+		{
+			name: "channel config policy reference",
+			policy: &pb.ApplicationPolicy{Type: &pb.ApplicationPolicy_ChannelConfigPolicyReference{
+				ChannelConfigPolicyReference: "/Channel/Application/Endorsement",
+			}},
+			referenced:      true,
+			policyReference: "/Channel/Application/Endorsement",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := proto.Marshal(tt.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reference, err := policyReferencesMSP(payload, "org1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if (reference != nil) != tt.referenced {
+				t.Fatalf("expected referenced = %t, got %+v", tt.referenced, reference)
+			}
+
+			if reference != nil && reference.policyReference != tt.policyReference {
+				t.Errorf("expected '%s' policy reference, got '%s'", tt.policyReference, reference.policyReference)
+			}
+		})
+	}
+
+	// Long MSP IDs must be matched as well, since their length prefix takes more than one byte:
+	payload, err := proto.Marshal(signaturePolicy(signedBy(0), rolePrincipal(t, longMSPID, msp.MSPRole_ADMIN)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reference, err := policyReferencesMSP(payload, longMSPID); err != nil || reference == nil {
+		t.Errorf("expected long MSP ID to be referenced, got %+v (err: %v)", reference, err)
+	}
+
+	if _, err = policyReferencesMSP([]byte("not a policy"), "org1"); err == nil {
+		t.Error("expected error for malformed policy, got <nil>")
+	}
+}
+
+func signaturePolicy(rule *cb.SignaturePolicy, identities ...*msp.MSPPrincipal) *pb.ApplicationPolicy {
+	return &pb.ApplicationPolicy{Type: &pb.ApplicationPolicy_SignaturePolicy{
+		SignaturePolicy: &cb.SignaturePolicyEnvelope{Rule: rule, Identities: identities},
+	}}
+}
@@ -0,0 +1,125 @@
+package fabric
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/timoth-y/fabnctl/pkg/certs"
+	"github.com/timoth-y/fabnctl/pkg/cryptostore"
+)
+
+// crypto returns store of the network crypto material, which is constructed on the first call.
+func (a *sharedArgs) crypto() (cryptostore.Store, error) {
+	a.cryptoStoreMu.Lock()
+	defer a.cryptoStoreMu.Unlock()
+
+	if a.cryptoStore != nil {
+		return a.cryptoStore, nil
+	}
+
+	store, err := cryptostore.New(a.cryptoStoreKind, a.domain, a.kubeNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	a.cryptoStore = store
+
+	return store, nil
+}
+
+// readCrypto reads file with given `name` from the crypto store.
+func (a *sharedArgs) readCrypto(ctx context.Context, name string) ([]byte, error) {
+	store, err := a.crypto()
+	if err != nil {
+		return nil, err
+	}
+
+	return store.Read(ctx, name)
+}
+
+// writeCrypto writes given `files` keyed by their names into the crypto store.
+func (a *sharedArgs) writeCrypto(ctx context.Context, files map[string][]byte) error {
+	store, err := a.crypto()
+	if err != nil {
+		return err
+	}
+
+	return cryptostore.WriteFiles(ctx, store, files)
+}
+
+// loadCA loads certificate authority from the `dir` directory of the crypto store,
+// which contains '*-cert.pem' certificate and '*_sk' private key, as the 'ca' and 'tlsca' ones generated by cryptogen.
+func (a *sharedArgs) loadCA(ctx context.Context, dir string) (*certs.CA, error) {
+	store, err := a.crypto()
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := store.List(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var certPEM, keyPEM []byte
+
+	for _, name := range names {
+		if path.Dir(name) != path.Clean(dir) {
+			continue
+		}
+
+		switch {
+		case strings.HasSuffix(name, "-cert.pem"):
+			certPEM, err = store.Read(ctx, name)
+		case strings.HasSuffix(name, "_sk"):
+			keyPEM, err = store.Read(ctx, name)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s' CA file: %w", name, err)
+		}
+	}
+
+	if certPEM == nil {
+		return nil, fmt.Errorf("no CA certificate found in '%s' directory", dir)
+	}
+
+	if keyPEM == nil {
+		return nil, fmt.Errorf("no CA private key found in '%s' directory", dir)
+	}
+
+	return certs.ParseCA(certPEM, keyPEM)
+}
+
+// cryptoDir provides local directory with the content of the `dir` directory of the crypto store,
+// for the consumers expecting crypto material on the file system, e.g. MSP loaders.
+// Local store directory is used as is, while the rest are exported into temporary directory removed with returned func.
+func (a *sharedArgs) cryptoDir(ctx context.Context, dir string) (string, func(), error) {
+	store, err := a.crypto()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if local, ok := store.(*cryptostore.LocalStore); ok {
+		return path.Join(local.Dir(), dir), func() {}, nil
+	}
+
+	tmpDir, err := ioutil.TempDir("", "fabnctl-crypto-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	var cleanup = func() {
+		_ = os.RemoveAll(tmpDir)
+	}
+
+	if err = cryptostore.Export(ctx, store, dir, tmpDir); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	return path.Join(tmpDir, dir), cleanup, nil
+}
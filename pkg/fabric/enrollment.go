@@ -0,0 +1,331 @@
+package fabric
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/timoth-y/fabnctl/pkg/ca"
+	"github.com/timoth-y/fabnctl/pkg/certs"
+	"github.com/timoth-y/fabnctl/pkg/cryptostore"
+	"github.com/timoth-y/fabnctl/pkg/helm"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// caPort is the port Fabric CA server deployed along with network nodes listens on.
+	caPort = 7054
+
+	// helmReleaseAnnotation is set by Helm on the resources it manages with the name of their release.
+	helmReleaseAnnotation = "meta.helm.sh/release-name"
+)
+
+// orgCA describes Fabric CA server deployed for the organization, which serves both its enrollment CA
+// and TLS CA, which are backed by the same keys as the ones generated by cryptogen.
+type orgCA struct {
+	selector   string
+	deployment string
+	caName     string
+	tlsCAName  string
+	// mspDir is the crypto store directory of the organization MSP, which channel artifacts are generated from.
+	mspDir string
+}
+
+// caEnrollment describes identity, which is registered and enrolled against the organization CA,
+// and directory of the crypto store its crypto material is written into following cryptogen layout.
+type caEnrollment struct {
+	name  string
+	kind  string
+	dir   string
+	tls   bool
+	hosts []string
+	// mount is the directory of the pod, which is backed by the `dir` directory of the artifacts volume.
+	mount artifactsMount
+}
+
+// artifactsMount describes directory of the pod matching `selector`, which is backed by the artifacts volume.
+type artifactsMount struct {
+	selector string
+	dir      string
+}
+
+// enrollIdentities registers and enrolls given `identities` against the Fabric CA `orgCA`,
+// writing enrolled crypto material into the crypto store and into the artifacts volume mounted by the nodes,
+// so that it replaces the cryptogen generated one. Identities which are already registered are re-enrolled
+// with the reset secret. Nodes must be restarted afterwards to pick up enrolled material.
+func (a *sharedArgs) enrollIdentities(ctx context.Context, org orgCA, identities ...caEnrollment) error {
+	store, err := a.crypto()
+	if err != nil {
+		return err
+	}
+
+	url, stop, err := a.forwardCA(ctx, org)
+	if err != nil {
+		return err
+	}
+
+	defer stop()
+
+	credentials, err := a.registrarCredentials(ctx, org)
+	if err != nil {
+		return err
+	}
+
+	var (
+		clients   = map[bool]*ca.Client{false: ca.NewClient(url, org.caName), true: ca.NewClient(url, org.tlsCAName)}
+		registrar = make(map[bool]*ca.Identity)
+	)
+
+	for tls, client := range clients {
+		if registrar[tls], err = enrollRegistrar(ctx, client, credentials); err != nil {
+			return err
+		}
+
+		if err = a.verifyMSPTrust(ctx, store, org, tls, registrar[tls].CAChainPEM); err != nil {
+			return err
+		}
+	}
+
+	for _, enrollment := range identities {
+		var (
+			client       = clients[enrollment.tls]
+			registration = ca.Registration{
+				Name:   enrollment.name,
+				Type:   enrollment.kind,
+				Secret: randomSecret(),
+			}
+			profile string
+		)
+
+		if _, err = client.Register(ctx, registrar[enrollment.tls], registration); errors.Is(err, ca.ErrAlreadyRegistered) {
+			err = client.ModifyIdentity(ctx, registrar[enrollment.tls], ca.Registration{
+				Name:   registration.Name,
+				Secret: registration.Secret,
+			})
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if enrollment.tls {
+			profile = "tls"
+		}
+
+		identity, err := client.Enroll(ctx, ca.EnrollmentRequest{
+			Name:    registration.Name,
+			Secret:  registration.Secret,
+			Profile: profile,
+			Hosts:   enrollment.hosts,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = a.writeEnrollment(ctx, store, enrollment, identity); err != nil {
+			return err
+		}
+
+		cert, err := certs.ParseCertificate(identity.CertPEM)
+		if err != nil {
+			return err
+		}
+
+		var kind = "enrollment"
+		if enrollment.tls {
+			kind = "TLS"
+		}
+
+		a.logger.Okf("Identity '%s' enrolled with %s certificate valid until %s (serial %x)",
+			enrollment.name, kind, cert.NotAfter.Format("2006-01-02"), cert.SerialNumber,
+		)
+	}
+
+	return nil
+}
+
+// forwardCA makes Fabric CA server of the organization reachable from the local device.
+// Returns its URL and the function, which stops port forwarding.
+func (a *sharedArgs) forwardCA(ctx context.Context, org orgCA) (string, func(), error) {
+	pod, err := kube.FindPod(ctx, a.kubeNamespace, org.selector)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if pod == nil {
+		return "", nil, fmt.Errorf("Fabric CA pod matching '%s' selector isn't found", org.selector)
+	}
+
+	port, stop, err := kube.PortForward(ctx, pod.Name, a.kubeNamespace, caPort)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("http://localhost:%d", port), stop, nil
+}
+
+// registrarCredentials resolves credentials of the bootstrap identity of the `org` CA
+// from 'ca.admin' values of the Helm release, which has deployed it.
+func (a *sharedArgs) registrarCredentials(ctx context.Context, org orgCA) (ca.EnrollmentRequest, error) {
+	deployment, err := kube.Client.AppsV1().Deployments(a.kubeNamespace).Get(ctx, org.deployment, metav1.GetOptions{})
+	if err != nil {
+		return ca.EnrollmentRequest{}, fmt.Errorf("failed to get '%s' CA deployment: %w", org.deployment, err)
+	}
+
+	var release = deployment.Annotations[helmReleaseAnnotation]
+	if len(release) == 0 {
+		return ca.EnrollmentRequest{}, fmt.Errorf("CA deployment '%s' isn't managed by Helm", org.deployment)
+	}
+
+	values, err := helm.Client.GetReleaseValues(release, true)
+	if err != nil {
+		return ca.EnrollmentRequest{}, fmt.Errorf("failed to get values of '%s' release: %w", release, err)
+	}
+
+	name, _, _ := unstructured.NestedString(values, "ca", "admin", "name")
+	secret, _, _ := unstructured.NestedString(values, "ca", "admin", "secret")
+
+	if len(name) == 0 || len(secret) == 0 {
+		return ca.EnrollmentRequest{}, fmt.Errorf("release '%s' doesn't define 'ca.admin' credentials", release)
+	}
+
+	return ca.EnrollmentRequest{Name: name, Secret: secret}, nil
+}
+
+// enrollRegistrar enrolls bootstrap identity of the CA with given `credentials`,
+// which is used for registering other identities.
+func enrollRegistrar(ctx context.Context, client *ca.Client, credentials ca.EnrollmentRequest) (*ca.Identity, error) {
+	registrar, err := client.Enroll(ctx, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enroll CA registrar: %w", err)
+	}
+
+	return registrar, nil
+}
+
+// peerOrgCA describes Fabric CA deployed along with peers of the `org` organization.
+func peerOrgCA(org string) orgCA {
+	var caHost = strings.ReplaceAll(fmt.Sprintf("%s.org", org), ".", "-")
+
+	return orgCA{
+		selector:   fmt.Sprintf("fabnctl/app=ca.%s.org", org),
+		deployment: fmt.Sprintf("ca.%s.org", org),
+		caName:     fmt.Sprintf("ca-%s", caHost),
+		tlsCAName:  fmt.Sprintf("tlsca-%s", caHost),
+	}
+}
+
+// verifyMSPTrust ensures that root certificate of the CA chain `chainPEM` is listed in the MSP of the `org`
+// among CA certificates, or TLS CA ones when `tls` is set. Otherwise enrolled certificates wouldn't be valid
+// for the channel MSP, until channel artifacts are regenerated.
+func (a *sharedArgs) verifyMSPTrust(
+	ctx context.Context,
+	store cryptostore.Store,
+	org orgCA,
+	tls bool,
+	chainPEM []byte,
+) error {
+	var (
+		caName   = org.caName
+		certsDir = path.Join(org.mspDir, "cacerts")
+		chain    []*x509.Certificate
+	)
+
+	if tls {
+		caName, certsDir = org.tlsCAName, path.Join(org.mspDir, "tlscacerts")
+	}
+
+	for block, rest := pem.Decode(chainPEM); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse certificate chain of '%s' CA: %w", caName, err)
+		}
+
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return fmt.Errorf("'%s' CA has returned empty certificate chain", caName)
+	}
+
+	names, err := store.List(ctx, certsDir)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		payload, err := store.Read(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		if cert, err := certs.ParseCertificate(payload); err == nil && cert.Equal(chain[len(chain)-1]) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"root certificate of '%s' CA isn't found in '%s' directory of the organization MSP, "+
+			"thus enrolled identities won't be valid on the channel: CA must be backed by crypto material "+
+			"the channel artifacts are generated from, regenerate them with 'fabnctl gen artifacts' otherwise",
+		caName, certsDir,
+	)
+}
+
+// writeEnrollment writes enrolled `identity` into the directory of the enrollment `e` the same way cryptogen does:
+// either into 'msp' directory for enrollment certificates or into 'tls' directory for TLS ones.
+// Files are replaced both in the crypto store and in the artifacts volume mounted by the pod.
+func (a *sharedArgs) writeEnrollment(
+	ctx context.Context,
+	store cryptostore.Store,
+	e caEnrollment,
+	identity *ca.Identity,
+) error {
+	var files map[string][]byte
+
+	if e.tls {
+		files = map[string][]byte{
+			path.Join("tls", "server.crt"): identity.CertPEM,
+			path.Join("tls", "server.key"): identity.KeyPEM,
+			path.Join("tls", "ca.crt"):     identity.CAChainPEM,
+		}
+	} else {
+		files = map[string][]byte{
+			path.Join("msp", "signcerts", fmt.Sprintf("%s-cert.pem", e.name)): identity.CertPEM,
+			path.Join("msp", "keystore", "priv_sk"):                           identity.KeyPEM,
+		}
+	}
+
+	var storeFiles = make(map[string][]byte, len(files))
+	for name, payload := range files {
+		storeFiles[path.Join(e.dir, name)] = payload
+	}
+
+	if err := cryptostore.WriteFiles(ctx, store, storeFiles); err != nil {
+		return fmt.Errorf("failed to write '%s' identity: %w", e.name, err)
+	}
+
+	podName, err := kube.AwaitPodReady(ctx, e.mount.selector, a.kubeNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to write '%s' identity into artifacts volume: %w", e.name, err)
+	}
+
+	return a.replaceMountedFiles(ctx, podName, e.mount.dir, files)
+}
+
+// randomSecret generates random enrollment secret.
+func randomSecret() string {
+	var secret = make([]byte, 16)
+
+	_, _ = rand.Read(secret)
+
+	return hex.EncodeToString(secret)
+}
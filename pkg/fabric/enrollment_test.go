@@ -0,0 +1,189 @@
+package fabric
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"path"
+	"testing"
+	"time"
+
+	helmclient "github.com/mittwald/go-helm-client"
+	"github.com/timoth-y/fabnctl/pkg/cryptostore"
+	"github.com/timoth-y/fabnctl/pkg/helm"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// releaseValuesClient substitutes Helm client with the one returning predefined release values.
+type releaseValuesClient struct {
+	helmclient.Client
+	values map[string]map[string]interface{}
+}
+
+func (c releaseValuesClient) GetReleaseValues(name string, _ bool) (map[string]interface{}, error) {
+	values, ok := c.values[name]
+	if !ok {
+		return nil, fmt.Errorf("release: not found")
+	}
+
+	return values, nil
+}
+
+func TestRegistrarCredentials(t *testing.T) {
+	var deployment = func(name, release string) *appsv1.Deployment {
+		var annotations map[string]string
+		if len(release) != 0 {
+			annotations = map[string]string{helmReleaseAnnotation: release}
+		}
+
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "network",
+			Annotations: annotations,
+		}}
+	}
+
+	var adminValues = func(name, secret string) map[string]interface{} {
+		return map[string]interface{}{
+			"ca": map[string]interface{}{
+				"admin": map[string]interface{}{"name": name, "secret": secret},
+			},
+		}
+	}
+
+	var originalKube, originalHelm = kube.Client, helm.Client
+	defer func() { kube.Client, helm.Client = originalKube, originalHelm }()
+
+	kube.Client = fake.NewSimpleClientset(
+		deployment("ca.org1.org", "peer0-org1"),
+		deployment("ca.org2.org", "peer0-org2"),
+		deployment("ca.org3.org", ""),
+		deployment("ca.orderer", "orderer"),
+	)
+	helm.Client = releaseValuesClient{values: map[string]map[string]interface{}{
+		"peer0-org1": adminValues("registrar", "s3cr3t"),
+		"peer0-org2": {"ca": map[string]interface{}{"enabled": true}},
+		"orderer":    adminValues("admin", "adminpw"),
+	}}
+
+	var args = &sharedArgs{kubeNamespace: "network"}
+
+	var tests = []struct {
+		name       string
+		deployment string
+		user       string
+		secret     string
+		fails      bool
+	}{
+		{name: "overridden credentials", deployment: "ca.org1.org", user: "registrar", secret: "s3cr3t"},
+		{name: "default credentials", deployment: "ca.orderer", user: "admin", secret: "adminpw"},
+		{name: "missing credentials", deployment: "ca.org2.org", fails: true},
+		{name: "not managed by helm", deployment: "ca.org3.org", fails: true},
+		{name: "missing deployment", deployment: "ca.org4.org", fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credentials, err := args.registrarCredentials(context.Background(), orgCA{deployment: tt.deployment})
+
+			if tt.fails {
+				if err == nil {
+					t.Errorf("expected error, got credentials: %v", credentials)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if credentials.Name != tt.user || credentials.Secret != tt.secret {
+				t.Errorf("expected '%s:%s' credentials, got '%s:%s'",
+					tt.user, tt.secret, credentials.Name, credentials.Secret,
+				)
+			}
+		})
+	}
+}
+
+func TestVerifyMSPTrust(t *testing.T) {
+	var (
+		store  = cryptostore.NewLocal(t.TempDir())
+		ctx    = context.Background()
+		org    = orgCA{caName: "ca-org1-org", tlsCAName: "tlsca-org1-org", mspDir: "peerOrganizations/org1.org.example.com/msp"}
+		rootCA = selfSignedPEM(t, "ca.org1.org.example.com")
+		tlsCA  = selfSignedPEM(t, "tlsca.org1.org.example.com")
+		args   = &sharedArgs{}
+	)
+
+	for name, payload := range map[string][]byte{
+		path.Join(org.mspDir, "cacerts", "ca.org1.org.example.com-cert.pem"):       rootCA,
+		path.Join(org.mspDir, "tlscacerts", "tlsca.org1.org.example.com-cert.pem"): tlsCA,
+	} {
+		if err := store.Write(ctx, name, payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var tests = []struct {
+		name  string
+		tls   bool
+		chain []byte
+		fails bool
+	}{
+		{name: "enrollment CA", chain: rootCA},
+		{name: "TLS CA", tls: true, chain: tlsCA},
+		{name: "TLS CA isn't enrollment one", chain: tlsCA, fails: true},
+		{name: "enrollment CA isn't TLS one", tls: true, chain: rootCA, fails: true},
+		{name: "unknown CA", chain: selfSignedPEM(t, "ca.org1.org.example.com"), fails: true},
+		{name: "empty chain", chain: nil, fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := args.verifyMSPTrust(ctx, store, org, tt.tls, tt.chain)
+
+			if tt.fails && err == nil {
+				t.Error("expected error, got <nil>")
+			} else if !tt.fails && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// selfSignedPEM generates self-signed CA certificate with given `commonName`.
+func selfSignedPEM(t *testing.T, commonName string) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var template = &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
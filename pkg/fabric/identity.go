@@ -0,0 +1,280 @@
+package fabric
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/timoth-y/fabnctl/pkg/ca"
+	"github.com/timoth-y/fabnctl/pkg/certs"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/term"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// mspConfig enables node OUs in the MSP of enrolled identities, the same way cryptogen does for peer organizations.
+const mspConfig = `NodeOUs:
+  Enable: true
+  ClientOUIdentifier:
+    Certificate: cacerts/ca.pem
+    OrganizationalUnitIdentifier: client
+  PeerOUIdentifier:
+    Certificate: cacerts/ca.pem
+    OrganizationalUnitIdentifier: peer
+  AdminOUIdentifier:
+    Certificate: cacerts/ca.pem
+    OrganizationalUnitIdentifier: admin
+  OrdererOUIdentifier:
+    Certificate: cacerts/ca.pem
+    OrganizationalUnitIdentifier: orderer
+`
+
+// invalidSecretNameChars matches characters, which aren't allowed in Kubernetes resource names.
+var invalidSecretNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// Identities manages users and application client identities of the organization,
+// which are registered with and enrolled against its Fabric CA.
+type Identities struct {
+	org string
+	*identityArgs
+}
+
+// NewIdentities constructs new Identities instance for the `org` organization.
+func NewIdentities(org string, options ...IdentityOption) (*Identities, error) {
+	var args = &identityArgs{
+		identityType: "client",
+		sharedArgs: &sharedArgs{
+			arch:          "amd64",
+			kubeNamespace: "network",
+			logger:        term.NewLogger(),
+			chartsPath:    "./network-config.yaml",
+		},
+	}
+
+	for i := range options {
+		options[i](args)
+	}
+
+	if len(args.initErrors) > 0 {
+		return nil, args.Error()
+	}
+
+	return &Identities{
+		org:          org,
+		identityArgs: args,
+	}, nil
+}
+
+// IdentityMSPPath forms path of the MSP directory in the crypto store,
+// where enrolled identity `name` of the `org` organization is stored.
+func IdentityMSPPath(org, name string) string {
+	return path.Join("identities", org, name, "msp")
+}
+
+// Register registers identity with given `name` with the organization CA. Returns its enrollment secret.
+func (i *Identities) Register(ctx context.Context, name string) (string, error) {
+	var secret string
+
+	if err := i.withRegistrar(ctx, func(client *ca.Client, registrar *ca.Identity) (err error) {
+		var registration = ca.Registration{
+			Name:           name,
+			Type:           i.identityType,
+			Secret:         i.secret,
+			Affiliation:    i.affiliation,
+			MaxEnrollments: i.maxEnrollments,
+		}
+
+		for key, value := range i.attributes {
+			registration.Attributes = append(registration.Attributes, ca.Attribute{
+				Name:  key,
+				Value: value,
+				ECert: true,
+			})
+		}
+
+		secret, err = client.Register(ctx, registrar, registration)
+		return err
+	}); err != nil {
+		return "", err
+	}
+
+	i.logger.Successf("Identity '%s' successfully registered with '%s' organization CA", name, i.org)
+
+	return secret, nil
+}
+
+// Enroll enrolls identity with given `name` against the organization CA, using the enrollment secret.
+// Enrolled MSP is stored in the crypto store and published as Kubernetes secret, if it's requested.
+// Returns the MSP path in the crypto store.
+func (i *Identities) Enroll(ctx context.Context, name string) (string, error) {
+	if len(i.secret) == 0 {
+		return "", fmt.Errorf("%w: enrollment secret is required to enroll '%s' identity", term.ErrInvalidArgs, name)
+	}
+
+	var org = peerOrgCA(i.org)
+
+	url, stop, err := i.forwardCA(ctx, org)
+	if err != nil {
+		return "", err
+	}
+
+	defer stop()
+
+	identity, err := ca.NewClient(url, org.caName).Enroll(ctx, ca.EnrollmentRequest{
+		Name:   name,
+		Secret: i.secret,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var mspDir = IdentityMSPPath(i.org, name)
+
+	if err = i.writeCrypto(ctx, mspFiles(mspDir, identity)); err != nil {
+		return "", fmt.Errorf("failed to write '%s' identity: %w", name, err)
+	}
+
+	cert, err := certs.ParseCertificate(identity.CertPEM)
+	if err != nil {
+		return "", err
+	}
+
+	i.logger.Successf(
+		"Identity '%s' enrolled with certificate valid until %s (serial %x), MSP is stored in crypto store at '%s'",
+		name, cert.NotAfter.Format("2006-01-02"), cert.SerialNumber, mspDir,
+	)
+
+	if i.publish {
+		secretName, err := i.publishSecret(ctx, name, identity)
+		if err != nil {
+			return "", err
+		}
+
+		i.logger.Successf("Secret '%s' successfully created", secretName)
+	}
+
+	return mspDir, nil
+}
+
+// Revoke revokes all certificates of the identity with given `name`, so that it can no longer transact or enroll.
+// Its published Kubernetes secret is removed as well.
+func (i *Identities) Revoke(ctx context.Context, name string) error {
+	var result *ca.RevocationResult
+
+	if err := i.withRegistrar(ctx, func(client *ca.Client, registrar *ca.Identity) (err error) {
+		result, err = client.Revoke(ctx, registrar, ca.RevocationRequest{
+			Name:   name,
+			Reason: i.revocationReason,
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	for _, cert := range result.RevokedCerts {
+		i.logger.Okf("Certificate with serial %s of '%s' identity revoked", cert.Serial, name)
+	}
+
+	var secretName = i.secretName(name)
+
+	if err := kube.Client.CoreV1().Secrets(i.kubeNamespace).Delete(ctx, secretName, metav1.DeleteOptions{}); err == nil {
+		i.logger.Okf("Secret '%s' removed", secretName)
+	} else if !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to remove '%s' secret: %w", secretName, err)
+	}
+
+	i.logger.Successf("Identity '%s' successfully revoked", name)
+
+	return nil
+}
+
+// List retrieves identities registered with the organization CA.
+func (i *Identities) List(ctx context.Context) ([]ca.IdentityInfo, error) {
+	var identities []ca.IdentityInfo
+
+	if err := i.withRegistrar(ctx, func(client *ca.Client, registrar *ca.Identity) (err error) {
+		identities, err = client.ListIdentities(ctx, registrar)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+// withRegistrar calls `fn` with the client of the organization CA and its enrolled registrar.
+func (i *Identities) withRegistrar(ctx context.Context, fn func(client *ca.Client, registrar *ca.Identity) error) error {
+	var org = peerOrgCA(i.org)
+
+	url, stop, err := i.forwardCA(ctx, org)
+	if err != nil {
+		return err
+	}
+
+	defer stop()
+
+	credentials, err := i.registrarCredentials(ctx, org)
+	if err != nil {
+		return err
+	}
+
+	var client = ca.NewClient(url, org.caName)
+
+	registrar, err := enrollRegistrar(ctx, client, credentials)
+	if err != nil {
+		return err
+	}
+
+	return fn(client, registrar)
+}
+
+// publishSecret creates or updates secret with enrolled `identity` key pair and CA certificates chain.
+func (i *Identities) publishSecret(ctx context.Context, name string, identity *ca.Identity) (string, error) {
+	var secretName = i.secretName(name)
+
+	if _, err := kube.SecretAdapter(kube.Client.CoreV1().Secrets(i.kubeNamespace)).CreateOrUpdate(ctx, corev1.Secret{
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"cert.pem": identity.CertPEM,
+			"key.pem":  identity.KeyPEM,
+			"ca.pem":   identity.CAChainPEM,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: i.kubeNamespace,
+			Labels: map[string]string{
+				"fabnctl/cid":    "identity",
+				"fabnctl/domain": i.domain,
+				"fabnctl/org":    i.org,
+			},
+			Annotations: map[string]string{
+				"fabnctl/identity": name,
+			},
+		},
+	}); err != nil {
+		return "", fmt.Errorf("failed to create %s secret: %w", secretName, err)
+	}
+
+	return secretName, nil
+}
+
+// secretName forms name of the secret the identity with given `name` is published as.
+func (i *Identities) secretName(name string) string {
+	var sanitized = strings.Trim(invalidSecretNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-.")
+
+	return fmt.Sprintf("identity.%s.%s", i.org, sanitized)
+}
+
+// mspFiles lays out enrolled `identity` files in the `mspDir` following MSP directory layout.
+func mspFiles(mspDir string, identity *ca.Identity) map[string][]byte {
+	return map[string][]byte{
+		path.Join(mspDir, "signcerts", "cert.pem"): identity.CertPEM,
+		path.Join(mspDir, "keystore", "priv_sk"):   identity.KeyPEM,
+		path.Join(mspDir, "cacerts", "ca.pem"):     identity.CAChainPEM,
+		path.Join(mspDir, "config.yaml"):           []byte(mspConfig),
+	}
+}
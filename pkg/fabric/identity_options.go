@@ -0,0 +1,174 @@
+package fabric
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+type (
+	// IdentityOption allows passing additional arguments for managing organization identities.
+	IdentityOption func(*identityArgs)
+
+	identityArgs struct {
+		identityType     string
+		affiliation      string
+		attributes       map[string]string
+		maxEnrollments   int
+		secret           string
+		publish          bool
+		revocationReason string
+		*sharedArgs
+	}
+)
+
+// WithIdentityType sets type of the registered identity, e.g. 'client' (default), 'admin' or 'peer'.
+func WithIdentityType(identityType string) IdentityOption {
+	return func(args *identityArgs) {
+		args.identityType = identityType
+	}
+}
+
+// WithIdentityTypeFlag ...
+func WithIdentityTypeFlag(flags *pflag.FlagSet, name string) IdentityOption {
+	return func(args *identityArgs) {
+		var err error
+
+		if args.identityType, err = flags.GetString(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (identity type): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithAffiliation sets affiliation of the registered identity. Empty one stands for the root affiliation.
+func WithAffiliation(affiliation string) IdentityOption {
+	return func(args *identityArgs) {
+		args.affiliation = affiliation
+	}
+}
+
+// WithAffiliationFlag ...
+func WithAffiliationFlag(flags *pflag.FlagSet, name string) IdentityOption {
+	return func(args *identityArgs) {
+		var err error
+
+		if args.affiliation, err = flags.GetString(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (affiliation): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithIdentityAttributes sets attributes of the registered identity,
+// which are included into its enrollment certificates for attribute-based access control.
+func WithIdentityAttributes(attributes map[string]string) IdentityOption {
+	return func(args *identityArgs) {
+		args.attributes = attributes
+	}
+}
+
+// WithIdentityAttributesFlag ...
+func WithIdentityAttributesFlag(flags *pflag.FlagSet, name string) IdentityOption {
+	return func(args *identityArgs) {
+		var err error
+
+		if args.attributes, err = flags.GetStringToString(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (identity attributes): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithMaxEnrollments limits the number of times the registered identity can be enrolled, 0 stands for the CA default.
+func WithMaxEnrollments(maxEnrollments int) IdentityOption {
+	return func(args *identityArgs) {
+		args.maxEnrollments = maxEnrollments
+	}
+}
+
+// WithMaxEnrollmentsFlag ...
+func WithMaxEnrollmentsFlag(flags *pflag.FlagSet, name string) IdentityOption {
+	return func(args *identityArgs) {
+		var err error
+
+		if args.maxEnrollments, err = flags.GetInt(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (max enrollments): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithEnrollmentSecret sets enrollment secret of the identity.
+// It's generated by the CA on registration when not set, while it's required for enrollment.
+func WithEnrollmentSecret(secret string) IdentityOption {
+	return func(args *identityArgs) {
+		args.secret = secret
+	}
+}
+
+// WithEnrollmentSecretFlag ...
+func WithEnrollmentSecretFlag(flags *pflag.FlagSet, name string) IdentityOption {
+	return func(args *identityArgs) {
+		var err error
+
+		if args.secret, err = flags.GetString(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (enrollment secret): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithPublishSecret makes enrolled identity be published as Kubernetes secret labelled with 'fabnctl/cid=identity'.
+func WithPublishSecret(publish bool) IdentityOption {
+	return func(args *identityArgs) {
+		args.publish = publish
+	}
+}
+
+// WithPublishSecretFlag ...
+func WithPublishSecretFlag(flags *pflag.FlagSet, name string) IdentityOption {
+	return func(args *identityArgs) {
+		var err error
+
+		if args.publish, err = flags.GetBool(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (publish secret): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithRevocationReason sets reason of the identity revocation as defined in RFC 5280, e.g. 'keycompromise'.
+func WithRevocationReason(reason string) IdentityOption {
+	return func(args *identityArgs) {
+		args.revocationReason = reason
+	}
+}
+
+// WithRevocationReasonFlag ...
+func WithRevocationReasonFlag(flags *pflag.FlagSet, name string) IdentityOption {
+	return func(args *identityArgs) {
+		var err error
+
+		if args.revocationReason, err = flags.GetString(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (revocation reason): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithSharedOptionsForIdentity ...
+func WithSharedOptionsForIdentity(options ...SharedOption) IdentityOption {
+	return func(args *identityArgs) {
+		for i := range options {
+			options[i](args.sharedArgs)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package fabric
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/timoth-y/fabnctl/pkg/model"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// Network defines methods for reconciling the whole network deployment with its model.NetworkConfig.
+type Network struct {
+	config *model.NetworkConfig
+	*sharedArgs
+}
+
+// NewNetwork constructs new Network instance.
+func NewNetwork(config *model.NetworkConfig, options ...SharedOption) (*Network, error) {
+	var args = &sharedArgs{
+		arch:          "amd64",
+		kubeNamespace: "network",
+		parallelism:   1,
+		logger:        term.NewLogger(),
+		chartsPath:    "./network-config.yaml",
+	}
+
+	for i := range options {
+		options[i](args)
+	}
+
+	if len(args.domain) == 0 {
+		args.domain = config.Domain
+	}
+
+	if len(args.orderers) == 0 {
+		args.orderers = config.Orderer.Hostnames()
+	}
+
+	if args.domain != config.Domain {
+		args.initErrors = append(args.initErrors,
+			fmt.Errorf("given domain '%s' doesn't match '%s' domain from network config", args.domain, config.Domain),
+		)
+	}
+
+	if len(args.initErrors) > 0 {
+		return nil, args.Error()
+	}
+
+	return &Network{
+		config:     config,
+		sharedArgs: args,
+	}, nil
+}
+
+// Apply reconciles cluster state with the network config by installing missing components in dependency order:
+// orderer, organization peers, channels along with anchor peers and finally chaincodes.
+// Components which are already in the desired state are skipped, so that repeated calls are no-op.
+func (n *Network) Apply(ctx context.Context) error {
+	store, err := n.crypto()
+	if err != nil {
+		return err
+	}
+
+	if names, err := store.List(ctx, ""); err != nil {
+		return err
+	} else if len(names) == 0 {
+		return fmt.Errorf("crypto materials not found in the crypto store, generate them first with 'fabnctl gen artifacts'")
+	}
+
+	plan, err := n.Plan(ctx)
+	if err != nil {
+		return err
+	}
+
+	if plan.Empty() {
+		n.logger.Okf("Network on '%s' domain is up to date, nothing to apply", n.domain)
+		return nil
+	}
+
+	for _, component := range plan.Components {
+		if err = n.applyComponent(ctx, component); err != nil {
+			return err
+		}
+
+		n.logger.NewLine()
+	}
+
+	for _, change := range plan.Channels {
+		if err = n.applyChannel(ctx, change); err != nil {
+			return err
+		}
+
+		n.logger.NewLine()
+	}
+
+	for _, change := range plan.Chaincodes {
+		if err = n.applyChaincode(ctx, change); err != nil {
+			return err
+		}
+
+		n.logger.NewLine()
+	}
+
+	n.logger.Successf("Network on '%s' domain successfully applied!", n.domain)
+
+	return nil
+}
+
+func (n *Network) applyComponent(ctx context.Context, component ComponentChange) error {
+	switch component.Kind {
+	case "orderer":
+		orderer, err := NewOrderer(component.Hostname, n.sharedOptions()...)
+		if err != nil {
+			return err
+		}
+
+		return orderer.Install(ctx)
+	case "peer":
+		peer, err := NewPeer(component.Org, component.Hostname,
+			WithCA(true),
+			WithSharedOptionsForPeer(n.sharedOptions()...),
+		)
+		if err != nil {
+			return err
+		}
+
+		return peer.Install(ctx)
+	}
+
+	return fmt.Errorf("unknown network component kind: %s", component.Kind)
+}
+
+// applyChannel creates the channel if `change` requires so, adds new member organizations to its definition,
+// joins missing peers and sets missing anchor peers.
+func (n *Network) applyChannel(ctx context.Context, change ChannelChange) error {
+	if len(change.NewMembers) != 0 {
+		channel, err := NewChannel(change.ChannelID, WithSharedOptionsForChannel(n.sharedOptions()...))
+		if err != nil {
+			return err
+		}
+
+		for _, member := range change.NewMembers {
+			if err = channel.AddOrganization(ctx, member.Org, member.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	if change.Create || len(change.MissingPeers) != 0 {
+		var options = []ChannelOption{
+			WithSharedOptionsForChannel(n.sharedOptions()...),
+		}
+
+		for _, member := range change.MissingPeers {
+			options = append(options, WithChannelPeers(member.Org, member.Peer))
+		}
+
+		channel, err := NewChannel(change.ChannelID, options...)
+		if err != nil {
+			return err
+		}
+
+		if change.Create {
+			err = channel.Install(ctx)
+		} else {
+			err = channel.Join(ctx)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(change.MissingAnchors) != 0 {
+		channel, err := NewChannel(change.ChannelID, WithSharedOptionsForChannel(n.sharedOptions()...))
+		if err != nil {
+			return err
+		}
+
+		if err = channel.SetAnchors(ctx, change.MissingAnchors...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *Network) applyChaincode(ctx context.Context, change ChaincodeChange) error {
+	var cc = n.config.GetChaincode(change.Name, change.ChannelID)
+
+	chaincode, err := n.chaincode(cc.Name, cc.ChannelID, cc.Organizations)
+	if err != nil {
+		return err
+	}
+
+	return chaincode.Install(ctx, chaincodeInstallOptions(cc)...)
+}
+
+// chaincodeInstallOptions forms installation options of the chaincode defined in network config.
+func chaincodeInstallOptions(cc *model.Chaincode) []ChaincodeInstallOption {
+	var installOptions []ChaincodeInstallOption
+
+	if len(cc.Image) != 0 {
+		installOptions = append(installOptions, WithImage(cc.Image))
+	}
+
+	if len(cc.Source) != 0 {
+		installOptions = append(installOptions, WithSource(cc.Source))
+	}
+
+	if len(cc.Type) != 0 {
+		installOptions = append(installOptions, WithChaincodeType(cc.Type))
+	}
+
+	if len(cc.Version) != 0 {
+		installOptions = append(installOptions, WithVersion(string(cc.Version)))
+	}
+
+	if cc.VersionFromGit {
+		installOptions = append(installOptions, WithVersionFromGit(true))
+	}
+
+	if len(cc.Policy) != 0 {
+		installOptions = append(installOptions, WithEndorsementPolicy(cc.Policy))
+	}
+
+	if len(cc.CollectionsConfig) != 0 {
+		installOptions = append(installOptions, WithCollectionsConfig(cc.CollectionsConfig))
+	}
+
+	return append(installOptions, WithInitRequired(cc.InitRequired), WithTLS(cc.TLS))
+}
+
+// chaincode constructs Chaincode instance targeting all peers of the given organizations.
+func (n *Network) chaincode(name, channel string, orgNames []string) (*Chaincode, error) {
+	var options = []ChaincodeOption{
+		WithChannel(channel),
+		WithSharedOptionsForChaincode(n.sharedOptions()...),
+	}
+
+	for _, orgName := range orgNames {
+		var (
+			org   = n.config.GetOrganizationByName(orgName)
+			peers []string
+		)
+
+		if org == nil {
+			return nil, fmt.Errorf("organization '%s' of '%s' chaincode isn't defined in network config",
+				orgName, name,
+			)
+		}
+
+		for _, p := range org.Peers {
+			peers = append(peers, p.Hostname)
+		}
+
+		options = append(options, WithChaincodePeers(org.MspID, peers...))
+	}
+
+	return NewChaincode(name, options...)
+}
+
+func (n *Network) isPeerJoined(ctx context.Context, org, peer, channel string) (bool, error) {
+	cliPodName, err := findPeerCliPod(ctx, n.kubeNamespace, org, peer)
+	if err != nil || len(cliPodName) == 0 {
+		return false, err
+	}
+
+	channels, err := joinedChannels(ctx, n.kubeNamespace, cliPodName)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range channels {
+		if channels[i] == channel {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// sharedOptions forms SharedOption list for passing Network arguments to the components it manages.
+func (n *Network) sharedOptions() []SharedOption {
+	return []SharedOption{
+		WithArch(n.arch),
+		WithDomain(n.domain),
+		WithKubeNamespace(n.kubeNamespace),
+		WithOrderers(n.orderers...),
+		WithParallelism(n.parallelism),
+		WithContinueOnError(n.continueOnError),
+		WithEnrollment(n.enrollment),
+		WithCryptoStore(n.cryptoStoreKind),
+		WithCustomCryptoStore(n.cryptoStore),
+		WithLogger(n.logger),
+		WithCustomDeployCharts(n.chartsPath),
+	}
+}
@@ -0,0 +1,302 @@
+package fabric
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/timoth-y/fabnctl/pkg/helm"
+	"github.com/timoth-y/fabnctl/pkg/util"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// PlanAction defines the kind of change required for a network component.
+type PlanAction string
+
+const (
+	// PlanInstall means that component is missing and would be installed.
+	PlanInstall PlanAction = "install"
+	// PlanUpgrade means that component is present but isn't in the desired state.
+	PlanUpgrade PlanAction = "upgrade"
+	// PlanCreate means that resource is missing and would be created.
+	PlanCreate PlanAction = "create"
+	// PlanJoin means that organization isn't a channel member and would be added to its definition.
+	PlanJoin PlanAction = "join"
+)
+
+type (
+	// NetworkPlan describes the difference between network config and the cluster state,
+	// which must be applied in order to bring network to the desired state.
+	NetworkPlan struct {
+		Domain     string            `json:"domain"`
+		Components []ComponentChange `json:"components,omitempty"`
+		Channels   []ChannelChange   `json:"channels,omitempty"`
+		Chaincodes []ChaincodeChange `json:"chaincodes,omitempty"`
+	}
+
+	// ComponentChange describes changes of the orderer or peer deployment.
+	ComponentChange struct {
+		Kind          string         `json:"kind"`
+		Org           string         `json:"org,omitempty"`
+		Hostname      string         `json:"hostname"`
+		Release       string         `json:"release"`
+		Chart         string         `json:"chart"`
+		ReleaseAction PlanAction     `json:"releaseAction,omitempty"`
+		Secrets       []SecretChange `json:"secrets,omitempty"`
+	}
+
+	// SecretChange describes secret which would be created with kube.SecretInterface CreateOrUpdate method.
+	SecretChange struct {
+		Name   string     `json:"name"`
+		Action PlanAction `json:"action"`
+	}
+
+	// ChannelChange describes organizations which aren't members of the channel, peers which are missing from it
+	// and organizations which anchor peers aren't set in the channel definition.
+	// Channel is created only when Create is set, otherwise it's expected to exist already.
+	ChannelChange struct {
+		ChannelID      string          `json:"channelID"`
+		Create         bool            `json:"create,omitempty"`
+		NewMembers     []ChannelMember `json:"newMembers,omitempty"`
+		MissingPeers   []ChannelPeer   `json:"missingPeers,omitempty"`
+		MissingAnchors []string        `json:"missingAnchors,omitempty"`
+	}
+
+	// ChannelMember defines organization, which MSP definition would be added to the channel config under Name.
+	ChannelMember struct {
+		Org    string     `json:"org"`
+		Name   string     `json:"name"`
+		Action PlanAction `json:"action"`
+	}
+
+	// ChannelPeer defines organization peer as the channel member.
+	ChannelPeer struct {
+		Org  string `json:"org"`
+		Peer string `json:"peer"`
+	}
+
+	// ChaincodeChange describes the difference between committed chaincode definition and the target one.
+	ChaincodeChange struct {
+		Name              string   `json:"name"`
+		ChannelID         string   `json:"channelID"`
+		Committed         bool     `json:"committed"`
+		CommittedVersion  string   `json:"committedVersion,omitempty"`
+		CommittedSequence int      `json:"committedSequence,omitempty"`
+		TargetVersion     string   `json:"targetVersion"`
+		TargetSequence    int      `json:"targetSequence"`
+		DefinitionChanges []string `json:"definitionChanges,omitempty"`
+	}
+)
+
+// Empty determines whether the NetworkPlan contains no changes.
+func (p *NetworkPlan) Empty() bool {
+	return len(p.Components) == 0 && len(p.Channels) == 0 && len(p.Chaincodes) == 0
+}
+
+// Plan compares network config with the cluster state and forms NetworkPlan without changing anything.
+func (n *Network) Plan(ctx context.Context) (*NetworkPlan, error) {
+	var plan = &NetworkPlan{
+		Domain: n.domain,
+	}
+
+	// Orderer cluster nodes deployment:
+	for _, hostname := range n.ordererHostnames() {
+		if change, err := n.planComponent(ctx, ComponentChange{
+			Kind:     "orderer",
+			Hostname: hostname,
+			Release:  hostname,
+			Chart:    "orderer",
+		}, fmt.Sprintf("%s.%s", hostname, n.domain)); err != nil {
+			return nil, err
+		} else if change != nil {
+			plan.Components = append(plan.Components, *change)
+		}
+	}
+
+	// Organization peers deployment:
+	for _, org := range n.config.Organizations {
+		for _, p := range org.Peers {
+			if change, err := n.planComponent(ctx, ComponentChange{
+				Kind:     "peer",
+				Org:      org.MspID,
+				Hostname: p.Hostname,
+				Release:  fmt.Sprintf("%s-%s", p.Hostname, org.MspID),
+				Chart:    "peer",
+			}, fmt.Sprintf("%s.%s.org.%s", p.Hostname, org.MspID, n.domain)); err != nil {
+				return nil, err
+			} else if change != nil {
+				plan.Components = append(plan.Components, *change)
+			}
+		}
+	}
+
+	// Channels membership and anchor peers:
+	var existingChannels = make(map[string]bool)
+
+	for _, ch := range n.config.Channels {
+		var (
+			change = ChannelChange{ChannelID: ch.ChannelID}
+			joined *ChannelPeer
+		)
+
+		for _, orgName := range ch.Organizations {
+			var org = n.config.GetOrganizationByName(orgName)
+			if org == nil {
+				return nil, fmt.Errorf("organization '%s' of '%s' channel isn't defined in network config",
+					orgName, ch.ChannelID,
+				)
+			}
+
+			for _, p := range org.Peers {
+				isJoined, err := n.isPeerJoined(ctx, org.MspID, p.Hostname, ch.ChannelID)
+				if err != nil {
+					return nil, err
+				}
+
+				if isJoined {
+					if joined == nil {
+						joined = &ChannelPeer{Org: org.MspID, Peer: p.Hostname}
+					}
+					continue
+				}
+
+				change.MissingPeers = append(change.MissingPeers, ChannelPeer{
+					Org:  org.MspID,
+					Peer: p.Hostname,
+				})
+			}
+		}
+
+		change.Create = joined == nil
+		existingChannels[ch.ChannelID] = !change.Create
+
+		// Current channel config is fetched with one of the joined peers, since others may not have access to it:
+		var anchors map[string]bool
+
+		if !change.Create {
+			var err error
+			if anchors, err = n.channelAnchors(ctx, ch.ChannelID, *joined); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, orgName := range ch.Organizations {
+			var (
+				org           = n.config.GetOrganizationByName(orgName)
+				isSet, member = anchors[org.Name]
+			)
+
+			// Organizations of the new channel are members of its definition from the channel creation transaction:
+			if !change.Create && !member {
+				change.NewMembers = append(change.NewMembers, ChannelMember{
+					Org:    org.MspID,
+					Name:   org.Name,
+					Action: PlanJoin,
+				})
+			}
+
+			if len(org.Peers) != 0 && !isSet {
+				change.MissingAnchors = append(change.MissingAnchors, org.MspID)
+			}
+		}
+
+		if len(change.NewMembers) != 0 || len(change.MissingPeers) != 0 || len(change.MissingAnchors) != 0 {
+			plan.Channels = append(plan.Channels, change)
+		}
+	}
+
+	// Chaincodes definitions:
+	for _, cc := range n.config.Chaincodes {
+		chaincode, err := n.chaincode(cc.Name, cc.ChannelID, cc.Organizations)
+		if err != nil {
+			return nil, err
+		}
+
+		args, err := chaincode.newInstallArgs(chaincodeInstallOptions(&cc)...)
+		if err != nil {
+			return nil, err
+		}
+
+		var change = ChaincodeChange{
+			Name:           cc.Name,
+			ChannelID:      cc.ChannelID,
+			TargetVersion:  args.version,
+			TargetSequence: 1,
+		}
+
+		if existingChannels[cc.ChannelID] {
+			if change.Committed, change.CommittedVersion, change.CommittedSequence, err =
+				chaincode.checkChaincodeCommitStatus(ctx); err != nil {
+				return nil, err
+			}
+
+			if change.Committed {
+				definition, err := desiredDefinition(args)
+				if err != nil {
+					return nil, err
+				}
+
+				if change.DefinitionChanges, err = chaincode.definitionChanges(ctx, definition); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if change.Committed {
+			var versionChanged = args.customVersion && args.version != change.CommittedVersion
+
+			if !versionChanged && len(change.DefinitionChanges) == 0 {
+				continue
+			}
+
+			// Version is automatically incremented during update, unless it's set explicitly:
+			if !args.customVersion {
+				change.TargetVersion = util.NextVersion(change.CommittedVersion)
+			}
+
+			change.TargetSequence = change.CommittedSequence + 1
+		}
+
+		plan.Chaincodes = append(plan.Chaincodes, change)
+	}
+
+	return plan, nil
+}
+
+// planComponent determines whether the helm release of the `component` and its TLS secrets
+// for the `host` must be installed. Returns <nil> if component is already in the desired state.
+func (n *Network) planComponent(ctx context.Context, component ComponentChange, host string) (*ComponentChange, error) {
+	for _, secret := range []string{
+		fmt.Sprintf("%s-tls", host),
+		fmt.Sprintf("%s-ca", host),
+	} {
+		exists, err := secretExists(ctx, n.kubeNamespace, secret)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			component.Secrets = append(component.Secrets, SecretChange{
+				Name:   secret,
+				Action: PlanCreate,
+			})
+		}
+	}
+
+	history, err := helm.ReleaseHistory(component.Release)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(history) == 0:
+		component.ReleaseAction = PlanInstall
+	case history[0].Info == nil || history[0].Info.Status != release.StatusDeployed:
+		component.ReleaseAction = PlanUpgrade
+	}
+
+	if len(component.ReleaseAction) == 0 && len(component.Secrets) == 0 {
+		return nil, nil
+	}
+
+	return &component, nil
+}
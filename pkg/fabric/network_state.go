@@ -0,0 +1,147 @@
+package fabric
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/timoth-y/fabnctl/pkg/channelconfig"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/term"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// secretExists determines whether the secret with given `name` exists in the `namespace`.
+func secretExists(ctx context.Context, namespace, name string) (bool, error) {
+	if _, err := kube.Client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to get '%s' secret: %w", name, err)
+	}
+
+	return true, nil
+}
+
+// findPeerCliPod finds name of the CLI pod deployed along with `peer` of the `org` organization.
+// Returns empty string when peer isn't deployed yet.
+func findPeerCliPod(ctx context.Context, namespace, org, peer string) (string, error) {
+	pod, err := kube.FindPod(ctx, namespace, fmt.Sprintf("fabnctl/app=cli.%s.%s.org", peer, org))
+	if err != nil || pod == nil {
+		return "", err
+	}
+
+	return pod.Name, nil
+}
+
+// joinedChannels retrieves list of channels joined by peer, which CLI pod is `cliPodName`.
+func joinedChannels(ctx context.Context, namespace, cliPodName string) ([]string, error) {
+	stdout, _, err := kube.ExecCommandInPod(ctx, cliPodName, namespace, "peer", "channel", "list")
+	if err != nil {
+		if errors.Is(err, term.ErrRemoteCmdFailed) {
+			return nil, fmt.Errorf("failed to list joined channels: %w", err)
+		}
+
+		return nil, fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
+	}
+
+	var (
+		channels []string
+		listed   bool
+		scanner  = bufio.NewScanner(stdout)
+	)
+
+	for scanner.Scan() {
+		var line = strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "Channels peers has joined") {
+			listed = true
+			continue
+		}
+
+		if listed && len(line) != 0 {
+			channels = append(channels, line)
+		}
+	}
+
+	return channels, nil
+}
+
+// channelAnchors retrieves organizations, which are members of the `channel` according to its current configuration,
+// mapping their names to whether their anchor peers are already set.
+// Config is fetched with the `joined` peer, since peers not joined to the channel may not have access to it.
+func (n *Network) channelAnchors(ctx context.Context, channel string, joined ChannelPeer) (map[string]bool, error) {
+	ch, err := NewChannel(channel,
+		WithSharedOptionsForChannel(n.sharedOptions()...),
+		WithChannelPeers(joined.Org, joined.Peer),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := ch.FetchBlock(ctx, "config")
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := channelconfig.ConfigFromBlockBytes(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode '%s' channel config: %w", channel, err)
+	}
+
+	var anchors = make(map[string]bool)
+
+	if application, ok := config.GetChannelGroup().GetGroups()["Application"]; ok {
+		for orgName, org := range application.Groups {
+			_, anchors[orgName] = org.GetValues()["AnchorPeers"]
+		}
+	}
+
+	return anchors, nil
+}
+
+// committedChaincodes retrieves definitions of chaincodes committed on the `channel`
+// using the CLI pod named `cliPodName`.
+func committedChaincodes(ctx context.Context, namespace, cliPodName, channel string) ([]CommittedChaincode, error) {
+	stdout, _, err := kube.ExecCommandInPod(ctx, cliPodName, namespace,
+		"peer", "lifecycle", "chaincode", "querycommitted", "-C", channel, "-O", "json",
+	)
+	if err != nil {
+		if errors.Is(err, term.ErrRemoteCmdFailed) {
+			return nil, fmt.Errorf("failed to query chaincodes committed on '%s' channel: %w", channel, err)
+		}
+
+		return nil, fmt.Errorf("failed to execute command on '%s' pod: %w", cliPodName, err)
+	}
+
+	var (
+		chaincodes []CommittedChaincode
+		result     struct {
+			Definitions []struct {
+				Name     string `json:"name"`
+				Version  string `json:"version"`
+				Sequence int    `json:"sequence"`
+			} `json:"chaincode_definitions"`
+		}
+	)
+
+	if err = json.NewDecoder(stdout).Decode(&result); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to decode chaincodes committed on '%s' channel: %w", channel, err)
+	}
+
+	for _, definition := range result.Definitions {
+		chaincodes = append(chaincodes, CommittedChaincode{
+			Name:     definition.Name,
+			Version:  definition.Version,
+			Sequence: definition.Sequence,
+		})
+	}
+
+	return chaincodes, nil
+}
@@ -0,0 +1,300 @@
+package fabric
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/timoth-y/fabnctl/pkg/helm"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/term"
+	"helm.sh/helm/v3/pkg/release"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	// NetworkStatus describes live state of the deployed network components.
+	NetworkStatus struct {
+		Namespace  string            `json:"namespace"`
+		Time       time.Time         `json:"time"`
+		Components []ComponentStatus `json:"components"`
+		Peers      []PeerStatus      `json:"peers"`
+	}
+
+	// ComponentStatus describes state of the single network component pod.
+	ComponentStatus struct {
+		Kind     string `json:"kind"`
+		Org      string `json:"org,omitempty"`
+		Peer     string `json:"peer,omitempty"`
+		Name     string `json:"name,omitempty"`
+		Pod      string `json:"pod"`
+		Phase    string `json:"phase"`
+		Ready    bool   `json:"ready"`
+		Restarts int32  `json:"restarts"`
+		Image    string `json:"image"`
+		Release  string `json:"release"`
+		Revision int    `json:"revision"`
+	}
+
+	// PeerStatus describes channels joined by the organization peer.
+	PeerStatus struct {
+		Org      string          `json:"org"`
+		Peer     string          `json:"peer"`
+		Channels []ChannelStatus `json:"channels,omitempty"`
+		Error    string          `json:"error,omitempty"`
+	}
+
+	// ChannelStatus describes chaincodes committed on the channel.
+	ChannelStatus struct {
+		ChannelID  string               `json:"channelID"`
+		Chaincodes []CommittedChaincode `json:"chaincodes,omitempty"`
+	}
+
+	// CommittedChaincode describes chaincode definition committed on the channel.
+	CommittedChaincode struct {
+		Name     string `json:"name"`
+		Version  string `json:"version"`
+		Sequence int    `json:"sequence"`
+	}
+)
+
+// podKinds maps 'fabnctl/cid' pod labels of the peer chart to the component kinds.
+var podKinds = map[string]string{
+	"org.peer":         "peer",
+	"org-peer-cli":     "cli",
+	"org-peer-couchdb": "couchdb",
+	"org-ca":           "ca",
+}
+
+// Status defines methods for inspecting live state of the network deployment.
+type Status struct {
+	*sharedArgs
+}
+
+// NewStatus constructs new Status instance.
+func NewStatus(options ...SharedOption) (*Status, error) {
+	var args = &sharedArgs{
+		arch:          "amd64",
+		kubeNamespace: "network",
+		logger:        term.NewLogger(),
+		chartsPath:    "./network-config.yaml",
+	}
+
+	for i := range options {
+		options[i](args)
+	}
+
+	if len(args.initErrors) > 0 {
+		return nil, args.Error()
+	}
+
+	return &Status{
+		sharedArgs: args,
+	}, nil
+}
+
+// Collect gathers state of the orderer, peer, CA, CouchDB, CLI and chaincode pods deployed with helm releases,
+// along with channels joined by each peer and chaincodes committed on them.
+// Only releases of the network on the configured domain are reported, if it's set.
+func (s *Status) Collect(ctx context.Context) (*NetworkStatus, error) {
+	var status = &NetworkStatus{
+		Namespace: s.kubeNamespace,
+		Time:      time.Now(),
+	}
+
+	releases, err := helm.ListReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Name < releases[j].Name
+	})
+
+	for _, rel := range domainReleases(releases, s.kubeNamespace, s.domain) {
+
+		var component = ComponentStatus{
+			Kind:     rel.Chart.Metadata.Name,
+			Release:  rel.Name,
+			Revision: rel.Version,
+		}
+
+		switch component.Kind {
+		case "orderer":
+			component.Name = rel.Name
+		case "peer":
+			if config, ok := rel.Config["config"].(map[string]interface{}); ok {
+				component.Org, _ = config["mspID"].(string)
+			}
+			component.Peer = strings.TrimSuffix(rel.Name, "-"+component.Org)
+		case "chaincode":
+			component.Org, _ = rel.Config["org"].(string)
+			component.Peer, _ = rel.Config["peer"].(string)
+			component.Name, _ = rel.Config["chaincode"].(string)
+		default:
+			continue
+		}
+
+		pods, err := s.releasePods(ctx, rel.Manifest)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pod := range pods {
+			var podStatus = component
+
+			if kind, ok := podKinds[pod.Labels["fabnctl/cid"]]; ok {
+				podStatus.Kind = kind
+			}
+
+			podStatus.Pod = pod.Name
+			podStatus.Phase = string(pod.Status.Phase)
+			podStatus.Image = imageTag(pod)
+			podStatus.Ready = len(pod.Status.ContainerStatuses) != 0
+
+			for _, container := range pod.Status.ContainerStatuses {
+				podStatus.Ready = podStatus.Ready && container.Ready
+				podStatus.Restarts += container.RestartCount
+			}
+
+			status.Components = append(status.Components, podStatus)
+
+			if podStatus.Kind == "cli" {
+				status.Peers = append(status.Peers, s.peerStatus(ctx, podStatus))
+			}
+		}
+	}
+
+	return status, nil
+}
+
+// domainReleases filters orderer, peer and chaincode `releases` installed in the `namespace` for the network
+// on the `domain`, so that other networks deployed in the same namespace aren't reported. Empty `domain` matches any.
+// Chaincode releases don't define domain, thus they're matched by the peer they're installed on.
+func domainReleases(releases []*release.Release, namespace, domain string) []*release.Release {
+	var (
+		filtered []*release.Release
+		peers    = make(map[string]bool)
+	)
+
+	var matches = func(rel *release.Release) bool {
+		if rel.Namespace != namespace || rel.Chart == nil || rel.Chart.Metadata == nil {
+			return false
+		}
+
+		if len(domain) == 0 {
+			return true
+		}
+
+		if rel.Chart.Metadata.Name == "chaincode" {
+			return peers[fmt.Sprintf("%v-%v", rel.Config["peer"], rel.Config["org"])]
+		}
+
+		releaseDomain, _ := rel.Config["domain"].(string)
+
+		return releaseDomain == domain
+	}
+
+	for _, rel := range releases {
+		if rel.Chart != nil && rel.Chart.Metadata != nil && rel.Chart.Metadata.Name == "peer" && matches(rel) {
+			peers[rel.Name] = true
+		}
+	}
+
+	for _, rel := range releases {
+		if matches(rel) {
+			filtered = append(filtered, rel)
+		}
+	}
+
+	return filtered
+}
+
+// releasePods retrieves pods of the deployments rendered in the release `manifest`.
+func (s *Status) releasePods(ctx context.Context, manifest string) ([]corev1.Pod, error) {
+	var pods []corev1.Pod
+
+	for _, name := range helm.ManifestResources(manifest, "Deployment") {
+		deployment, err := kube.Client.AppsV1().Deployments(s.kubeNamespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to get '%s' deployment: %w", name, err)
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse selector of '%s' deployment: %w", name, err)
+		}
+
+		list, err := kube.Client.CoreV1().Pods(s.kubeNamespace).List(ctx, metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods of '%s' deployment: %w", name, err)
+		}
+
+		pods = append(pods, list.Items...)
+	}
+
+	return pods, nil
+}
+
+// peerStatus queries channels joined by the peer and chaincodes committed on them using its `cli` pod.
+// Query failures are reported in PeerStatus, so that a single unhealthy peer doesn't hide the rest of the network.
+func (s *Status) peerStatus(ctx context.Context, cli ComponentStatus) PeerStatus {
+	var status = PeerStatus{
+		Org:  cli.Org,
+		Peer: cli.Peer,
+	}
+
+	if !cli.Ready {
+		status.Error = "CLI pod isn't ready"
+		return status
+	}
+
+	channels, err := joinedChannels(ctx, s.kubeNamespace, cli.Pod)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	for _, channel := range channels {
+		chaincodes, err := committedChaincodes(ctx, s.kubeNamespace, cli.Pod, channel)
+		if err != nil {
+			status.Error = err.Error()
+		}
+
+		status.Channels = append(status.Channels, ChannelStatus{
+			ChannelID:  channel,
+			Chaincodes: chaincodes,
+		})
+	}
+
+	return status
+}
+
+// imageTag retrieves tag of the image of the first `pod` container.
+func imageTag(pod corev1.Pod) string {
+	if len(pod.Spec.Containers) == 0 {
+		return ""
+	}
+
+	var image = pod.Spec.Containers[0].Image
+
+	if i := strings.LastIndex(image, "@"); i != -1 {
+		return image[i+1:]
+	}
+
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+
+	return "latest"
+}
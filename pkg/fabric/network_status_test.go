@@ -0,0 +1,73 @@
+package fabric
+
+import (
+	"reflect"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+func TestDomainReleases(t *testing.T) {
+	var releases = []*release.Release{
+		testRelease("assets-peer0-org1", "chaincode", "network", map[string]interface{}{
+			"org": "org1", "peer": "peer0", "chaincode": "assets",
+		}),
+		testRelease("assets-peer0-org3", "chaincode", "network", map[string]interface{}{
+			"org": "org3", "peer": "peer0", "chaincode": "assets",
+		}),
+		testRelease("orderer", "orderer", "network", map[string]interface{}{"domain": "example.com"}),
+		testRelease("orderer-another", "orderer", "network", map[string]interface{}{"domain": "another.com"}),
+		testRelease("peer0-org1", "peer", "network", map[string]interface{}{"domain": "example.com"}),
+		testRelease("peer0-org2", "peer", "staging", map[string]interface{}{"domain": "example.com"}),
+		testRelease("peer0-org3", "peer", "network", map[string]interface{}{"domain": "another.com"}),
+		{Name: "broken", Namespace: "network"},
+	}
+
+	var tests = []struct {
+		name     string
+		domain   string
+		expected []string
+	}{
+		{
+			name:     "domain",
+			domain:   "example.com",
+			expected: []string{"assets-peer0-org1", "orderer", "peer0-org1"},
+		},
+		{
+			name:     "another domain",
+			domain:   "another.com",
+			expected: []string{"assets-peer0-org3", "orderer-another", "peer0-org3"},
+		},
+		{
+			name:   "any domain",
+			domain: "",
+			expected: []string{
+				"assets-peer0-org1", "assets-peer0-org3", "orderer", "orderer-another", "peer0-org1", "peer0-org3",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+
+			for _, rel := range domainReleases(releases, "network", tt.domain) {
+				names = append(names, rel.Name)
+			}
+
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("expected %v releases, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func testRelease(name, chartName, namespace string, values map[string]interface{}) *release.Release {
+	return &release.Release{
+		Name:      name,
+		Namespace: namespace,
+		Chart:     &chart.Chart{Metadata: &chart.Metadata{Name: chartName}},
+		Config:    values,
+	}
+}
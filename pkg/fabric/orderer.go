@@ -3,8 +3,8 @@ package fabric
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
 	"path"
+	"strings"
 
 	helmclient "github.com/mittwald/go-helm-client"
 	"github.com/spf13/viper"
@@ -43,10 +43,10 @@ func NewOrderer(name string, options ...SharedOption) (*Orderer, error) {
 	}, nil
 }
 
-func (o *Orderer) Install(ctx context.Context) error {
+// createTransportSecrets creates or updates secrets with orderer transport TLS key pair and CA certificate used by its ingress.
+func (o *Orderer) createTransportSecrets(ctx context.Context) error {
 	var (
 		tlsDir   = path.Join(
-			fmt.Sprintf(".crypto-config.%s", o.domain),
 			"ordererOrganizations", o.domain,
 			"orderers", fmt.Sprintf("%s.%s", o.hostname, o.domain),
 			"tls",
@@ -59,19 +59,19 @@ func (o *Orderer) Install(ctx context.Context) error {
 	)
 
 	// Retrieve orderer transport TLS private key:
-	pkPayload, err := ioutil.ReadFile(pkPath)
+	pkPayload, err := o.readCrypto(ctx, pkPath)
 	if err != nil {
 		return fmt.Errorf("failed to read private key from path: %s: %w", pkPath, err)
 	}
 
 	// Retrieve orderer transport TLS cert:
-	certPayload, err := ioutil.ReadFile(certPath)
+	certPayload, err := o.readCrypto(ctx, certPath)
 	if err != nil {
 		return fmt.Errorf("failed to read certificate identity from path: %s: %w", certPath, err)
 	}
 
 	// Retrieve orderer transport CA cert:
-	caPayload, err := ioutil.ReadFile(caPath)
+	caPayload, err := o.readCrypto(ctx, caPath)
 	if err != nil {
 		return fmt.Errorf("failed to read certificate CA from path: %s: %w", caPath, err)
 	}
@@ -119,11 +119,22 @@ func (o *Orderer) Install(ctx context.Context) error {
 
 	o.logger.Successf("Secret '%s' successfully created", caSecretName)
 
+	return nil
+}
+
+func (o *Orderer) Install(ctx context.Context) error {
+	// Enrolled identities are issued by the CA deployed along with orderer, so secrets are created once it's installed:
+	if !o.enrollment {
+		if err := o.createTransportSecrets(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Preparing additional values for chart installation:
 	var (
 		values = make(map[string]interface{})
 		chartSpec = &helmclient.ChartSpec{
-			ReleaseName: "orderer",
+			ReleaseName: o.hostname,
 			ChartName:   path.Join(o.chartsPath, "orderer"),
 			Namespace:   o.kubeNamespace,
 			Wait:        true,
@@ -139,6 +150,23 @@ func (o *Orderer) Install(ctx context.Context) error {
 	}
 
 	values["domain"] = o.domain
+	if caValues, ok := values["ca"].(map[string]interface{}); ok {
+		caValues["enabled"] = o.enrollment
+	} else {
+		values["ca"] = map[string]interface{}{
+			"enabled": o.enrollment,
+		}
+	}
+
+	if configValues, ok := values["config"].(map[string]interface{}); ok {
+		configValues["domain"] = o.domain
+		configValues["hostname"] = o.hostname
+	} else {
+		values["config"] = map[string]interface{}{
+			"domain":   o.domain,
+			"hostname": o.hostname,
+		}
+	}
 
 	valuesYaml, err := yaml.Marshal(values)
 	if err != nil {
@@ -156,11 +184,75 @@ func (o *Orderer) Install(ctx context.Context) error {
 			return fmt.Errorf("failed to install orderer helm chart: %w", err)
 		}
 		return nil
-	}, "Installing orderer chart", "Chart 'orderer/orderer' installed successfully"); err != nil {
-		return nil
+	}, fmt.Sprintf("Installing '%s' orderer chart", o.hostname),
+		fmt.Sprintf("Chart 'orderer/%s' installed successfully", o.hostname),
+	); err != nil {
+		return err
+	}
+
+	if o.enrollment {
+		if err = o.enroll(ctx); err != nil {
+			return err
+		}
+
+		if err = o.createTransportSecrets(ctx); err != nil {
+			return err
+		}
+
+		if err = o.restartNode(ctx, o.hostname, "enrolled crypto material"); err != nil {
+			return err
+		}
 	}
 
 	o.logger.Successf("Orderer service successfully deployed on %s.%s!", o.hostname, o.domain)
 
 	return nil
 }
+
+// enroll registers and enrolls orderer and its TLS identities against the CA deployed along with it.
+// Admin identity of the orderer organization isn't enrolled, since its MSP doesn't enable node OUs
+// and thus recognizes only admins listed in its 'admincerts'.
+func (o *Orderer) enroll(ctx context.Context) error {
+	var (
+		ordererHost = fmt.Sprintf("%s.%s", o.hostname, o.domain)
+		ordererDir  = path.Join(
+			"ordererOrganizations", o.domain,
+			"orderers", ordererHost,
+		)
+		caHost = strings.ReplaceAll(o.hostname, ".", "-")
+		mount  = artifactsMount{
+			selector: fmt.Sprintf("name=%s,instance=%s", o.hostname, o.hostname),
+			dir:      "/var/hyperledger/orderer",
+		}
+	)
+
+	return o.enrollIdentities(ctx, orgCA{
+		selector:   fmt.Sprintf("fabnctl/app=ca.%s", o.hostname),
+		deployment: fmt.Sprintf("ca.%s", o.hostname),
+		caName:     fmt.Sprintf("ca-%s", caHost),
+		tlsCAName:  fmt.Sprintf("tlsca-%s", caHost),
+		mspDir:     path.Join("ordererOrganizations", o.domain, "msp"),
+	},
+		caEnrollment{name: ordererHost, kind: "orderer", dir: ordererDir, mount: mount},
+		caEnrollment{name: ordererHost, kind: "orderer", dir: ordererDir, mount: mount, tls: true, hosts: []string{
+			ordererHost, o.hostname, "localhost",
+		}},
+	)
+}
+
+// RotateTLS re-issues orderer TLS certificate with the orderer organization TLS CA, replaces it in the artifacts volume
+// and in the transport secrets, then restarts the orderer, so that it serves renewed certificate.
+// Private key is kept, since Raft consenters are pinned by their TLS certificates in channel configs,
+// while certificate renewed with the same public key is still recognized by other consenters.
+func (o *Orderer) RotateTLS(ctx context.Context) error {
+	var orgDir = path.Join("ordererOrganizations", o.domain)
+
+	return o.rotateTLS(ctx, tlsRotation{
+		tlsDir:     path.Join(orgDir, "orderers", fmt.Sprintf("%s.%s", o.hostname, o.domain), "tls"),
+		caDir:      path.Join(orgDir, "tlsca"),
+		selector:   fmt.Sprintf("name=%s,instance=%s", o.hostname, o.hostname),
+		mountDir:   "/var/hyperledger/orderer/tls",
+		deployment: o.hostname,
+		keepKey:    true,
+	}, o.createTransportSecrets)
+}
@@ -0,0 +1,90 @@
+package fabric
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// ordererConnectionErrors are the gRPC dial and transport errors, which peer CLI prints to stderr
+// when it can't reach the orderer endpoint at all. Only these trigger failover to the next orderer node,
+// while any other failure, e.g. rejected transaction or missing channel, is returned as is.
+// The list must be kept in sync with the Fabric releases the charts use:
+//   - "connection refused": orderer service has no ready endpoints;
+//   - "error while dialing": gRPC dial failed, e.g. due to DNS or TLS handshake error;
+//   - "context deadline exceeded": connection wasn't established within the peer CLI timeout.
+var ordererConnectionErrors = []string{
+	"connection refused",
+	"error while dialing",
+	"context deadline exceeded",
+}
+
+// ordererHostnames determines hostnames of the orderer cluster nodes,
+// falling back to the single orderer configured with 'fabric.orderer_hostname_name' option.
+func (a *sharedArgs) ordererHostnames() []string {
+	if len(a.orderers) != 0 {
+		return a.orderers
+	}
+
+	if hostnames := viper.GetStringSlice("fabric.orderer_hostnames"); len(hostnames) != 0 {
+		return hostnames
+	}
+
+	return []string{viper.GetString("fabric.orderer_hostname_name")}
+}
+
+// ordererAddresses forms public endpoint addresses of the orderer cluster nodes.
+func (a *sharedArgs) ordererAddresses() []string {
+	var addresses []string
+
+	for _, hostname := range a.ordererHostnames() {
+		addresses = append(addresses, fmt.Sprintf("%s.%s:443", hostname, a.domain))
+	}
+
+	return addresses
+}
+
+// execWithOrdererFailover executes shell command formed by `form` for the orderer endpoint in the `cliPodName` pod.
+// When command fails because the orderer can't be reached, it's retried with the next endpoint of the orderer cluster.
+func (a *sharedArgs) execWithOrdererFailover(
+	ctx context.Context,
+	cliPodName string,
+	form func(orderer string) string,
+) (stdout io.Reader, stderr io.Reader, err error) {
+	var addresses = a.ordererAddresses()
+
+	for i, address := range addresses {
+		if stdout, stderr, err = kube.ExecShellInPod(ctx, cliPodName, a.kubeNamespace, form(address)); err == nil {
+			return stdout, stderr, nil
+		}
+
+		if i == len(addresses)-1 || !errors.Is(err, term.ErrRemoteCmdFailed) || stderr == nil {
+			return stdout, stderr, err
+		}
+
+		var stderrBuffer bytes.Buffer
+		if _, copyErr := io.Copy(&stderrBuffer, stderr); copyErr != nil || !isOrdererConnectionError(stderrBuffer.String()) {
+			return stdout, &stderrBuffer, err
+		}
+	}
+
+	return stdout, stderr, err
+}
+
+// isOrdererConnectionError determines whether peer CLI `stderr` contains any of the ordererConnectionErrors.
+func isOrdererConnectionError(stderr string) bool {
+	for _, marker := range ordererConnectionErrors {
+		if strings.Contains(stderr, marker) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,119 @@
+package fabric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// peerTarget defines organization peer targeted by the installation.
+type peerTarget struct {
+	org  string
+	peer string
+}
+
+// String returns peer host prefix, which is used as the target's progress line key, e.g. 'peer0.org1'.
+func (t peerTarget) String() string {
+	return fmt.Sprintf("%s.%s", t.peer, t.org)
+}
+
+// appendPeerTargets appends `peers` of the `org` to `targets` preserving the order they were given in,
+// so that installation is performed and reported in the same order on every run. Duplicates are omitted.
+func appendPeerTargets(targets []peerTarget, org string, peers ...string) []peerTarget {
+	for _, peer := range peers {
+		var target = peerTarget{org: org, peer: peer}
+
+		if !containsPeerTarget(targets, target) {
+			targets = append(targets, target)
+		}
+	}
+
+	return targets
+}
+
+func containsPeerTarget(targets []peerTarget, target peerTarget) bool {
+	for i := range targets {
+		if targets[i] == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// forEachParallel calls `fn` for each index in range of `count` using pool of workers,
+// which size is bounded by the configured parallelism. Once any call fails,
+// the context passed to the rest of them is canceled, unless continue on error is set. Returns errors by index.
+func (a *sharedArgs) forEachParallel(
+	ctx context.Context,
+	count int,
+	fn func(ctx context.Context, i int) error,
+) []error {
+	var (
+		errs    = make([]error, count)
+		jobs    = make(chan int)
+		wg      sync.WaitGroup
+		workers = a.parallelism
+	)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	if workers > count {
+		workers = count
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+
+				if errs[i] = fn(ctx, i); errs[i] != nil && !a.continueOnError {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return errs
+}
+
+// firstError returns the first error from `errs`, preferring the actual failures over cancellations caused by them.
+func firstError(errs []error) error {
+	var canceled error
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if !errors.Is(err, context.Canceled) {
+			return err
+		}
+
+		if canceled == nil {
+			canceled = err
+		}
+	}
+
+	return canceled
+}
@@ -0,0 +1,131 @@
+package fabric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachParallelBound(t *testing.T) {
+	var (
+		args    = &sharedArgs{parallelism: 3, continueOnError: true}
+		active  int32
+		maximum int32
+	)
+
+	errs := args.forEachParallel(context.Background(), 20, func(_ context.Context, i int) error {
+		var current = atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+
+		for {
+			var observed = atomic.LoadInt32(&maximum)
+			if current <= observed || atomic.CompareAndSwapInt32(&maximum, observed, current) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+
+		if i%2 == 1 {
+			return fmt.Errorf("failed %d", i)
+		}
+
+		return nil
+	})
+
+	if maximum > 3 {
+		t.Errorf("expected at most 3 workers running at once, got %d", maximum)
+	}
+
+	if len(errs) != 20 {
+		t.Fatalf("expected 20 results, got %d", len(errs))
+	}
+
+	// Results must stay at the index of the call, no matter in which order calls complete:
+	for i, err := range errs {
+		if i%2 == 0 && err != nil {
+			t.Errorf("expected no error at %d, got: %v", i, err)
+		}
+
+		if i%2 == 1 && (err == nil || err.Error() != fmt.Sprintf("failed %d", i)) {
+			t.Errorf("expected 'failed %d' error at %d, got: %v", i, i, err)
+		}
+	}
+}
+
+func TestForEachParallelCancel(t *testing.T) {
+	var (
+		args   = &sharedArgs{parallelism: 2}
+		failed = errors.New("failed")
+		calls  int32
+	)
+
+	errs := args.forEachParallel(context.Background(), 6, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+
+		if i == 0 {
+			return failed
+		}
+
+		// The rest of calls are blocked until the first failure cancels them:
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+			return fmt.Errorf("call %d wasn't canceled", i)
+		}
+	})
+
+	if !errors.Is(errs[0], failed) {
+		t.Errorf("expected first call to fail, got: %v", errs[0])
+	}
+
+	for i, err := range errs[1:] {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected call %d to be canceled, got: %v", i+1, err)
+		}
+	}
+
+	// Pending calls must be skipped once the context is canceled:
+	if calls > 2 {
+		t.Errorf("expected at most 2 calls, got %d", calls)
+	}
+
+	if err := firstError(errs); !errors.Is(err, failed) {
+		t.Errorf("expected actual failure to be preferred over cancellations, got: %v", err)
+	}
+}
+
+func TestForEachParallelContinueOnError(t *testing.T) {
+	var (
+		args  = &sharedArgs{parallelism: 1, continueOnError: true}
+		calls int32
+	)
+
+	errs := args.forEachParallel(context.Background(), 4, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if i == 0 {
+			return errors.New("failed")
+		}
+
+		return nil
+	})
+
+	if calls != 4 {
+		t.Errorf("expected all 4 calls, got %d", calls)
+	}
+
+	for i, err := range errs[1:] {
+		if err != nil {
+			t.Errorf("expected call %d to succeed after failure, got: %v", i+1, err)
+		}
+	}
+}
@@ -3,8 +3,8 @@ package fabric
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
 	"path"
+	"strings"
 
 	helmclient "github.com/mittwald/go-helm-client"
 	"github.com/spf13/viper"
@@ -36,6 +36,12 @@ func NewPeer(org, name string, options ...PeerOption) (*Peer, error) {
 		options[i](args)
 	}
 
+	if args.enrollment && !args.installCA {
+		args.initErrors = append(args.initErrors,
+			fmt.Errorf("enrollment requires CA to be deployed along with peer"),
+		)
+	}
+
 	if len(args.initErrors) > 0 {
 		return nil, args.Error()
 	}
@@ -47,10 +53,10 @@ func NewPeer(org, name string, options ...PeerOption) (*Peer, error) {
 	}, nil
 }
 
-func (p *Peer) Install(ctx context.Context) error {
+// createTransportSecrets creates or updates secrets with peer transport TLS key pair and CA certificate used by its ingress.
+func (p *Peer) createTransportSecrets(ctx context.Context) error {
 	var (
 		tlsDir = path.Join(
-			fmt.Sprintf(".crypto-config.%s", p.domain),
 			"peerOrganizations", fmt.Sprintf("%s.org.%s", p.org, p.domain),
 			"peers", fmt.Sprintf("%s.%s.org.%s", p.peer, p.org, p.domain),
 			"tls",
@@ -63,19 +69,19 @@ func (p *Peer) Install(ctx context.Context) error {
 	)
 
 	// Retrieve orderer transport TLS private key:
-	pkPayload, err := ioutil.ReadFile(pkPath)
+	pkPayload, err := p.readCrypto(ctx, pkPath)
 	if err != nil {
 		return fmt.Errorf("failed to read private key from path: %s: %w", pkPath, err)
 	}
 
 	// Retrieve orderer transport TLS cert:
-	certPayload, err := ioutil.ReadFile(certPath)
+	certPayload, err := p.readCrypto(ctx, certPath)
 	if err != nil {
 		return fmt.Errorf("failed to read certificate identity from path: %s: %w", certPath, err)
 	}
 
 	// Retrieve orderer transport CA cert:
-	caPayload, err := ioutil.ReadFile(caPath)
+	caPayload, err := p.readCrypto(ctx, caPath)
 	if err != nil {
 		return fmt.Errorf("failed to read certificate CA from path: %s: %w", caPath, err)
 	}
@@ -123,6 +129,17 @@ func (p *Peer) Install(ctx context.Context) error {
 
 	p.logger.Successf("Secret '%s' successfully created", caSecretName)
 
+	return nil
+}
+
+func (p *Peer) Install(ctx context.Context) error {
+	// Enrolled identities are issued by the CA deployed along with peer, so secrets are created once it's installed:
+	if !p.enrollment {
+		if err := p.createTransportSecrets(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Preparing additional values for chart installation:
 	var (
 		values = make(map[string]interface{})
@@ -152,11 +169,13 @@ func (p *Peer) Install(ctx context.Context) error {
 	}
 
 	if configValues, ok := values["config"].(map[string]interface{}); ok {
+		configValues["peer"] = p.peer
 		configValues["mspID"] = p.org
 		configValues["domain"] = p.domain
 		configValues["hostname"] = fmt.Sprintf("%s.org", p.org)
 	} else {
 		values["config"] = map[string]interface{}{
+			"peer":     p.peer,
 			"mspID":    p.org,
 			"domain":   p.domain,
 			"hostname": fmt.Sprintf("%s.org", p.org),
@@ -165,9 +184,11 @@ func (p *Peer) Install(ctx context.Context) error {
 
 	if ordererValues, ok := values["orderer"].(map[string]interface{}); ok {
 		ordererValues["domain"] = p.domain
+		ordererValues["hostname"] = p.ordererHostnames()[0]
 	} else {
 		values["orderer"] = map[string]interface{}{
-			"domain": p.domain,
+			"domain":   p.domain,
+			"hostname": p.ordererHostnames()[0],
 		}
 	}
 
@@ -190,10 +211,75 @@ func (p *Peer) Install(ctx context.Context) error {
 	}, fmt.Sprintf("Installing 'peer/%s-%s' chart", p.peer, p.org),
 		fmt.Sprintf("Chart 'peer/%s' installed successfully", chartSpec.ReleaseName),
 	); err != nil {
-		return nil
+		return err
+	}
+
+	if p.enrollment {
+		if err = p.enroll(ctx); err != nil {
+			return err
+		}
+
+		if err = p.createTransportSecrets(ctx); err != nil {
+			return err
+		}
+
+		if err = p.restartNode(ctx, fmt.Sprintf("%s.%s.org", p.peer, p.org), "enrolled crypto material"); err != nil {
+			return err
+		}
 	}
 
 	p.logger.Successf("Peer successfully deployed on %s.%s.org.%s!", p.peer, p.org, p.domain)
 
 	return nil
 }
+
+// enroll registers and enrolls peer, its TLS and organization admin identities against the organization CA.
+func (p *Peer) enroll(ctx context.Context) error {
+	var (
+		orgHost   = fmt.Sprintf("%s.org.%s", p.org, p.domain)
+		peerHost  = fmt.Sprintf("%s.%s", p.peer, orgHost)
+		orgDir    = path.Join("peerOrganizations", orgHost)
+		peerDir   = path.Join(orgDir, "peers", peerHost)
+		adminName = fmt.Sprintf("Admin@%s", orgHost)
+		adminDir  = path.Join(orgDir, "users", adminName)
+		caHost    = strings.ReplaceAll(fmt.Sprintf("%s.org", p.org), ".", "-")
+		org       = peerOrgCA(p.org)
+		// Peer mounts its own crypto material, while admin one is used from the cli pod mounting the whole volume:
+		peerMount = artifactsMount{
+			selector: fmt.Sprintf("fabnctl/app=%s.%s.org", p.peer, p.org),
+			dir:      "/etc/hyperledger/fabric",
+		}
+		adminMount = artifactsMount{
+			selector: fmt.Sprintf("fabnctl/app=cli.%s.%s.org", p.peer, p.org),
+			dir:      path.Join("/opt/gopath/src/github.com/hyperledger/fabric/peer/crypto-config", adminDir),
+		}
+	)
+
+	org.mspDir = path.Join(orgDir, "msp")
+
+	return p.enrollIdentities(ctx, org,
+		caEnrollment{name: peerHost, kind: "peer", dir: peerDir, mount: peerMount},
+		caEnrollment{name: peerHost, kind: "peer", dir: peerDir, mount: peerMount, tls: true, hosts: []string{
+			peerHost, p.peer, fmt.Sprintf("%s-%s", p.peer, caHost), "localhost",
+		}},
+		caEnrollment{name: adminName, kind: "admin", dir: adminDir, mount: adminMount},
+	)
+}
+
+// RotateTLS re-issues peer TLS key pair with the organization TLS CA, replaces it in the artifacts volume
+// and in the transport secrets, then restarts the peer, so that it serves renewed certificate.
+func (p *Peer) RotateTLS(ctx context.Context) error {
+	var (
+		orgHost  = fmt.Sprintf("%s.org.%s", p.org, p.domain)
+		orgDir   = path.Join("peerOrganizations", orgHost)
+		peerName = fmt.Sprintf("%s.%s.org", p.peer, p.org)
+	)
+
+	return p.rotateTLS(ctx, tlsRotation{
+		tlsDir:     path.Join(orgDir, "peers", fmt.Sprintf("%s.%s", p.peer, orgHost), "tls"),
+		caDir:      path.Join(orgDir, "tlsca"),
+		selector:   fmt.Sprintf("fabnctl/app=%s", peerName),
+		mountDir:   "/etc/hyperledger/fabric/tls",
+		deployment: peerName,
+	}, p.createTransportSecrets)
+}
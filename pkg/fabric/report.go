@@ -0,0 +1,155 @@
+package fabric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/timoth-y/fabnctl/pkg/term"
+)
+
+// resultStatus defines outcome of the operation performed on a single target.
+type resultStatus int
+
+const (
+	resultPending resultStatus = iota
+	resultSucceeded
+	resultSkipped
+	resultFailed
+)
+
+// String returns human readable name of the status.
+func (s resultStatus) String() string {
+	switch s {
+	case resultSucceeded:
+		return "succeeded"
+	case resultSkipped:
+		return "skipped"
+	case resultFailed:
+		return "failed"
+	default:
+		return "pending"
+	}
+}
+
+// targetResult describes outcome of the operation performed on a single target, along with its reason.
+type targetResult struct {
+	key    string
+	status resultStatus
+	reason string
+}
+
+// resultReport collects outcomes of the operation performed on multiple targets, e.g. peers or organizations,
+// in the order in which targets were given.
+type resultReport struct {
+	operation string
+	results   []targetResult
+}
+
+// newResultReport constructs new resultReport of the `operation` with pending result for each of the `keys`.
+func newResultReport(operation string, keys ...string) *resultReport {
+	var report = &resultReport{
+		operation: operation,
+		results:   make([]targetResult, len(keys)),
+	}
+
+	for i := range keys {
+		report.results[i] = targetResult{key: keys[i]}
+	}
+
+	return report
+}
+
+// succeed marks `i`-th target as succeeded.
+func (r *resultReport) succeed(i int, reason string) {
+	r.results[i].status, r.results[i].reason = resultSucceeded, reason
+}
+
+// skip marks `i`-th target as skipped.
+func (r *resultReport) skip(i int, reason string) {
+	r.results[i].status, r.results[i].reason = resultSkipped, reason
+}
+
+// fail marks `i`-th target as failed with `err`.
+func (r *resultReport) fail(i int, err error) {
+	r.results[i].status, r.results[i].reason = resultFailed, err.Error()
+}
+
+// collect completes the report with `errs` returned by forEachParallel:
+// targets, which were canceled before finishing, are considered skipped.
+func (r *resultReport) collect(errs []error) {
+	for i, err := range errs {
+		if err == nil {
+			if r.results[i].status == resultPending {
+				r.succeed(i, "")
+			}
+
+			continue
+		}
+
+		if errors.Is(err, context.Canceled) {
+			r.skip(i, "canceled due to failure of another target")
+		} else {
+			r.fail(i, err)
+		}
+	}
+}
+
+// keys lists keys of the targets having given `status`.
+func (r *resultReport) keys(status resultStatus) []string {
+	var keys []string
+
+	for _, result := range r.results {
+		if result.status == status {
+			keys = append(keys, result.key)
+		}
+	}
+
+	return keys
+}
+
+// succeeded checks whether `i`-th target has succeeded.
+func (r *resultReport) succeeded(i int) bool {
+	return r.results[i].status == resultSucceeded
+}
+
+// failure returns error listing failed targets, or nil if there are none.
+func (r *resultReport) failure() error {
+	if failed := r.keys(resultFailed); len(failed) != 0 {
+		return fmt.Errorf("%s failed for %d of %d targets: %s",
+			r.operation, len(failed), len(r.results), strings.Join(failed, ", "),
+		)
+	}
+
+	return nil
+}
+
+// print displays summary of the report followed by result of each target with its reason.
+func (r *resultReport) print(logger *term.Logger) {
+	var levels = map[resultStatus]term.LogStreamLevel{
+		resultPending:   term.LogStreamInfo,
+		resultSucceeded: term.LogStreamSuccess,
+		resultSkipped:   term.LogStreamWarning,
+		resultFailed:    term.LogStreamError,
+	}
+
+	logger.Infof("Results of %s: %d succeeded, %d skipped, %d failed",
+		r.operation,
+		len(r.keys(resultSucceeded)), len(r.keys(resultSkipped)), len(r.keys(resultFailed)),
+	)
+
+	for _, result := range r.results {
+		var line = fmt.Sprintf("%s %s: %s", logger.StreamSpinners[levels[result.status]].Frames[0],
+			result.key, result.status,
+		)
+
+		if len(result.reason) != 0 {
+			line = fmt.Sprintf("%s - %s", line, strings.Join(strings.Fields(result.reason), " "))
+		}
+
+		logger.Info(line)
+	}
+
+	logger.NewLine()
+}
@@ -0,0 +1,88 @@
+package fabric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestResultReportContinueOnError(t *testing.T) {
+	var keys = []string{"peer0.org1", "peer1.org1", "peer0.org2", "peer1.org2"}
+
+	// Report must be the same on every run, no matter in which order targets are completed:
+	for run := 0; run < 3; run++ {
+		var (
+			args   = &sharedArgs{parallelism: len(keys), continueOnError: true}
+			report = newResultReport("chaincode installation", keys...)
+		)
+
+		report.collect(args.forEachParallel(context.Background(), len(keys), func(_ context.Context, i int) error {
+			switch keys[i] {
+			case "peer1.org1":
+				report.skip(i, "already installed")
+			case "peer0.org2":
+				return errors.New("peer is unreachable")
+			}
+
+			return nil
+		}))
+
+		var expected = []targetResult{
+			{key: "peer0.org1", status: resultSucceeded},
+			{key: "peer1.org1", status: resultSkipped, reason: "already installed"},
+			{key: "peer0.org2", status: resultFailed, reason: "peer is unreachable"},
+			{key: "peer1.org2", status: resultSucceeded},
+		}
+
+		if !reflect.DeepEqual(report.results, expected) {
+			t.Fatalf("run %d: expected results %+v, got %+v", run, expected, report.results)
+		}
+
+		if succeeded := report.keys(resultSucceeded); !reflect.DeepEqual(succeeded, []string{"peer0.org1", "peer1.org2"}) {
+			t.Errorf("run %d: unexpected succeeded targets: %v", run, succeeded)
+		}
+
+		if !report.succeeded(0) || report.succeeded(1) || report.succeeded(2) {
+			t.Errorf("run %d: unexpected succeeded flags: %+v", run, report.results)
+		}
+
+		var err = report.failure()
+		if err == nil || err.Error() != "chaincode installation failed for 1 of 4 targets: peer0.org2" {
+			t.Errorf("run %d: unexpected failure: %v", run, err)
+		}
+	}
+}
+
+func TestResultReportCollect(t *testing.T) {
+	var report = newResultReport("channel join", "org1", "org2", "org3", "org4")
+
+	report.skip(3, "already joined")
+	report.collect([]error{
+		nil,
+		fmt.Errorf("failed to join: %w", errors.New("forbidden")),
+		fmt.Errorf("interrupted: %w", context.Canceled),
+		nil,
+	})
+
+	var expected = []resultStatus{resultSucceeded, resultFailed, resultSkipped, resultSkipped}
+
+	for i, result := range report.results {
+		if result.status != expected[i] {
+			t.Errorf("expected '%s' to be %s, got %s", result.key, expected[i], result.status)
+		}
+	}
+
+	if report.results[2].reason != "canceled due to failure of another target" {
+		t.Errorf("unexpected reason of canceled target: %s", report.results[2].reason)
+	}
+
+	if report.results[3].reason != "already joined" {
+		t.Errorf("expected skip reason to be kept, got: %s", report.results[3].reason)
+	}
+
+	if err := newResultReport("channel join", "org1").failure(); err != nil {
+		t.Errorf("expected no failure for report without failed targets, got: %v", err)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/spf13/pflag"
+	"github.com/timoth-y/fabnctl/pkg/cryptostore"
 	"github.com/timoth-y/fabnctl/pkg/term"
 )
 
@@ -13,12 +15,19 @@ type (
 	SharedOption func(*sharedArgs)
 
 	sharedArgs struct {
-		domain        string
-		arch          string
-		configPath    string
-		chartsPath    string
-		kubeNamespace string
-		logger        *term.Logger
+		domain          string
+		arch            string
+		configPath      string
+		chartsPath      string
+		kubeNamespace   string
+		orderers        []string
+		parallelism     int
+		continueOnError bool
+		enrollment      bool
+		cryptoStoreKind string
+		cryptoStore     cryptostore.Store
+		cryptoStoreMu   sync.Mutex
+		logger          *term.Logger
 		initErrorArgs
 	}
 
@@ -113,6 +122,14 @@ func WithCustomDeployChartsFlag(flags *pflag.FlagSet, name string) SharedOption
 	}
 }
 
+// WithOrderers can be used to pass hostnames of the orderer cluster nodes,
+// which endpoints are used in turn when one of them is unavailable.
+func WithOrderers(hostnames ...string) SharedOption {
+	return func(args *sharedArgs) {
+		args.orderers = hostnames
+	}
+}
+
 // WithArch ...
 func WithArch(arch string) SharedOption {
 	return func(args *sharedArgs) {
@@ -133,6 +150,102 @@ func WithArchFlag(flags *pflag.FlagSet, name string) SharedOption {
 	}
 }
 
+// WithParallelism sets maximum number of peers processed at the same time during installation.
+func WithParallelism(parallelism int) SharedOption {
+	return func(args *sharedArgs) {
+		args.parallelism = parallelism
+	}
+}
+
+// WithParallelismFlag ...
+func WithParallelismFlag(flags *pflag.FlagSet, name string) SharedOption {
+	return func(args *sharedArgs) {
+		var err error
+
+		if args.parallelism, err = flags.GetInt(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (parallelism): %s", name, err),
+			)
+		}
+
+		if args.parallelism < 1 {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("parameter '%s' (parallelism) must be positive, got %d", name, args.parallelism),
+			)
+		}
+	}
+}
+
+// WithContinueOnError makes installation proceed with the rest of peers when some of them fail,
+// instead of stopping at the first failure.
+func WithContinueOnError(continueOnError bool) SharedOption {
+	return func(args *sharedArgs) {
+		args.continueOnError = continueOnError
+	}
+}
+
+// WithContinueOnErrorFlag ...
+func WithContinueOnErrorFlag(flags *pflag.FlagSet, name string) SharedOption {
+	return func(args *sharedArgs) {
+		var err error
+
+		if args.continueOnError, err = flags.GetBool(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (continue on error): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithEnrollment makes network nodes identities be registered and enrolled against
+// Fabric CA of their organization, instead of using ones generated by cryptogen.
+func WithEnrollment(enrollment bool) SharedOption {
+	return func(args *sharedArgs) {
+		args.enrollment = enrollment
+	}
+}
+
+// WithEnrollmentFlag ...
+func WithEnrollmentFlag(flags *pflag.FlagSet, name string) SharedOption {
+	return func(args *sharedArgs) {
+		var err error
+
+		if args.enrollment, err = flags.GetBool(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (enrollment): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithCryptoStore sets kind of the store crypto material is read from and written to,
+// one of: cryptostore.Local (default), cryptostore.Encrypted or cryptostore.Cluster.
+func WithCryptoStore(kind string) SharedOption {
+	return func(args *sharedArgs) {
+		args.cryptoStoreKind = kind
+	}
+}
+
+// WithCryptoStoreFlag ...
+func WithCryptoStoreFlag(flags *pflag.FlagSet, name string) SharedOption {
+	return func(args *sharedArgs) {
+		var err error
+
+		if args.cryptoStoreKind, err = flags.GetString(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (crypto store): %s", name, err),
+			)
+		}
+	}
+}
+
+// WithCustomCryptoStore can be used to pass custom implementation of the crypto material store.
+func WithCustomCryptoStore(store cryptostore.Store) SharedOption {
+	return func(args *sharedArgs) {
+		args.cryptoStore = store
+	}
+}
+
 // WithLogger can be used to pass custom logger for displaying commands output.
 func WithLogger(logger *term.Logger, options ...term.LoggerOption) SharedOption {
 	return func(args *sharedArgs) {
@@ -0,0 +1,113 @@
+package fabric
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/timoth-y/fabnctl/pkg/helm"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	"github.com/timoth-y/fabnctl/pkg/term"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// Teardown defines methods for removing the whole network deployment.
+type Teardown struct {
+	*sharedArgs
+}
+
+// NewTeardown constructs new Teardown instance.
+func NewTeardown(options ...SharedOption) (*Teardown, error) {
+	var args = &sharedArgs{
+		arch:          "amd64",
+		kubeNamespace: "network",
+		logger:        term.NewLogger(),
+		chartsPath:    "./network-config.yaml",
+	}
+
+	for i := range options {
+		options[i](args)
+	}
+
+	if len(args.initErrors) > 0 {
+		return nil, args.Error()
+	}
+
+	return &Teardown{
+		sharedArgs: args,
+	}, nil
+}
+
+// Run uninstalls all chaincode, peer, orderer and artifacts helm releases found in the namespace
+// in reverse to the deployment order, and then removes TLS secrets, config maps and jobs created during installation.
+func (t *Teardown) Run(ctx context.Context, options ...UninstallOption) error {
+	args, err := newUninstallArgs(options...)
+	if err != nil {
+		return err
+	}
+
+	releases, err := t.Releases()
+	if err != nil {
+		return err
+	}
+
+	for _, rel := range releases {
+		if err = t.uninstallRelease(ctx, rel.Name, args.keepData); err != nil {
+			return err
+		}
+	}
+
+	var secretsSelector = "fabnctl/cid in (orderer.tls.secret,orderer.ca.secret,peer.tls.secret,peer.ca.secret,identity)"
+	if len(t.domain) != 0 {
+		secretsSelector = fmt.Sprintf("%s,fabnctl/domain=%s", secretsSelector, t.domain)
+	}
+
+	if err = t.deleteSecrets(ctx, secretsSelector); err != nil {
+		return err
+	}
+
+	// Chaincode resources aren't bound to domain, yet all chaincode releases of the namespace are removed above:
+	if err = t.deleteSecrets(ctx, "fabnctl/cid in (chaincode.tls.secret)"); err != nil {
+		return err
+	}
+
+	if err = t.deleteConfigMaps(ctx, "fabnctl/cid in (chaincode.install.state,chaincode.history)"); err != nil {
+		return err
+	}
+
+	if err = kube.DeleteJobs(ctx, "fabnctl/cid=artifacts.wait", t.kubeNamespace); err != nil {
+		return err
+	}
+
+	t.logger.Successf("Network successfully torn down from '%s' namespace!", t.kubeNamespace)
+
+	return nil
+}
+
+// Releases retrieves helm releases of the network components installed in the namespace,
+// ordered in the way they must be uninstalled: chaincodes, peers, orderer and then artifacts.
+func (t *Teardown) Releases() ([]*release.Release, error) {
+	var (
+		ordered []*release.Release
+		byChart = make(map[string][]*release.Release)
+		charts  = []string{"chaincode", "peer", "orderer", "artifacts"}
+	)
+
+	releases, err := helm.ListReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rel := range releases {
+		if rel.Namespace != t.kubeNamespace || rel.Chart == nil || rel.Chart.Metadata == nil {
+			continue
+		}
+
+		byChart[rel.Chart.Metadata.Name] = append(byChart[rel.Chart.Metadata.Name], rel)
+	}
+
+	for _, chart := range charts {
+		ordered = append(ordered, byChart[chart]...)
+	}
+
+	return ordered, nil
+}
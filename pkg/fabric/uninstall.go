@@ -0,0 +1,226 @@
+package fabric
+
+import (
+	"context"
+	"fmt"
+
+	helmclient "github.com/mittwald/go-helm-client"
+	"github.com/spf13/pflag"
+	"github.com/timoth-y/fabnctl/pkg/helm"
+	"github.com/timoth-y/fabnctl/pkg/kube"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type (
+	// UninstallOption allows passing additional arguments for uninstalling network components.
+	UninstallOption func(*uninstallArgs)
+
+	uninstallArgs struct {
+		keepData bool
+		initErrorArgs
+	}
+)
+
+// WithKeepData can be used to keep persistent volume claims of the uninstalled components.
+func WithKeepData(keep bool) UninstallOption {
+	return func(args *uninstallArgs) {
+		args.keepData = keep
+	}
+}
+
+// WithKeepDataFlag ...
+func WithKeepDataFlag(flags *pflag.FlagSet, name string) UninstallOption {
+	return func(args *uninstallArgs) {
+		var err error
+
+		if args.keepData, err = flags.GetBool(name); err != nil {
+			args.initErrors = append(args.initErrors,
+				fmt.Errorf("failed to parse parameter '%s' (keep data): %s", name, err),
+			)
+		}
+	}
+}
+
+func newUninstallArgs(options ...UninstallOption) (*uninstallArgs, error) {
+	var args = &uninstallArgs{}
+
+	for i := range options {
+		options[i](args)
+	}
+
+	if len(args.initErrors) > 0 {
+		return nil, args.Error()
+	}
+
+	return args, nil
+}
+
+// uninstallRelease uninstalls helm release with given `name` along with persistent volume claims rendered by it,
+// unless `keepData` is set. In such case claims are annotated to be kept by helm during uninstallation.
+func (a *sharedArgs) uninstallRelease(ctx context.Context, name string, keepData bool) error {
+	history, err := helm.ReleaseHistory(name)
+	if err != nil {
+		return err
+	}
+
+	if len(history) == 0 {
+		a.logger.Infof("Release '%s' isn't installed, skipping", name)
+		return nil
+	}
+
+	var (
+		claims = helm.ManifestResources(history[0].Manifest, "PersistentVolumeClaim")
+		pvcs   = kube.Client.CoreV1().PersistentVolumeClaims(a.kubeNamespace)
+	)
+
+	if keepData {
+		var patch = []byte(`{"metadata":{"annotations":{"helm.sh/resource-policy":"keep"}}}`)
+
+		for _, claim := range claims {
+			if _, err = pvcs.Patch(ctx, claim, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil && !kerrors.IsNotFound(err) {
+				return fmt.Errorf("failed to mark '%s' persistent volume claim to be kept: %w", claim, err)
+			}
+		}
+	}
+
+	if err = a.logger.Stream(func() error {
+		if err := helm.Client.UninstallRelease(&helmclient.ChartSpec{
+			ReleaseName: name,
+			Namespace:   a.kubeNamespace,
+		}); err != nil {
+			return fmt.Errorf("failed to uninstall '%s' release: %w", name, err)
+		}
+		return nil
+	}, fmt.Sprintf("Uninstalling '%s' release", name),
+		fmt.Sprintf("Release '%s' uninstalled successfully", name),
+	); err != nil {
+		return err
+	}
+
+	for _, claim := range claims {
+		if keepData {
+			a.logger.Infof("Persistent volume claim '%s' is kept", claim)
+			continue
+		}
+
+		if err = pvcs.Delete(ctx, claim, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete '%s' persistent volume claim: %w", claim, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteSecrets deletes secrets matching given label `selector`.
+func (a *sharedArgs) deleteSecrets(ctx context.Context, selector string) error {
+	secrets, err := kube.Client.CoreV1().Secrets(a.kubeNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find secrets by '%s' selector: %w", selector, err)
+	}
+
+	for _, secret := range secrets.Items {
+		if err = kube.Client.CoreV1().Secrets(a.kubeNamespace).Delete(ctx, secret.Name, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete '%s' secret: %w", secret.Name, err)
+		}
+
+		a.logger.Successf("Secret '%s' successfully deleted", secret.Name)
+	}
+
+	return nil
+}
+
+// deleteConfigMaps deletes config maps matching given label `selector`.
+func (a *sharedArgs) deleteConfigMaps(ctx context.Context, selector string) error {
+	configMaps, err := kube.Client.CoreV1().ConfigMaps(a.kubeNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find config maps by '%s' selector: %w", selector, err)
+	}
+
+	for _, configMap := range configMaps.Items {
+		if err = kube.Client.CoreV1().ConfigMaps(a.kubeNamespace).Delete(ctx, configMap.Name, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete '%s' config map: %w", configMap.Name, err)
+		}
+
+		a.logger.Successf("Config map '%s' successfully deleted", configMap.Name)
+	}
+
+	return nil
+}
+
+// Uninstall removes orderer helm release and its transport TLS secrets.
+func (o *Orderer) Uninstall(ctx context.Context, options ...UninstallOption) error {
+	args, err := newUninstallArgs(options...)
+	if err != nil {
+		return err
+	}
+
+	if err = o.uninstallRelease(ctx, o.hostname, args.keepData); err != nil {
+		return err
+	}
+
+	if err = o.deleteSecrets(ctx, fmt.Sprintf(
+		"fabnctl/cid in (orderer.tls.secret,orderer.ca.secret),fabnctl/domain=%s,fabnctl/host=%s",
+		o.domain, o.hostname,
+	)); err != nil {
+		return err
+	}
+
+	o.logger.Successf("Orderer service successfully removed from %s.%s!", o.hostname, o.domain)
+
+	return nil
+}
+
+// Uninstall removes peer helm release and its transport TLS secrets.
+func (p *Peer) Uninstall(ctx context.Context, options ...UninstallOption) error {
+	args, err := newUninstallArgs(options...)
+	if err != nil {
+		return err
+	}
+
+	if err = p.uninstallRelease(ctx, fmt.Sprintf("%s-%s", p.peer, p.org), args.keepData); err != nil {
+		return err
+	}
+
+	if err = p.deleteSecrets(ctx, fmt.Sprintf(
+		"fabnctl/cid in (peer.tls.secret,peer.ca.secret),fabnctl/domain=%s,fabnctl/host=%s.%s.org",
+		p.domain, p.peer, p.org,
+	)); err != nil {
+		return err
+	}
+
+	p.logger.Successf("Peer successfully removed from %s.%s.org.%s!", p.peer, p.org, p.domain)
+
+	return nil
+}
+
+// Uninstall removes chaincode helm releases and TLS secrets from all given organization peers.
+// Note that chaincode definition committed on channel stays unchanged.
+func (c *Chaincode) Uninstall(ctx context.Context, options ...UninstallOption) error {
+	args, err := newUninstallArgs(options...)
+	if err != nil {
+		return err
+	}
+
+	for _, target := range c.targets {
+		if err = c.uninstallRelease(ctx, c.releaseName(target.org, target.peer), args.keepData); err != nil {
+			return err
+		}
+
+		if err = c.deleteSecrets(ctx, fmt.Sprintf(
+			"fabnctl/cid=chaincode.tls.secret,fabnctl/chaincode=%s,fabnctl/host=%s.%s.org",
+			c.chaincodeName, target.peer, target.org,
+		)); err != nil {
+			return err
+		}
+	}
+
+	c.logger.Successf("Chaincode '%s' successfully removed!", c.chaincodeName)
+
+	return nil
+}
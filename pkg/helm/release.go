@@ -0,0 +1,101 @@
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mittwald/go-helm-client"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"sigs.k8s.io/yaml"
+)
+
+// ReleaseHistory retrieves revisions of the release with given `name` ordered from the latest one,
+// or empty slice in case release was never installed.
+func ReleaseHistory(name string) ([]*release.Release, error) {
+	client, ok := Client.(*helmclient.HelmClient)
+	if !ok {
+		return nil, fmt.Errorf("helm client does not provide access to release history")
+	}
+
+	history, err := action.NewHistory(client.ActionConfig).Run(name)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to retrieve '%s' release history: %w", name, err)
+	}
+
+	releaseutil.Reverse(history, releaseutil.SortByRevision)
+
+	return history, nil
+}
+
+// RollbackRelease rolls release with given `name` back to its `revision`,
+// waiting up to `timeout` for its resources to become ready.
+func RollbackRelease(name string, revision int, timeout time.Duration) error {
+	client, ok := Client.(*helmclient.HelmClient)
+	if !ok {
+		return fmt.Errorf("helm client does not provide access to release rollback")
+	}
+
+	rollback := action.NewRollback(client.ActionConfig)
+	rollback.Version = revision
+	rollback.Wait = true
+	rollback.Timeout = timeout
+
+	if err := rollback.Run(name); err != nil {
+		return fmt.Errorf("failed to roll '%s' release back to revision %d: %w", name, revision, err)
+	}
+
+	return nil
+}
+
+// ListReleases retrieves the latest revisions of all releases regardless of their state.
+func ListReleases() ([]*release.Release, error) {
+	client, ok := Client.(*helmclient.HelmClient)
+	if !ok {
+		return nil, fmt.Errorf("helm client does not provide access to releases list")
+	}
+
+	list := action.NewList(client.ActionConfig)
+	list.StateMask = action.ListAll
+
+	releases, err := list.Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list releases: %w", err)
+	}
+
+	return releases, nil
+}
+
+// ManifestResources retrieves names of resources of the given `kind` rendered in the release `manifest`.
+func ManifestResources(manifest, kind string) []string {
+	var names []string
+
+	for _, doc := range releaseutil.SplitManifests(manifest) {
+		var resource struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}
+
+		if err := yaml.Unmarshal([]byte(doc), &resource); err != nil {
+			continue
+		}
+
+		if resource.Kind == kind && len(resource.Metadata.Name) != 0 {
+			names = append(names, resource.Metadata.Name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
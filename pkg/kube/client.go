@@ -1,41 +1,40 @@
 package kube
 
 import (
-	"flag"
-	"path/filepath"
+	"fmt"
 
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
 )
 
 // Client defines shared client interface for Kubernetes cli.
+// It can be substituted, e.g. with the fake clientset in tests.
 var (
-	Client *kubernetes.Clientset
+	Client kubernetes.Interface
 	Config *rest.Config
+
+	initErr error
 )
 
 func init() {
-	var (
-		err        error
-		kubeconfig *string
+	// use the current context in kubeconfig found by $KUBECONFIG or in $HOME/.kube directory:
+	var loader = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{},
 	)
 
-	if home := homedir.HomeDir(); len(home) != 0 {
-		kubeconfig = flag.String("kubeconfig", filepath.Join(home, ".kube", "config"), "(optional) absolute path to the kubeconfig file")
-	} else {
-		kubeconfig = flag.String("kubeconfig", "", "absolute path to the kubeconfig file")
+	if Config, initErr = loader.ClientConfig(); initErr != nil {
+		initErr = fmt.Errorf("failed to load kubeconfig: %w", initErr)
+		return
 	}
-	flag.Parse()
 
-	// use the current context in kubeconfig
-	Config, err = clientcmd.BuildConfigFromFlags("", *kubeconfig)
-	if err != nil {
-		panic(err.Error())
+	if Client, initErr = kubernetes.NewForConfig(Config); initErr != nil {
+		initErr = fmt.Errorf("failed to create Kubernetes client: %w", initErr)
 	}
+}
 
-	if Client, err = kubernetes.NewForConfig(Config); err != nil {
-		panic(err.Error())
-	}
+// Error returns error occurred while constructing Client from kubeconfig, or <nil> if it's ready to be used.
+func Error() error {
+	return initErr
 }
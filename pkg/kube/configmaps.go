@@ -0,0 +1,33 @@
+package kube
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// ConfigMapInterface provides additional methods for dealing with Kubernetes config maps.
+type ConfigMapInterface struct {
+	v1.ConfigMapInterface
+}
+
+// ConfigMapAdapter constructs new ConfigMapInterface adapter instance.
+func ConfigMapAdapter(i v1.ConfigMapInterface) *ConfigMapInterface {
+	return &ConfigMapInterface{
+		ConfigMapInterface: i,
+	}
+}
+
+// CreateOrUpdate takes the representation of a config map and either creates it or update existing one.
+func (i *ConfigMapInterface) CreateOrUpdate(ctx context.Context, configMap corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	if _, err := i.Get(ctx, configMap.Name, metav1.GetOptions{}); kerrors.IsNotFound(err) {
+		return i.Create(ctx, &configMap, metav1.CreateOptions{})
+	} else if err != nil {
+		return nil, err
+	}
+
+	return i.Update(ctx, &configMap, metav1.UpdateOptions{})
+}
@@ -79,7 +79,9 @@ func CopyToPod(
 	return nil
 }
 
-func copyFromPod(
+// CopyFromPod copies file or directory on `srcPath` in the default container of the given pod
+// into the local `destPath`, the same way as 'kubectl cp' command does.
+func CopyFromPod(
 	ctx context.Context,
 	podName, namespace string,
 	srcPath string, destPath string,
@@ -112,21 +114,23 @@ func copyFromPod(
 	)
 
 	go func() {
-		defer writer.Close()
+		var err error
 		if err = execute(ctx, "POST", req.URL(), Config, nil, writer, &stderr); err != nil {
 			if stdErr := term.ErrFromStderr(stderr); stdErr != nil {
 				err = stdErr
 			}
 		}
+		writer.CloseWithError(err)
 	}()
 
 	prefix := getPrefix(srcPath)
 	prefix = path.Clean(prefix)
 	prefix = stripPathShortcuts(prefix)
-	destPath = path.Join(destPath, path.Base(prefix))
-	err = untarAll(reader, destPath, prefix)
+	if err = untarAll(reader, destPath, prefix); err != nil {
+		return fmt.Errorf("failed to copy '%s' from '%s' pod: %w", srcPath, podName, err)
+	}
 
-	return err
+	return nil
 }
 
 func untarAll(reader io.Reader, destDir, prefix string) error {
@@ -0,0 +1,110 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// failedContainerReasons lists reasons of the waiting container state, which won't resolve without intervention.
+var failedContainerReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+}
+
+// AwaitDeploymentReady waits for the latest rollout of deployment with given `name` in given `namespace`
+// to have all its replicas updated and passing readiness checks.
+// Fails early once any of its pods is crash looping or its image can't be pulled.
+func AwaitDeploymentReady(ctx context.Context, name, namespace string) error {
+	ctx, cancel := context.WithTimeout(ctx, viper.GetDuration("k8s.wait_timeout"))
+	defer cancel()
+
+	var ticker = time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		deployment, err := Client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to retrieve '%s' deployment: %w", name, err)
+		}
+
+		if deploymentReady(deployment) {
+			return nil
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return fmt.Errorf("failed to parse '%s' deployment selector: %w", name, err)
+		}
+
+		pods, err := Client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list '%s' deployment pods: %w", name, err)
+		}
+
+		for _, pod := range pods.Items {
+			if reason, message := podFailure(pod); len(reason) != 0 {
+				return fmt.Errorf("pod '%s' of '%s' deployment failed: %s: %s", pod.Name, name, reason, message)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for '%s' deployment readiness: %w", name, ctx.Err())
+		}
+	}
+}
+
+// RestartDeployment triggers rollout of the deployment with given `name` in given `namespace`,
+// which recreates its pods the same way as 'kubectl rollout restart' command does.
+func RestartDeployment(ctx context.Context, name, namespace string) error {
+	var patch = fmt.Sprintf(
+		`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":%q}}}}}`,
+		time.Now().Format(time.RFC3339),
+	)
+
+	if _, err := Client.AppsV1().Deployments(namespace).Patch(
+		ctx, name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{},
+	); err != nil {
+		return fmt.Errorf("failed to restart '%s' deployment: %w", name, err)
+	}
+
+	return nil
+}
+
+// deploymentReady checks whether the latest `deployment` spec is rolled out and all its replicas are ready.
+func deploymentReady(deployment *appsv1.Deployment) bool {
+	var replicas int32 = 1
+
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.ReadyReplicas == replicas &&
+		deployment.Status.Replicas == replicas
+}
+
+// podFailure returns reason and message of the `pod` container failure, if any.
+func podFailure(pod corev1.Pod) (string, string) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && failedContainerReasons[status.State.Waiting.Reason] {
+			return status.State.Waiting.Reason, status.State.Waiting.Message
+		}
+	}
+
+	return "", ""
+}
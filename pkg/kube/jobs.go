@@ -0,0 +1,103 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/spf13/viper"
+	batchv1 "k8s.io/api/batch/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/yaml"
+)
+
+// CreateJobFromFile creates job defined by manifest on given `manifestPath` in given `namespace`.
+// Already existing job is considered stale and gets replaced along with its pods,
+// since they might have already completed and won't be ready ever again.
+func CreateJobFromFile(ctx context.Context, manifestPath, namespace string) (*batchv1.Job, error) {
+	manifest, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job manifest on path '%s': %w", manifestPath, err)
+	}
+
+	var job batchv1.Job
+
+	if err = yaml.Unmarshal(manifest, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode job manifest on path '%s': %w", manifestPath, err)
+	}
+
+	job.Namespace = namespace
+
+	created, err := Client.BatchV1().Jobs(namespace).Create(ctx, &job, metav1.CreateOptions{})
+	if err == nil {
+		return created, nil
+	} else if !kerrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create '%s' job: %w", job.Name, err)
+	}
+
+	if err = deleteJob(ctx, job.Name, namespace); err != nil {
+		return nil, fmt.Errorf("failed to replace stale '%s' job: %w", job.Name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, viper.GetDuration("k8s.wait_timeout"))
+	defer cancel()
+
+	// Job deletion isn't instant, so creation is retried until the stale one is gone:
+	if err = wait.PollImmediateUntil(time.Second, func() (bool, error) {
+		created, err = Client.BatchV1().Jobs(namespace).Create(ctx, &job, metav1.CreateOptions{})
+		if kerrors.IsAlreadyExists(err) {
+			return false, nil
+		}
+
+		return err == nil, err
+	}, ctx.Done()); err != nil {
+		return nil, fmt.Errorf("failed to recreate '%s' job: %w", job.Name, err)
+	}
+
+	return created, nil
+}
+
+// DeleteJobs deletes jobs with given `selector` in given `namespace` along with their pods.
+func DeleteJobs(ctx context.Context, selector, namespace string) error {
+	jobs, err := Client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list jobs with '%s' selector: %w", selector, err)
+	}
+
+	for _, job := range jobs.Items {
+		if err = deleteJob(ctx, job.Name, namespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deleteJob(ctx context.Context, name, namespace string) error {
+	var (
+		propagation       = metav1.DeletePropagationBackground
+		zero        int64 = 0
+	)
+
+	if err := Client.BatchV1().Jobs(namespace).Delete(ctx, name, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	}); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete '%s' job: %w", name, err)
+	}
+
+	// Pods are removed right away, instead of waiting for their graceful termination:
+	if err := Client.CoreV1().Pods(namespace).DeleteCollection(ctx, metav1.DeleteOptions{
+		GracePeriodSeconds: &zero,
+	}, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("job-name=%s", name),
+	}); err != nil {
+		return fmt.Errorf("failed to delete '%s' job pods: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package kube
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const testNamespace = "network"
+
+var (
+	podsResource = corev1.SchemeGroupVersion.WithResource("pods")
+	podsKind     = corev1.SchemeGroupVersion.WithKind("Pod")
+)
+
+const waitJobManifest = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: artifacts.wait
+  labels:
+    fabnctl/cid: artifacts.wait
+spec:
+  template:
+    spec:
+      containers:
+        - name: wait
+          image: busybox
+      restartPolicy: Never
+`
+
+// useFakeClient substitutes Client with the fake clientset, preloaded with given `objects`.
+func useFakeClient(t *testing.T, objects ...runtime.Object) *fake.Clientset {
+	t.Helper()
+
+	var client = fake.NewSimpleClientset(objects...)
+
+	// Fake clientset doesn't implement collection deletion, so it is emulated for pods:
+	client.PrependReactor("delete-collection", "pods",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			var selector = action.(k8stesting.DeleteCollectionAction).GetListRestrictions().Labels
+
+			pods, err := client.Tracker().List(podsResource, podsKind, action.GetNamespace())
+			if err != nil {
+				return true, nil, err
+			}
+
+			for _, pod := range pods.(*corev1.PodList).Items {
+				if selector.Matches(labels.Set(pod.Labels)) {
+					if err = client.Tracker().Delete(podsResource, pod.Namespace, pod.Name); err != nil {
+						return true, nil, err
+					}
+				}
+			}
+
+			return true, nil, nil
+		},
+	)
+
+	var original = Client
+	Client = client
+	t.Cleanup(func() { Client = original })
+
+	return client
+}
+
+func TestCreateJobFromFile(t *testing.T) {
+	useFakeClient(t)
+
+	job, err := CreateJobFromFile(context.Background(), writeManifest(t, waitJobManifest), testNamespace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if job.Name != "artifacts.wait" || job.Namespace != testNamespace {
+		t.Errorf("expected 'artifacts.wait' job in '%s' namespace, got '%s' in '%s'",
+			testNamespace, job.Name, job.Namespace)
+	}
+
+	if job.Labels["fabnctl/cid"] != "artifacts.wait" {
+		t.Errorf("expected job labels from the manifest, got: %v", job.Labels)
+	}
+
+	if _, err = Client.BatchV1().Jobs(testNamespace).Get(context.Background(), job.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("expected job to be created: %v", err)
+	}
+}
+
+func TestCreateJobFromFileReplacesStaleJob(t *testing.T) {
+	useFakeClient(t,
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "artifacts.wait",
+				Namespace: testNamespace,
+				Labels:    map[string]string{"fabnctl/cid": "artifacts.wait"},
+			},
+			Status: batchv1.JobStatus{Succeeded: 1},
+		},
+		jobPod("artifacts.wait-stale", "artifacts.wait", corev1.PodSucceeded),
+	)
+
+	job, err := CreateJobFromFile(context.Background(), writeManifest(t, waitJobManifest), testNamespace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if job.Status.Succeeded != 0 {
+		t.Error("expected stale completed job to be replaced with the new one")
+	}
+
+	assertPods(t, "job-name=artifacts.wait")
+}
+
+func TestCreateJobFromFileErrors(t *testing.T) {
+	useFakeClient(t)
+
+	var tests = []struct {
+		name string
+		path string
+	}{
+		{
+			name: "missing manifest",
+			path: filepath.Join(t.TempDir(), "missing.yaml"),
+		},
+		{
+			name: "invalid manifest",
+			path: writeManifest(t, "kind: [Job"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CreateJobFromFile(context.Background(), tt.path, testNamespace); err == nil {
+				t.Error("expected error, got <nil>")
+			}
+		})
+	}
+}
+
+func TestDeleteJobs(t *testing.T) {
+	useFakeClient(t,
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name:      "artifacts.wait",
+			Namespace: testNamespace,
+			Labels:    map[string]string{"fabnctl/cid": "artifacts.wait"},
+		}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name:      "artifacts.generate",
+			Namespace: testNamespace,
+			Labels:    map[string]string{"fabnctl/cid": "artifacts.generate"},
+		}},
+		jobPod("artifacts.wait-abcde", "artifacts.wait", corev1.PodRunning),
+		jobPod("artifacts.generate-abcde", "artifacts.generate", corev1.PodSucceeded),
+	)
+
+	if err := DeleteJobs(context.Background(), "fabnctl/cid=artifacts.wait", testNamespace); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := Client.BatchV1().Jobs(testNamespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(jobs.Items) != 1 || jobs.Items[0].Name != "artifacts.generate" {
+		t.Errorf("expected only 'artifacts.generate' job to be left, got: %v", jobs.Items)
+	}
+
+	assertPods(t, "job-name=artifacts.wait")
+	assertPods(t, "job-name=artifacts.generate", "artifacts.generate-abcde")
+}
+
+// assertPods checks that pods with given `selector` are exactly the ones with given `names`.
+func assertPods(t *testing.T, selector string, names ...string) {
+	t.Helper()
+
+	pods, err := Client.CoreV1().Pods(testNamespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pods.Items) != len(names) {
+		t.Fatalf("expected %d pods with '%s' selector, got %d", len(names), selector, len(pods.Items))
+	}
+
+	for i, pod := range pods.Items {
+		if pod.Name != names[i] {
+			t.Errorf("expected '%s' pod, got '%s'", names[i], pod.Name)
+		}
+	}
+}
+
+func jobPod(name, job string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{"job-name": job},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func writeManifest(t *testing.T, manifest string) string {
+	t.Helper()
+
+	var manifestPath = filepath.Join(t.TempDir(), "job.yaml")
+	if err := ioutil.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return manifestPath
+}
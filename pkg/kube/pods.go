@@ -0,0 +1,26 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FindPod finds first pod matching given label `selector` in the given `namespace`.
+// Returns <nil> pod when no such pod is found.
+func FindPod(ctx context.Context, namespace, selector string) (*corev1.Pod, error) {
+	pods, err := Client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pod by '%s' selector: %w", selector, err)
+	}
+
+	if pods == nil || len(pods.Items) == 0 {
+		return nil, nil
+	}
+
+	return &pods.Items[0], nil
+}
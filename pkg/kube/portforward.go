@@ -0,0 +1,68 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForward forwards random local port to the `port` of the pod with given `podName` in the given `namespace`.
+// Returns local port the pod is reachable on and the function, which stops forwarding.
+func PortForward(ctx context.Context, podName, namespace string, port int) (int, func(), error) {
+	transport, upgrader, err := spdy.RoundTripperFor(Config)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create port forwarding transport: %w", err)
+	}
+
+	var (
+		url = Client.CoreV1().RESTClient().Post().
+			Resource("pods").
+			Name(podName).
+			Namespace(namespace).
+			SubResource("portforward").
+			URL()
+		dialer  = spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+		stopCh  = make(chan struct{})
+		readyCh = make(chan struct{})
+		errCh   = make(chan error, 1)
+		once    sync.Once
+		stop    = func() {
+			once.Do(func() {
+				close(stopCh)
+			})
+		}
+	)
+
+	forwarder, err := portforward.New(dialer, []string{fmt.Sprintf("0:%d", port)},
+		stopCh, readyCh, ioutil.Discard, ioutil.Discard,
+	)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to forward port of '%s' pod: %w", podName, err)
+	}
+
+	go func() {
+		errCh <- forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-readyCh:
+	case err = <-errCh:
+		return 0, nil, fmt.Errorf("failed to forward port of '%s' pod: %w", podName, err)
+	case <-ctx.Done():
+		stop()
+		return 0, nil, ctx.Err()
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil || len(ports) == 0 {
+		stop()
+		return 0, nil, fmt.Errorf("failed to determine forwarded port of '%s' pod: %v", podName, err)
+	}
+
+	return int(ports[0].Local), stop, nil
+}
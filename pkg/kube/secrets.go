@@ -4,6 +4,7 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
@@ -22,8 +23,10 @@ func SecretAdapter(i v1.SecretInterface) *SecretInterface {
 
 // CreateOrUpdate takes the representation of a secret and either creates it or update existing one.
 func (i *SecretInterface) CreateOrUpdate(ctx context.Context, secret corev1.Secret) (*corev1.Secret, error) {
-	if s, _ := i.Get(ctx, secret.Name, metav1.GetOptions{}); s == nil {
+	if _, err := i.Get(ctx, secret.Name, metav1.GetOptions{}); kerrors.IsNotFound(err) {
 		return i.Create(ctx, &secret, metav1.CreateOptions{})
+	} else if err != nil {
+		return nil, err
 	}
 
 	return i.Update(ctx, &secret, metav1.UpdateOptions{})
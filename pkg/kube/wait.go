@@ -99,6 +99,37 @@ func WaitForPodReady(
 	)
 }
 
+// AwaitPodReady waits for pod with given 'selector' in given 'namespace' to become ready and returns its name.
+// Unlike WaitForPodReady it doesn't log its progress, thus can be used concurrently.
+func AwaitPodReady(ctx context.Context, selector, namespace string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, viper.GetDuration("k8s.wait_timeout"))
+	defer cancel()
+
+	watcher, err := Client.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to wait for pod readiness: %w", err)
+	}
+
+	defer watcher.Stop()
+
+	for {
+		select {
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return "", fmt.Errorf("pod watch with selector '%s' closed unexpectedly", selector)
+			}
+
+			if pod, ok := event.Object.(*corev1.Pod); ok && podutils.IsPodReady(pod) {
+				return pod.Name, nil
+			}
+		case <-ctx.Done():
+			return "", fmt.Errorf("timeout waiting for pod readiness with selector '%s': %w", selector, ctx.Err())
+		}
+	}
+}
+
 // WaitForEvent waits for custom event occurrence.
 func WaitForEvent(
 	ctx context.Context, cancel context.CancelFunc,
@@ -1,11 +1,22 @@
 package model
 
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
 // NetworkConfig defines network deployment configuration structure.
 type NetworkConfig struct {
 	Domain        string         `yaml:"domain" json:"domain"`
 	Orderer       Orderer        `yaml:"orderer" json:"orderer"`
 	Organizations []Organization `yaml:"organizations" json:"organizations"`
 	Channels      []Channel      `yaml:"channels" json:"channels"`
+	Chaincodes    []Chaincode    `yaml:"chaincodes" json:"chaincodes"`
 
 	orgMap      map[string]*Organization
 	channelsMap map[string]*Channel
@@ -13,31 +24,135 @@ type NetworkConfig struct {
 
 // Orderer defines orderer block structure from NetworkConfig.
 type Orderer struct {
-	Name     string `yaml:"name" json:"name"`
+	Name      string `yaml:"name" json:"name"`
+	Type      string `yaml:"type" json:"type"`
+	MspID     string `yaml:"mspID" json:"mspID"`
+	Hostname  string `yaml:"hostname" json:"hostname"`
+	Port      int    `yaml:"port" json:"port"`
+	Profile   string `yaml:"profile" json:"profile"`
+	ChannelID string `yaml:"channelID" json:"channelID"`
+	TLSCert   string `yaml:"-" json:"-"`
+
+	Consenters []Consenter `yaml:"consenters" json:"consenters"`
+}
+
+// Consenter defines single Raft cluster node from the orderer block of NetworkConfig.
+type Consenter struct {
 	Hostname string `yaml:"hostname" json:"hostname"`
-	TLSCert  string `yaml:"-" json:"-"`
+}
+
+// Hostnames returns hostnames of all orderer cluster nodes.
+// When no consenters are declared the single orderer defined by its hostname is assumed.
+func (o Orderer) Hostnames() []string {
+	if len(o.Consenters) == 0 {
+		if len(o.Hostname) == 0 {
+			return nil
+		}
+
+		return []string{o.Hostname}
+	}
+
+	var hostnames = make([]string, 0, len(o.Consenters))
+
+	for _, consenter := range o.Consenters {
+		hostnames = append(hostnames, consenter.Hostname)
+	}
+
+	return hostnames
 }
 
 // Organization defines organization block structure from NetworkConfig.
 type Organization struct {
-	Name     string `yaml:"name" json:"name"`
-	Hostname string `yaml:"hostname" json:"hostname"`
-	MspID    string `yaml:"mspID" json:"mspID"`
-	Peers []struct{
-		Hostname string `yaml:"hostname" json:"hostname"`
-	}
-	TLSCert  string `yaml:"-" json:"-"`
-	CertAuthority struct{
+	Name           string `yaml:"name" json:"name"`
+	Hostname       string `yaml:"hostname" json:"hostname"`
+	MspID          string `yaml:"mspID" json:"mspID"`
+	Peers          []Peer `yaml:"peers" json:"peers"`
+	ChannelProfile string `yaml:"channelProfile" json:"channelProfile"`
+	ChannelID      string `yaml:"channelID" json:"channelID"`
+	TLSCert        string `yaml:"-" json:"-"`
+	CertAuthority  struct {
 		TLSCert string `yaml:"-" json:"-"`
 	} `yaml:"cert_authority" json:"cert_authority"`
 }
 
+// Peer defines organization peer block structure from NetworkConfig.
+type Peer struct {
+	Hostname string `yaml:"hostname" json:"hostname"`
+	Port     int    `yaml:"port" json:"port"`
+}
+
 // Channel defines channel block structure from NetworkConfig.
 type Channel struct {
-	ChannelID string `yaml:"channelID" json:"channelID"`
+	Name          string   `yaml:"name" json:"name"`
+	Profile       string   `yaml:"profile" json:"profile"`
+	ChannelID     string   `yaml:"channelID" json:"channelID"`
+	Consortium    string   `yaml:"consortium" json:"consortium"`
 	Organizations []string `yaml:"organizations" json:"organizations"`
 }
 
+// Chaincode defines chaincode block structure from NetworkConfig.
+type Chaincode struct {
+	Name              string   `yaml:"name" json:"name"`
+	ChannelID         string   `yaml:"channelID" json:"channelID"`
+	Image             string   `yaml:"image" json:"image"`
+	Source            string   `yaml:"source" json:"source"`
+	Type              string   `yaml:"type" json:"type"`
+	Version           Version  `yaml:"version" json:"version"`
+	VersionFromGit    bool     `yaml:"versionFromGit" json:"versionFromGit"`
+	Policy            string   `yaml:"policy" json:"policy"`
+	CollectionsConfig string   `yaml:"collectionsConfig" json:"collectionsConfig"`
+	InitRequired      bool     `yaml:"initRequired" json:"initRequired"`
+	TLS               bool     `yaml:"tls" json:"tls"`
+	Organizations     []string `yaml:"organizations" json:"organizations"`
+}
+
+// Version defines chaincode version, which can be either semantic or free-form one.
+// Numeric values in YAML are accepted as well, though they're better to be quoted, e.g. "1.10".
+type Version string
+
+// UnmarshalJSON decodes Version from either JSON string or number.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	var value interface{}
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch value := value.(type) {
+	case string:
+		*v = Version(value)
+	case float64:
+		var version = strconv.FormatFloat(value, 'f', -1, 64)
+		if !strings.Contains(version, ".") {
+			version += ".0"
+		}
+
+		*v = Version(version)
+	case nil:
+		*v = ""
+	default:
+		return fmt.Errorf("version must be either string or number, got %v", value)
+	}
+
+	return nil
+}
+
+// NetworkConfigFromFile decodes NetworkConfig from YAML file on given `path`.
+func NetworkConfigFromFile(path string) (*NetworkConfig, error) {
+	var config NetworkConfig
+
+	configYaml, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("missing configuration values on path %s: %w", path, err)
+	}
+
+	if err = yaml.Unmarshal(configYaml, &config); err != nil {
+		return nil, fmt.Errorf("failed to decode config found on path: %s: %w", path, err)
+	}
+
+	return &config, nil
+}
+
 // GetChannel finds single Channel config in the NetworkConfig.
 func (n NetworkConfig) GetChannel(channelID string) *Channel {
 	if n.channelsMap == nil {
@@ -80,3 +195,25 @@ func (n NetworkConfig) GetOrganization(orgID string) *Organization {
 
 	return nil
 }
+
+// GetOrganizationByName finds single Organization config in the NetworkConfig by its name.
+func (n NetworkConfig) GetOrganizationByName(name string) *Organization {
+	for i, org := range n.Organizations {
+		if org.Name == name {
+			return &n.Organizations[i]
+		}
+	}
+
+	return nil
+}
+
+// GetChaincode finds single Chaincode config in the NetworkConfig by its name and channel.
+func (n NetworkConfig) GetChaincode(name, channelID string) *Chaincode {
+	for i, cc := range n.Chaincodes {
+		if cc.Name == name && cc.ChannelID == channelID {
+			return &n.Chaincodes[i]
+		}
+	}
+
+	return nil
+}
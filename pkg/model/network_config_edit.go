@@ -0,0 +1,106 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RemoveChannelOrganization removes organization named `orgName` from members of the `channelID` channel
+// and from organizations of the chaincodes deployed on it in the network config YAML file on given `path`.
+// File is edited in place preserving its comments. Returns false if organization wasn't listed there.
+func RemoveChannelOrganization(path, channelID, orgName string) (bool, error) {
+	var (
+		document yaml.Node
+		changed  bool
+	)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("missing configuration values on path %s: %w", path, err)
+	}
+
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("missing configuration values on path %s: %w", path, err)
+	}
+
+	if err = yaml.Unmarshal(payload, &document); err != nil {
+		return false, fmt.Errorf("failed to decode config found on path: %s: %w", path, err)
+	}
+
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return false, fmt.Errorf("config found on path %s is empty", path)
+	}
+
+	for _, section := range []string{"channels", "chaincodes"} {
+		var items = mappingValue(document.Content[0], section)
+		if items == nil || items.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		for _, item := range items.Content {
+			if id := mappingValue(item, "channelID"); id == nil || id.Value != channelID {
+				continue
+			}
+
+			if orgs := mappingValue(item, "organizations"); orgs != nil && orgs.Kind == yaml.SequenceNode {
+				var kept = orgs.Content[:0]
+
+				for _, org := range orgs.Content {
+					if org.Value == orgName {
+						changed = true
+						continue
+					}
+
+					kept = append(kept, org)
+				}
+
+				orgs.Content = kept
+			}
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	var (
+		buffer  bytes.Buffer
+		encoder = yaml.NewEncoder(&buffer)
+	)
+
+	encoder.SetIndent(2)
+
+	if err = encoder.Encode(&document); err != nil {
+		return false, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err = encoder.Close(); err != nil {
+		return false, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err = ioutil.WriteFile(path, buffer.Bytes(), info.Mode()); err != nil {
+		return false, fmt.Errorf("failed to write config on path %s: %w", path, err)
+	}
+
+	return true, nil
+}
+
+// mappingValue finds value node of the `key` in YAML mapping `node`.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
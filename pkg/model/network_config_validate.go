@@ -0,0 +1,271 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var channelIDRegexp = regexp.MustCompile(`^[a-z][a-z0-9.-]*$`)
+
+// ValidationError describes single NetworkConfig violation found on the YAML `Path`.
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors lists all violations found in NetworkConfig.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	var lines = make([]string, 0, len(e))
+
+	for i := range e {
+		lines = append(lines, fmt.Sprintf(" - %s", e[i].Error()))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Validate checks NetworkConfig for missing or inconsistent values.
+// Returns ValidationErrors listing every found violation along with its YAML path, or <nil> if config is valid.
+func (n NetworkConfig) Validate() error {
+	var (
+		errs     ValidationErrors
+		orgNames = make(map[string]string)
+		mspIDs   = make(map[string]string)
+		channels = make(map[string]*Channel)
+	)
+
+	var report = func(path, format string, a ...interface{}) {
+		errs = append(errs, ValidationError{Path: path, Message: fmt.Sprintf(format, a...)})
+	}
+
+	var required = func(path, value string) bool {
+		if len(value) == 0 {
+			report(path, "required value is missing")
+			return false
+		}
+		return true
+	}
+
+	var checkHostname = func(path, value string) {
+		if required(path, value) {
+			if msgs := validation.IsDNS1123Label(value); len(msgs) != 0 {
+				report(path, "invalid hostname '%s': %s", value, strings.Join(msgs, "; "))
+			}
+		}
+	}
+
+	var checkPort = func(path string, port int) {
+		if port <= 0 || port > 65535 {
+			report(path, "port must be in range 1-65535, got %d", port)
+		}
+	}
+
+	var checkChannelID = func(path, value string) {
+		if required(path, value) && (len(value) > 249 || !channelIDRegexp.MatchString(value)) {
+			report(path, "invalid channel ID '%s': must consist of lower case alphanumeric characters, "+
+				"'-' or '.', start with a letter and be less than 250 characters long", value)
+		}
+	}
+
+	// Domain:
+	if required("domain", n.Domain) {
+		if msgs := validation.IsDNS1123Subdomain(n.Domain); len(msgs) != 0 {
+			report("domain", "invalid domain '%s': %s", n.Domain, strings.Join(msgs, "; "))
+		}
+	}
+
+	// Orderer:
+	if required("orderer.name", n.Orderer.Name) {
+		orgNames[n.Orderer.Name] = "orderer.name"
+	}
+
+	if required("orderer.type", n.Orderer.Type) &&
+		n.Orderer.Type != "etcdraft" && n.Orderer.Type != "solo" {
+		report("orderer.type", "unsupported orderer type '%s', must be either 'etcdraft' or 'solo'", n.Orderer.Type)
+	}
+
+	if required("orderer.mspID", n.Orderer.MspID) {
+		mspIDs[n.Orderer.MspID] = "orderer.mspID"
+	}
+
+	// Hostname can be omitted when Raft consenters are declared:
+	if len(n.Orderer.Consenters) == 0 || len(n.Orderer.Hostname) != 0 {
+		checkHostname("orderer.hostname", n.Orderer.Hostname)
+	}
+
+	if n.Orderer.Type == "solo" && len(n.Orderer.Consenters) > 1 {
+		report("orderer.consenters", "'solo' orderer type supports only single node, use 'etcdraft' instead")
+	}
+
+	var consenters = make(map[string]bool)
+
+	for i, consenter := range n.Orderer.Consenters {
+		var path = fmt.Sprintf("orderer.consenters[%d].hostname", i)
+
+		checkHostname(path, consenter.Hostname)
+
+		if consenters[consenter.Hostname] {
+			report(path, "duplicate consenter hostname '%s'", consenter.Hostname)
+		}
+
+		consenters[consenter.Hostname] = true
+	}
+
+	checkPort("orderer.port", n.Orderer.Port)
+	required("orderer.profile", n.Orderer.Profile)
+	checkChannelID("orderer.channelID", n.Orderer.ChannelID)
+
+	// Organizations:
+	if len(n.Organizations) == 0 {
+		report("organizations", "at least one organization must be defined")
+	}
+
+	for i, org := range n.Organizations {
+		var path = fmt.Sprintf("organizations[%d]", i)
+
+		if required(path+".name", org.Name) {
+			if prev, ok := orgNames[org.Name]; ok {
+				report(path+".name", "duplicate name '%s', already defined in %s", org.Name, prev)
+			} else {
+				orgNames[org.Name] = path + ".name"
+			}
+		}
+
+		if required(path+".mspID", org.MspID) {
+			if msgs := validation.IsDNS1123Label(org.MspID); len(msgs) != 0 {
+				report(path+".mspID", "invalid MSP ID '%s': %s", org.MspID, strings.Join(msgs, "; "))
+			}
+
+			if prev, ok := mspIDs[org.MspID]; ok {
+				report(path+".mspID", "duplicate MSP ID '%s', already defined in %s", org.MspID, prev)
+			} else {
+				mspIDs[org.MspID] = path + ".mspID"
+			}
+		}
+
+		if required(path+".hostname", org.Hostname) {
+			if msgs := validation.IsDNS1123Subdomain(org.Hostname); len(msgs) != 0 {
+				report(path+".hostname", "invalid hostname '%s': %s", org.Hostname, strings.Join(msgs, "; "))
+			}
+		}
+
+		if len(org.Peers) == 0 {
+			report(path+".peers", "at least one peer must be defined")
+		}
+
+		var peers = make(map[string]bool)
+
+		for j, peer := range org.Peers {
+			var peerPath = fmt.Sprintf("%s.peers[%d]", path, j)
+
+			checkHostname(peerPath+".hostname", peer.Hostname)
+			checkPort(peerPath+".port", peer.Port)
+
+			if peers[peer.Hostname] {
+				report(peerPath+".hostname", "duplicate peer hostname '%s'", peer.Hostname)
+			}
+
+			peers[peer.Hostname] = true
+		}
+	}
+
+	// Channels:
+	for i := range n.Channels {
+		var (
+			ch   = &n.Channels[i]
+			path = fmt.Sprintf("channels[%d]", i)
+		)
+
+		checkChannelID(path+".channelID", ch.ChannelID)
+
+		if _, ok := channels[ch.ChannelID]; ok {
+			report(path+".channelID", "duplicate channel ID '%s'", ch.ChannelID)
+		} else if ch.ChannelID == n.Orderer.ChannelID {
+			report(path+".channelID", "channel ID '%s' is reserved for the orderer system channel", ch.ChannelID)
+		}
+
+		channels[ch.ChannelID] = ch
+
+		required(path+".name", ch.Name)
+		required(path+".profile", ch.Profile)
+
+		if len(ch.Organizations) == 0 {
+			report(path+".organizations", "at least one organization must be defined")
+		}
+
+		var members = make(map[string]bool)
+
+		for j, orgName := range ch.Organizations {
+			var orgPath = fmt.Sprintf("%s.organizations[%d]", path, j)
+
+			if n.GetOrganizationByName(orgName) == nil {
+				report(orgPath, "unknown organization '%s'", orgName)
+			}
+
+			if members[orgName] {
+				report(orgPath, "organization '%s' is listed more than once", orgName)
+			}
+
+			members[orgName] = true
+		}
+	}
+
+	for i, org := range n.Organizations {
+		if len(org.ChannelID) != 0 && channels[org.ChannelID] == nil {
+			report(fmt.Sprintf("organizations[%d].channelID", i), "unknown channel '%s'", org.ChannelID)
+		}
+	}
+
+	// Chaincodes:
+	var chaincodes = make(map[string]bool)
+
+	for i, cc := range n.Chaincodes {
+		var path = fmt.Sprintf("chaincodes[%d]", i)
+
+		checkHostname(path+".name", cc.Name)
+
+		ch, found := channels[cc.ChannelID]
+		if required(path+".channelID", cc.ChannelID) && !found {
+			report(path+".channelID", "unknown channel '%s'", cc.ChannelID)
+		}
+
+		var key = fmt.Sprintf("%s@%s", cc.Name, cc.ChannelID)
+		if chaincodes[key] {
+			report(path+".name", "chaincode '%s' is defined more than once for '%s' channel", cc.Name, cc.ChannelID)
+		}
+
+		chaincodes[key] = true
+
+		if len(cc.Organizations) == 0 {
+			report(path+".organizations", "at least one organization must be defined")
+		}
+
+		for j, orgName := range cc.Organizations {
+			var orgPath = fmt.Sprintf("%s.organizations[%d]", path, j)
+
+			if n.GetOrganizationByName(orgName) == nil {
+				report(orgPath, "unknown organization '%s'", orgName)
+				continue
+			}
+
+			if found && !ch.HasOrganization(orgName) {
+				report(orgPath, "organization '%s' isn't a member of '%s' channel", orgName, cc.ChannelID)
+			}
+		}
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// validConfig forms NetworkConfig, which passes validation.
+func validConfig() NetworkConfig {
+	return NetworkConfig{
+		Domain: "example.com",
+		Orderer: Orderer{
+			Name:      "Orderer",
+			Type:      "etcdraft",
+			MspID:     "orderer",
+			Hostname:  "orderer",
+			Port:      7050,
+			Profile:   "OrdererGenesis",
+			ChannelID: "system-channel",
+		},
+		Organizations: []Organization{
+			{
+				Name:      "Org1",
+				MspID:     "org1",
+				Hostname:  "org1",
+				Peers:     []Peer{{Hostname: "peer0", Port: 7051}},
+				ChannelID: "supply-channel",
+			},
+			{
+				Name:     "Org2",
+				MspID:    "org2",
+				Hostname: "org2",
+				Peers:    []Peer{{Hostname: "peer0", Port: 7051}},
+			},
+		},
+		Channels: []Channel{
+			{
+				Name:          "SupplyChannel",
+				Profile:       "SupplyProfile",
+				ChannelID:     "supply-channel",
+				Organizations: []string{"Org1", "Org2"},
+			},
+		},
+		Chaincodes: []Chaincode{
+			{
+				Name:          "assets",
+				ChannelID:     "supply-channel",
+				Organizations: []string{"Org1", "Org2"},
+			},
+		},
+	}
+}
+
+func TestNetworkConfigValidate(t *testing.T) {
+	var tests = []struct {
+		name   string
+		modify func(n *NetworkConfig)
+		paths  []string
+	}{
+		{
+			name:   "valid config",
+			modify: func(n *NetworkConfig) {},
+		},
+		{
+			name: "missing domain",
+			modify: func(n *NetworkConfig) {
+				n.Domain = ""
+			},
+			paths: []string{"domain"},
+		},
+		{
+			name: "duplicate MSP IDs",
+			modify: func(n *NetworkConfig) {
+				n.Organizations[1].MspID = "org1"
+				n.Orderer.MspID = "org1"
+			},
+			paths: []string{"organizations[0].mspID", "organizations[1].mspID"},
+		},
+		{
+			name: "unknown channel organizations",
+			modify: func(n *NetworkConfig) {
+				n.Channels[0].Organizations = []string{"Org1", "Org3", "Org1"}
+			},
+			paths: []string{
+				"channels[0].organizations[1]",
+				"channels[0].organizations[2]",
+				"chaincodes[0].organizations[1]",
+			},
+		},
+		{
+			name: "bad hostnames",
+			modify: func(n *NetworkConfig) {
+				n.Orderer.Hostname = "Orderer_0"
+				n.Organizations[0].Hostname = "org1..example"
+				n.Organizations[1].Peers = append(n.Organizations[1].Peers, Peer{Hostname: "peer.1", Port: 7051})
+			},
+			paths: []string{
+				"orderer.hostname",
+				"organizations[0].hostname",
+				"organizations[1].peers[1].hostname",
+			},
+		},
+		{
+			name: "missing ports",
+			modify: func(n *NetworkConfig) {
+				n.Orderer.Port = 0
+				n.Organizations[0].Peers[0].Port = 70510
+			},
+			paths: []string{"orderer.port", "organizations[0].peers[0].port"},
+		},
+		{
+			name: "profile differs from channel name",
+			modify: func(n *NetworkConfig) {
+				n.Channels[0].Profile = "AnotherProfile"
+			},
+		},
+		{
+			name: "unknown channels",
+			modify: func(n *NetworkConfig) {
+				n.Organizations[1].ChannelID = "missing-channel"
+				n.Chaincodes[0].ChannelID = "missing-channel"
+			},
+			paths: []string{"organizations[1].channelID", "chaincodes[0].channelID"},
+		},
+		{
+			name: "orderer system channel reused",
+			modify: func(n *NetworkConfig) {
+				n.Channels[0].ChannelID = "system-channel"
+				n.Organizations[0].ChannelID = ""
+				n.Chaincodes = nil
+			},
+			paths: []string{"channels[0].channelID"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config = validConfig()
+			tt.modify(&config)
+
+			var (
+				err   = config.Validate()
+				paths []string
+				errs  ValidationErrors
+			)
+
+			if err != nil && !errors.As(err, &errs) {
+				t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+			}
+
+			for i := range errs {
+				paths = append(paths, errs[i].Path)
+			}
+
+			if !reflect.DeepEqual(paths, tt.paths) {
+				t.Errorf("expected violations on %v, got:\n%v", tt.paths, err)
+			}
+		})
+	}
+}
@@ -68,6 +68,14 @@ func (l *Logger) Okf(format string, a ...interface{}) {
 	l.Ok(fmt.Sprintf(format, a...))
 }
 
+func (l *Logger) Warning(message string) {
+	_, _ = fmt.Fprintln(l.stderr, viper.GetString("cli.warning_emoji"), aec.YellowF, message, aec.DefaultF)
+}
+
+func (l *Logger) Warningf(format string, a ...interface{}) {
+	l.Warning(fmt.Sprintf(format, a...))
+}
+
 func (l *Logger) Errorf(err error, format string, a ...interface{}) {
 	_, _ = fmt.Fprintln(l.stderr, aec.LightRedF,
 		fmt.Sprintf("%s: %v", fmt.Sprintf(format, a...), err), aec.DefaultF,
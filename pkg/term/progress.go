@@ -0,0 +1,151 @@
+package term
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gernest/wow/spin"
+	"github.com/morikuni/aec"
+)
+
+// Progress displays interactive multi-line progress of the concurrently performed tasks,
+// where each task has its own line, which is updated in place.
+type Progress struct {
+	logger   *Logger
+	mutex    sync.Mutex
+	keys     []string
+	lines    map[string]*progressLine
+	frames   []string
+	frame    int
+	rendered int
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+type progressLine struct {
+	text     string
+	level    LogStreamLevel
+	finished bool
+}
+
+// NewProgress constructs new Progress with line for each of the `keys`.
+func (l *Logger) NewProgress(keys ...string) *Progress {
+	var progress = &Progress{
+		logger: l,
+		keys:   keys,
+		lines:  make(map[string]*progressLine, len(keys)),
+		frames: spin.Get(spin.Dots).Frames,
+	}
+
+	for _, key := range keys {
+		progress.lines[key] = &progressLine{text: "pending"}
+	}
+
+	return progress
+}
+
+// Start begins rendering progress lines until Stop is called.
+func (p *Progress) Start() {
+	p.stop, p.stopped = make(chan struct{}), make(chan struct{})
+
+	go func() {
+		var ticker = time.NewTicker(100 * time.Millisecond)
+
+		defer close(p.stopped)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				p.render()
+			case <-p.stop:
+				p.render()
+				return
+			}
+		}
+	}()
+}
+
+// Stop renders the final state of progress lines and stops updating them.
+func (p *Progress) Stop() {
+	if p.stop == nil {
+		return
+	}
+
+	close(p.stop)
+	<-p.stopped
+	p.stop = nil
+}
+
+// Textf updates text of the `key` line, which is displayed along with spinner.
+func (p *Progress) Textf(key, format string, a ...interface{}) {
+	p.update(key, fmt.Sprintf(format, a...), LogStreamInfo, false)
+}
+
+// Complete marks the `key` line as successfully finished with `message`.
+func (p *Progress) Complete(key, message string) {
+	p.update(key, message, LogStreamSuccess, true)
+}
+
+// Fail marks the `key` line as failed with `err`.
+func (p *Progress) Fail(key string, err error) {
+	p.update(key, err.Error(), LogStreamError, true)
+}
+
+// Persist marks the `key` line as finished with custom `level` and `message`.
+func (p *Progress) Persist(key string, level LogStreamLevel, message string) {
+	p.update(key, message, level, true)
+}
+
+func (p *Progress) update(key, text string, level LogStreamLevel, finished bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	line, ok := p.lines[key]
+	if !ok {
+		line = &progressLine{}
+		p.lines[key] = line
+		p.keys = append(p.keys, key)
+	}
+
+	// Line breaks would shift rendered lines, thus text is kept single-line:
+	line.text = strings.Join(strings.Fields(text), " ")
+	line.level, line.finished = level, finished
+}
+
+func (p *Progress) render() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var (
+		builder strings.Builder
+		frame   = p.frames[p.frame%len(p.frames)]
+	)
+
+	p.frame++
+
+	if p.rendered > 0 {
+		builder.WriteString(aec.Up(uint(p.rendered)).String())
+	}
+
+	for _, key := range p.keys {
+		var (
+			line = p.lines[key]
+			icon = frame
+		)
+
+		if line.finished {
+			icon = p.logger.StreamSpinners[line.level].Frames[0]
+		}
+
+		builder.WriteString(aec.Column(1).String())
+		builder.WriteString(aec.EraseLine(aec.EraseModes.All).String())
+		builder.WriteString(fmt.Sprintf("%s %s: %s\n", icon, key, line.text))
+	}
+
+	p.rendered = len(p.keys)
+
+	_, _ = fmt.Fprint(p.logger.stderr, builder.String())
+}
@@ -0,0 +1,31 @@
+package term
+
+import (
+	"strings"
+
+	"github.com/manifoldco/promptui"
+)
+
+// PromptConfirm asks whether the action described by `label` should be performed.
+// Returns true only in case of the explicit 'yes' answer.
+func (l *Logger) PromptConfirm(label string) bool {
+	prompt := promptui.Prompt{
+		Label:     label,
+		IsConfirm: true,
+		Templates: &promptui.PromptTemplates{
+			Confirm: "❓ {{ . }} [y/N]: ",
+		},
+	}
+
+	answer, err := prompt.Run()
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	semverRegexp         = regexp.MustCompile(`^(v?\d+\.\d+\.)(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+	trailingNumberRegexp = regexp.MustCompile(`^(.*?)(\d+)$`)
+)
+
+// NextVersion forms version following the given one.
+// Semantic version gets its patch number incremented ('1.2.3' -> '1.2.4'),
+// unless it is a pre-release, which gets its pre-release number incremented instead
+// ('1.2.3-rc1' -> '1.2.3-rc2', '1.2.3-beta' -> '1.2.3-beta.1'). Build metadata is dropped.
+// Otherwise the trailing number is incremented ('1.9' -> '1.10', 'rc1' -> 'rc2'),
+// while free-form version without trailing number gets '.1' suffix ('alpha' -> 'alpha.1').
+func NextVersion(version string) string {
+	if match := semverRegexp.FindStringSubmatch(version); match != nil {
+		if prerelease := match[3]; len(prerelease) != 0 {
+			return fmt.Sprintf("%s%s-%s", match[1], match[2], nextNumber(prerelease))
+		}
+
+		patch, _ := strconv.Atoi(match[2])
+		return fmt.Sprintf("%s%d", match[1], patch+1)
+	}
+
+	if len(version) == 0 {
+		return "1.0"
+	}
+
+	return nextNumber(version)
+}
+
+// nextNumber increments trailing number of the `value`, or appends '.1' to it when there is none.
+func nextNumber(value string) string {
+	if match := trailingNumberRegexp.FindStringSubmatch(value); match != nil {
+		if number, err := strconv.Atoi(match[2]); err == nil {
+			return fmt.Sprintf("%s%d", match[1], number+1)
+		}
+	}
+
+	return value + ".1"
+}
+
+// VersionFromGit determines version of the source code located in `dir` git repository.
+// It is either the tag pointing to the current commit, or the abbreviated commit hash otherwise.
+// Version gets '-dirty' suffix when repository has uncommitted changes.
+func VersionFromGit(dir string) (string, error) {
+	var git = func(args ...string) (string, error) {
+		var stdout, stderr bytes.Buffer
+
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		return strings.TrimSpace(stdout.String()), nil
+	}
+
+	version, err := git("describe", "--tags", "--exact-match")
+	if err != nil {
+		if version, err = git("rev-parse", "--short", "HEAD"); err != nil {
+			return "", fmt.Errorf("failed to determine git version of '%s': %w", dir, err)
+		}
+	}
+
+	if status, err := git("status", "--porcelain"); err == nil && len(status) != 0 {
+		version += "-dirty"
+	}
+
+	return version, nil
+}
@@ -0,0 +1,34 @@
+package util
+
+import "testing"
+
+func TestNextVersion(t *testing.T) {
+	var tests = []struct {
+		version  string
+		expected string
+	}{
+		{version: "", expected: "1.0"},
+		{version: "1.0", expected: "1.1"},
+		{version: "1.9", expected: "1.10"},
+		{version: "1", expected: "2"},
+		{version: "1.2.3", expected: "1.2.4"},
+		{version: "v1.2.3", expected: "v1.2.4"},
+		{version: "1.2.9", expected: "1.2.10"},
+		{version: "1.2.3-rc1", expected: "1.2.3-rc2"},
+		{version: "v1.2.3-rc.9", expected: "v1.2.3-rc.10"},
+		{version: "1.2.3-beta", expected: "1.2.3-beta.1"},
+		{version: "1.2.3-rc1+build.5", expected: "1.2.3-rc2"},
+		{version: "1.2.3+build.5", expected: "1.2.4"},
+		{version: "rc1", expected: "rc2"},
+		{version: "alpha", expected: "alpha.1"},
+		{version: "1.0-beta", expected: "1.0-beta.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if version := NextVersion(tt.version); version != tt.expected {
+				t.Errorf("expected '%s' -> '%s', got '%s'", tt.version, tt.expected, version)
+			}
+		})
+	}
+}